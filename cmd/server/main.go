@@ -1,58 +1,468 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
+	"log/slog"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 
+	"github.com/Pratham700/github-search-service/internal/config"
+	"github.com/Pratham700/github-search-service/internal/gateway"
+	"github.com/Pratham700/github-search-service/internal/github"
+	"github.com/Pratham700/github-search-service/internal/logging"
+	"github.com/Pratham700/github-search-service/internal/metrics"
 	"github.com/Pratham700/github-search-service/internal/server"
+	"github.com/Pratham700/github-search-service/internal/store"
+	"github.com/Pratham700/github-search-service/internal/tracing"
 	pb "github.com/Pratham700/github-search-service/proto/proto"
 )
 
+// historyPruneInterval is how often expired searches are dropped from the history.
+const historyPruneInterval = time.Hour
+
 func main() {
-	// Set the port for the gRPC server
-	var port int
-	flag.IntVar(&port, "port", 50051, "The port number to listen on")
-	flag.Parse() // Parse the command-line flags
-	log.Printf("Using gRPC server port: %d", port)
+	configPath, printConfig, applyFlags := parseFlags()
+	loadConfig := configLoader(configPath, applyFlags)
+	cfg, err := loadConfig()
+	if err != nil {
+		log.Fatalf("invalid configuration: %v", err)
+	}
+	if printConfig {
+		out, err := cfg.Redacted().Marshal()
+		if err != nil {
+			log.Fatalf("failed to render configuration: %v", err)
+		}
+		os.Stdout.Write(out)
+		return
+	}
+	logLevel := setupLogging(cfg.Logging)
+	slog.Info("Using gRPC listen address", "address", cfg.Listen.Address)
 
-	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	listener, err := net.Listen("tcp", cfg.Listen.Address)
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
 	defer listener.Close()
 
-	// Create a new gRPC server
-	s := grpc.NewServer(grpc.UnaryInterceptor(server.AuthInterceptor))
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Config{
+		Exporter:    cfg.Tracing.Exporter,
+		Endpoint:    cfg.Tracing.Endpoint,
+		Insecure:    cfg.Tracing.Insecure,
+		SampleRatio: cfg.Tracing.SampleRatio,
+		ServiceName: cfg.Tracing.ServiceName,
+	})
+	if err != nil {
+		log.Fatalf("failed to set up tracing: %v", err)
+	}
 
-	githubServer, err := server.NewGithubSearchServer() // Capture the returned error
+	// Create a new gRPC server; every call is traced, measured, logged and
+	// optionally audited, and quotas are enforced after the caller is authenticated
+	quota := server.NewQuotaLimiter(server.QuotaConfig{
+		RequestsPerMinute: cfg.Quota.RequestsPerMinute,
+		ResultsPerDay:     cfg.Quota.ResultsPerDay,
+	})
+	options := server.Options{
+		Quota: quota,
+		Batch: server.BatchConfig{
+			MaxQueries:     cfg.Batch.MaxQueries,
+			MaxConcurrency: cfg.Batch.MaxConcurrency,
+		},
+		Facets: server.FacetConfig{MaxResults: cfg.Facets.MaxResults},
+		Exhaustive: server.ExhaustiveConfig{
+			MaxQueries: cfg.Exhaustive.MaxQueries,
+			MaxResults: cfg.Exhaustive.MaxResults,
+		},
+		Cache: server.CacheConfig{
+			TTL:        cfg.GitHub.Cache.TTL.Duration(),
+			LoginTTL:   cfg.GitHub.Cache.LoginTTL.Duration(),
+			MaxEntries: cfg.GitHub.Cache.MaxEntries,
+		},
+	}
+	if cfg.StorageRequired() {
+		st, err := store.Open(cfg.Storage.Path)
+		if err != nil {
+			log.Fatalf("failed to open store: %v", err)
+		}
+		defer st.Close()
+		if cfg.History.Enabled {
+			options.History = server.NewSearchHistory(st, store.HistoryRetention{
+				MaxEntries: cfg.History.MaxEntries,
+				MaxAge:     cfg.History.Retention.Duration(),
+			})
+		}
+		if cfg.Saved.Enabled {
+			options.SavedSearches = st
+		}
+		if cfg.Watches.Enabled {
+			key, _ := cfg.Watches.Key() // Checked by Validate
+			options.Watches, err = server.NewWatches(st, server.WatchConfig{
+				MinInterval: cfg.Watches.MinInterval.Duration(),
+				MaxResults:  cfg.Watches.MaxResults,
+				MaxEvents:   cfg.Watches.MaxEvents,
+			}, key)
+			if err != nil {
+				log.Fatalf("failed to set up watches: %v", err)
+			}
+		}
+		if cfg.Webhooks.Enabled {
+			options.Webhooks = server.NewWebhooks(options.Watches, server.WebhookConfig{
+				MaxAttempts:          cfg.Webhooks.MaxAttempts,
+				InitialBackoff:       cfg.Webhooks.InitialBackoff.Duration(),
+				MaxBackoff:           cfg.Webhooks.MaxBackoff.Duration(),
+				Timeout:              cfg.Webhooks.Timeout.Duration(),
+				MaxDeliveries:        cfg.Webhooks.MaxDeliveries,
+				AllowedHosts:         cfg.Webhooks.AllowedHosts,
+				AllowPrivateNetworks: cfg.Webhooks.AllowPrivateNetworks,
+			})
+		}
+		if cfg.Snapshots.Enabled {
+			options.Snapshots = server.NewSnapshots(st, server.SnapshotConfig{
+				MaxResults:   cfg.Snapshots.MaxResults,
+				MaxPerCaller: cfg.Snapshots.MaxPerCaller,
+			})
+		}
+	}
+	githubServer, err := server.NewGithubSearchServer(github.NewGitHubClient(gitHubClientConfig(cfg.GitHub)), options)
 	if err != nil {
 		log.Fatalf("failed to create GithubSearchServer: %v", err)
 		return
 	}
+	interceptors := []grpc.UnaryServerInterceptor{tracing.UnaryServerInterceptor, metrics.UnaryServerInterceptor, server.LoggingInterceptor}
+	streamInterceptors := []grpc.StreamServerInterceptor{tracing.StreamServerInterceptor, metrics.StreamServerInterceptor, server.LoggingStreamInterceptor}
+	if cfg.Audit.Enabled {
+		// Audit ahead of authentication so that rejected calls are recorded too
+		sink, err := server.NewFileAuditSink(server.FileAuditOptions{
+			Path:          cfg.Audit.Path,
+			MaxSize:       int64(cfg.Audit.MaxSizeMB) << 20,
+			MaxBackups:    cfg.Audit.MaxBackups,
+			Fsync:         cfg.Audit.Fsync,
+			FsyncInterval: cfg.Audit.FsyncInterval.Duration(),
+		})
+		if err != nil {
+			log.Fatalf("failed to open audit log: %v", err)
+		}
+		auditor := server.NewAuditor(sink, githubServer)
+		defer auditor.Close()
+		githubServer.SetAuditor(auditor)
+		interceptors = append(interceptors, auditor.UnaryInterceptor)
+		streamInterceptors = append(streamInterceptors, auditor.StreamInterceptor)
+	}
+	interceptors = append(interceptors, server.AuthInterceptor, quota.UnaryInterceptor)
+	streamInterceptors = append(streamInterceptors, server.AuthStreamInterceptor, quota.StreamInterceptor)
+	interceptor := server.ChainUnaryInterceptors(interceptors...)
+	opts := []grpc.ServerOption{
+		grpc.UnaryInterceptor(interceptor),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if options.History != nil {
+		go options.History.Run(ctx, historyPruneInterval)
+	}
+	if options.Watches != nil {
+		go githubServer.RunWatches(ctx, cfg.Watches.PollInterval.Duration())
+	}
+	if options.Webhooks != nil {
+		go options.Webhooks.Run(ctx, cfg.Watches.PollInterval.Duration())
+	}
+	var certReloader *server.CertReloader
+	if cfg.TLS.Enabled {
+		certReloader, err = server.NewCertReloader(tlsOptions(cfg.TLS))
+		if err != nil {
+			log.Fatalf("failed to load TLS credentials: %v", err)
+		}
+		if interval := cfg.TLS.ReloadInterval.Duration(); interval > 0 {
+			go certReloader.Watch(ctx, interval)
+		}
+		opts = append(opts, grpc.Creds(credentials.NewTLS(certReloader.TLSConfig("h2"))))
+	}
+	s := grpc.NewServer(opts...)
+
 	pb.RegisterGithubSearchServiceServer(s, githubServer)
 
+	// Report readiness through the standard health service
+	healthChecker := server.NewHealthChecker(githubServer, cfg.GitHub.Token, cfg.Health.ProbeTimeout.Duration())
+	healthpb.RegisterHealthServer(s, healthChecker.Server())
+	healthChecker.SetConfigLoaded(true)
+	go healthChecker.Run(ctx, cfg.Health.ProbeInterval.Duration())
+
+	if cfg.Reflection.Enabled {
+		reflection.Register(s)
+	}
+
 	// Start the gRPC server in a separate goroutine
 	go func() {
-		log.Printf("gRPC server listening on port %d", port)
+		slog.Info("gRPC server listening", "address", listener.Addr().String())
 		if err := s.Serve(listener); err != nil {
 			log.Fatalf("failed to serve: %v", err)
 		}
 	}()
+	healthChecker.SetListening(true)
+
+	// Serve the REST/JSON gateway, through the same interceptors, and gRPC-Web
+	// for clients that cannot speak native gRPC
+	var gatewayServer *http.Server
+	if cfg.Gateway.Enabled || cfg.Gateway.GRPCWeb {
+		var handler http.Handler = http.NotFoundHandler()
+		if cfg.Gateway.Enabled {
+			handler = gateway.WithCORS(cfg.Gateway.AllowedOrigins, gateway.New(githubServer, interceptor))
+		}
+		if cfg.Gateway.GRPCWeb {
+			handler = gateway.WithGRPCWeb(s, cfg.Gateway.AllowedOrigins, handler)
+		}
+		gatewayServer = &http.Server{
+			Addr:              cfg.Gateway.Address,
+			Handler:           handler,
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		if certReloader != nil {
+			gatewayServer.TLSConfig = certReloader.TLSConfig("h2", "http/1.1")
+		}
+		go func() {
+			slog.Info("HTTP gateway listening", "address", cfg.Gateway.Address)
+			var err error
+			if gatewayServer.TLSConfig != nil {
+				err = gatewayServer.ListenAndServeTLS("", "")
+			} else {
+				err = gatewayServer.ListenAndServe()
+			}
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Fatalf("failed to serve gateway: %v", err)
+			}
+		}()
+	}
+
+	// Expose Prometheus metrics
+	var metricsServer *http.Server
+	if cfg.Metrics.Enabled {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", metrics.Handler())
+		metricsServer = &http.Server{
+			Addr:              cfg.Metrics.Address,
+			Handler:           mux,
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		go func() {
+			slog.Info("Metrics listening", "address", cfg.Metrics.Address)
+			if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Fatalf("failed to serve metrics: %v", err)
+			}
+		}()
+	}
+
+	// Reload the configuration on SIGHUP and, if enabled, when the file changes
+	r := &reloader{
+		load:          loadConfig,
+		current:       cfg,
+		logLevel:      logLevel,
+		quota:         quota,
+		githubServer:  githubServer,
+		healthChecker: healthChecker,
+	}
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go func() {
+		for range hup {
+			r.reload("SIGHUP")
+		}
+	}()
+	if configPath != "" && cfg.Reload.WatchInterval > 0 {
+		go watchFile(configPath, cfg.Reload.WatchInterval.Duration(), func() { r.reload("config file change") })
+	}
 
 	// Wait for a signal to quit
 	ch := make(chan os.Signal, 1)
 	signal.Notify(ch, syscall.SIGINT, syscall.SIGTERM)
 	<-ch
-	log.Println("Stopping gRPC server...")
+	slog.Info("Stopping gRPC server...")
+	healthChecker.Shutdown()
+
+	// Keep serving while load balancers notice NOT_SERVING; a second signal skips the wait
+	if delay := cfg.Health.ShutdownDelay.Duration(); delay > 0 {
+		slog.Info("Draining before stopping", "delay", delay)
+		select {
+		case <-time.After(delay):
+		case <-ch:
+		}
+	}
+	if options.Watches != nil {
+		options.Watches.Shutdown()
+	}
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), cfg.Listen.ShutdownTimeout.Duration())
+	defer cancelShutdown()
+	if gatewayServer != nil {
+		if err := gatewayServer.Shutdown(shutdownCtx); err != nil {
+			slog.Error("failed to stop gateway gracefully", "error", err)
+		}
+	}
+	if metricsServer != nil {
+		defer metricsServer.Close()
+	}
+
+	// Stop the gRPC server gracefully, cutting off RPCs that outlive the shutdown timeout
+	stopped := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-shutdownCtx.Done():
+		slog.Warn("Shutdown timeout exceeded, forcing stop")
+		s.Stop()
+	}
+	slog.Info("gRPC server stopped")
+
+	// Write the searches still waiting to be recorded before the store closes
+	if options.History != nil {
+		options.History.Close()
+	}
+
+	// Flush the spans of the last calls
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		slog.Error("failed to flush traces", "error", err)
+	}
+}
+
+// parseFlags parses the command line. It returns the config file path, whether
+// to print the config and exit, and a function applying the flags that were
+// given explicitly on top of a loaded configuration.
+func parseFlags() (string, bool, func(*config.Config)) {
+	var (
+		configPath  string
+		printConfig bool
+		port        int
+		overrides   config.Config
+	)
+	flag.StringVar(&configPath, "config", "", "Path to a YAML or TOML config file")
+	flag.BoolVar(&printConfig, "print-config", false, "Print the effective configuration with secrets redacted and exit")
+	flag.IntVar(&port, "port", 50051, "The port number to listen on (shorthand for -listen-address=:<port>)")
+	flag.StringVar(&overrides.Listen.Address, "listen-address", "", "The address to listen on")
+	flag.StringVar(&overrides.GitHub.BaseURL, "github-base-url", "", "The GitHub API base URL")
+	flag.BoolVar(&overrides.TLS.Enabled, "tls", false, "Serve gRPC over TLS")
+	flag.StringVar(&overrides.TLS.CertFile, "tls-cert-file", "", "Path to the TLS certificate")
+	flag.StringVar(&overrides.TLS.KeyFile, "tls-key-file", "", "Path to the TLS private key")
+	flag.StringVar(&overrides.TLS.ClientCAFile, "tls-client-ca-file", "", "Path to the CA bundle used to verify client certificates (enables mutual TLS)")
+	flag.IntVar(&overrides.Quota.RequestsPerMinute, "quota-requests-per-minute", 0, "Requests allowed per caller per minute (0 disables the quota)")
+	flag.IntVar(&overrides.Quota.ResultsPerDay, "quota-results-per-day", 0, "Search results returned per caller per day (0 disables the quota)")
+	flag.StringVar(&overrides.Gateway.Address, "gateway-address", "", "Serve the REST/JSON gateway on this address")
+	flag.BoolVar(&overrides.Gateway.GRPCWeb, "grpc-web", false, "Serve gRPC-Web on the gateway address")
+	flag.StringVar(&overrides.Metrics.Address, "metrics-address", "", "Serve Prometheus metrics on this address")
+	flag.BoolVar(&overrides.Reflection.Enabled, "reflection", false, "Register gRPC server reflection")
+	flag.StringVar(&overrides.Tracing.Exporter, "trace-exporter", "", "Trace exporter: none, stdout or otlp")
+	flag.StringVar(&overrides.Logging.Level, "log-level", "", "Log level: debug, info, warn or error")
+	flag.StringVar(&overrides.Logging.Format, "log-format", "", "Log format: text or json")
+	flag.Parse() // Parse the command-line flags
+
+	applyFlags := func(cfg *config.Config) {
+		// Only flags given explicitly override the file and the environment
+		flag.Visit(func(f *flag.Flag) {
+			switch f.Name {
+			case "port":
+				cfg.Listen.Address = fmt.Sprintf(":%d", port)
+			case "listen-address":
+				cfg.Listen.Address = overrides.Listen.Address
+			case "github-base-url":
+				cfg.GitHub.BaseURL = overrides.GitHub.BaseURL
+			case "tls":
+				cfg.TLS.Enabled = overrides.TLS.Enabled
+			case "tls-cert-file":
+				cfg.TLS.CertFile = overrides.TLS.CertFile
+			case "tls-key-file":
+				cfg.TLS.KeyFile = overrides.TLS.KeyFile
+			case "tls-client-ca-file":
+				cfg.TLS.ClientCAFile = overrides.TLS.ClientCAFile
+			case "quota-requests-per-minute":
+				cfg.Quota.RequestsPerMinute = overrides.Quota.RequestsPerMinute
+			case "quota-results-per-day":
+				cfg.Quota.ResultsPerDay = overrides.Quota.ResultsPerDay
+			case "gateway-address":
+				cfg.Gateway.Enabled, cfg.Gateway.Address = true, overrides.Gateway.Address
+			case "grpc-web":
+				cfg.Gateway.GRPCWeb = overrides.Gateway.GRPCWeb
+			case "metrics-address":
+				cfg.Metrics.Enabled, cfg.Metrics.Address = true, overrides.Metrics.Address
+			case "reflection":
+				cfg.Reflection.Enabled = overrides.Reflection.Enabled
+			case "trace-exporter":
+				cfg.Tracing.Exporter = overrides.Tracing.Exporter
+			case "log-level":
+				cfg.Logging.Level = overrides.Logging.Level
+			case "log-format":
+				cfg.Logging.Format = overrides.Logging.Format
+			}
+		})
+	}
+	return configPath, printConfig, applyFlags
+}
+
+// configLoader returns a function that layers the config file, the environment
+// and the command-line flags, in increasing order of precedence, and validates
+// the result. It is called at startup and again on every reload.
+func configLoader(configPath string, applyFlags func(*config.Config)) func() (config.Config, error) {
+	return func() (config.Config, error) {
+		cfg, err := config.Load(configPath)
+		if err != nil {
+			return config.Config{}, err
+		}
+		applyFlags(&cfg)
+		return cfg, cfg.Validate()
+	}
+}
+
+// gitHubClientConfig converts the GitHub section of the config into client settings.
+func gitHubClientConfig(cfg config.GitHubConfig) github.ClientConfig {
+	return github.ClientConfig{
+		BaseURL: cfg.BaseURL,
+		Timeout: cfg.Timeout.Duration(),
+		Retry: github.RetryConfig{
+			MaxAttempts:    cfg.Retry.MaxAttempts,
+			InitialBackoff: cfg.Retry.InitialBackoff.Duration(),
+			MaxBackoff:     cfg.Retry.MaxBackoff.Duration(),
+		},
+		Breaker: github.BreakerConfig{
+			FailureRatio: cfg.Breaker.FailureRatio,
+			MinRequests:  cfg.Breaker.MinRequests,
+			Window:       cfg.Breaker.Window.Duration(),
+			CoolDown:     cfg.Breaker.CoolDown.Duration(),
+		},
+	}
+}
+
+// tlsOptions resolves the TLS section of the config. It has already been
+// validated, so the conversions cannot fail.
+func tlsOptions(cfg config.TLSConfig) server.TLSOptions {
+	minVersion, _ := cfg.MinTLSVersion()
+	cipherSuites, _ := cfg.CipherSuiteIDs()
+	clientAuth, _ := cfg.ClientAuthType()
+	return server.TLSOptions{
+		CertFile:     cfg.CertFile,
+		KeyFile:      cfg.KeyFile,
+		ClientCAFile: cfg.ClientCAFile,
+		MinVersion:   minVersion,
+		CipherSuites: cipherSuites,
+		ClientAuth:   clientAuth,
+	}
+}
 
-	// Stop the gRPC server gracefully
-	s.GracefulStop()
-	log.Println("gRPC server stopped")
+// setupLogging routes the standard logger through slog with the configured
+// level and format, redacting secrets. The returned level can be changed at runtime.
+func setupLogging(cfg config.LoggingConfig) *slog.LevelVar {
+	level := new(slog.LevelVar)
+	_ = level.UnmarshalText([]byte(cfg.Level))
+	slog.SetDefault(slog.New(logging.NewHandler(os.Stderr, cfg.Format, level)))
+	return level
 }
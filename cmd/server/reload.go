@@ -0,0 +1,110 @@
+package main
+
+import (
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Pratham700/github-search-service/internal/config"
+	"github.com/Pratham700/github-search-service/internal/server"
+)
+
+// reloader re-reads the configuration and swaps in its reloadable parts: the
+// GitHub backend settings, the quotas and the log level. Each part is replaced
+// atomically, so RPCs in flight finish with the settings they started with.
+// The circuit breakers carry over to the new GitHub client with their state.
+type reloader struct {
+	mu            sync.Mutex
+	load          func() (config.Config, error)
+	current       config.Config
+	logLevel      *slog.LevelVar
+	quota         *server.QuotaLimiter
+	githubServer  *server.GithubSearchServer
+	healthChecker *server.HealthChecker
+}
+
+// reload loads and applies a new configuration. An invalid configuration is
+// rejected as a whole and the active one is kept.
+func (r *reloader) reload(reason string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	slog.Info("Reloading configuration", "reason", reason)
+	next, err := r.load()
+	if err != nil {
+		slog.Error("Rejected new configuration, keeping the active one", "error", err)
+		return
+	}
+
+	if changed := r.current.RestartRequired(next); len(changed) > 0 {
+		slog.Warn("Ignoring changes that take effect only after a restart", "settings", changed)
+	}
+	// Settings that need a restart stay as they are so they are reported again
+	keepRestartOnly(&next, r.current)
+
+	if next.GitHub != r.current.GitHub {
+		r.githubServer.SetGitHubClient(r.githubServer.GitHubClient().Reconfigured(gitHubClientConfig(next.GitHub)))
+		r.healthChecker.SetToken(next.GitHub.Token)
+	}
+	if next.Quota != r.current.Quota {
+		r.quota.SetConfig(server.QuotaConfig{
+			RequestsPerMinute: next.Quota.RequestsPerMinute,
+			ResultsPerDay:     next.Quota.ResultsPerDay,
+		})
+	}
+	if next.Logging.Level != r.current.Logging.Level {
+		_ = r.logLevel.UnmarshalText([]byte(next.Logging.Level))
+	}
+
+	r.current = next
+	slog.Info("Configuration reloaded")
+}
+
+// keepRestartOnly copies the settings that only take effect after a restart
+// from current into next, as listed by config.Config.RestartRequired.
+func keepRestartOnly(next *config.Config, current config.Config) {
+	next.Listen = current.Listen
+	next.TLS = current.TLS
+	next.GitHub.Cache = current.GitHub.Cache
+	next.Logging.Format = current.Logging.Format
+	next.Health = current.Health
+	next.Reflection = current.Reflection
+	next.Gateway = current.Gateway
+	next.Metrics = current.Metrics
+	next.Tracing = current.Tracing
+	next.Audit = current.Audit
+	next.Storage = current.Storage
+	next.History = current.History
+	next.Saved = current.Saved
+	next.Watches = current.Watches
+	next.Webhooks = current.Webhooks
+	next.Snapshots = current.Snapshots
+	next.Batch = current.Batch
+	next.Facets = current.Facets
+	next.Exhaustive = current.Exhaustive
+	next.Reload = current.Reload
+}
+
+// watchFile calls onChange whenever the modification time or size of the file
+// at path changes, checking every interval.
+func watchFile(path string, interval time.Duration, onChange func()) {
+	stat := func() (time.Time, int64) {
+		info, err := os.Stat(path)
+		if err != nil {
+			return time.Time{}, -1
+		}
+		return info.ModTime(), info.Size()
+	}
+
+	lastMod, lastSize := stat()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		mod, size := stat()
+		if !mod.Equal(lastMod) || size != lastSize {
+			lastMod, lastSize = mod, size
+			onChange()
+		}
+	}
+}
@@ -0,0 +1,822 @@
+package config
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// EnvPrefix is prepended to the env tag of every field that can be overridden
+// from the environment.
+const EnvPrefix = "GSS_"
+
+// Config is the complete configuration of the server.
+type Config struct {
+	Listen     ListenConfig     `yaml:"listen" toml:"listen"`
+	TLS        TLSConfig        `yaml:"tls" toml:"tls"`
+	GitHub     GitHubConfig     `yaml:"github" toml:"github"`
+	Quota      QuotaConfig      `yaml:"quota" toml:"quota"`
+	Logging    LoggingConfig    `yaml:"logging" toml:"logging"`
+	Reload     ReloadConfig     `yaml:"reload" toml:"reload"`
+	Health     HealthConfig     `yaml:"health" toml:"health"`
+	Reflection ReflectionConfig `yaml:"reflection" toml:"reflection"`
+	Gateway    GatewayConfig    `yaml:"gateway" toml:"gateway"`
+	Metrics    MetricsConfig    `yaml:"metrics" toml:"metrics"`
+	Tracing    TracingConfig    `yaml:"tracing" toml:"tracing"`
+	Audit      AuditConfig      `yaml:"audit" toml:"audit"`
+	Storage    StorageConfig    `yaml:"storage" toml:"storage"`
+	History    HistoryConfig    `yaml:"history" toml:"history"`
+	Saved      SavedConfig      `yaml:"saved_searches" toml:"saved_searches"`
+	Watches    WatchesConfig    `yaml:"watches" toml:"watches"`
+	Webhooks   WebhooksConfig   `yaml:"webhooks" toml:"webhooks"`
+	Snapshots  SnapshotsConfig  `yaml:"snapshots" toml:"snapshots"`
+	Batch      BatchConfig      `yaml:"batch" toml:"batch"`
+	Facets     FacetsConfig     `yaml:"facets" toml:"facets"`
+	Exhaustive ExhaustiveConfig `yaml:"exhaustive" toml:"exhaustive"`
+}
+
+// ListenConfig configures the gRPC listener.
+type ListenConfig struct {
+	Address         string   `yaml:"address" toml:"address" env:"LISTEN_ADDRESS"`
+	ShutdownTimeout Duration `yaml:"shutdown_timeout" toml:"shutdown_timeout" env:"LISTEN_SHUTDOWN_TIMEOUT"`
+}
+
+// TLSConfig configures TLS on the gRPC listener. Setting ClientCAFile enables
+// mutual TLS; ClientAuth then decides whether a client certificate is required.
+type TLSConfig struct {
+	Enabled        bool     `yaml:"enabled" toml:"enabled" env:"TLS_ENABLED"`
+	CertFile       string   `yaml:"cert_file" toml:"cert_file" env:"TLS_CERT_FILE"`
+	KeyFile        string   `yaml:"key_file" toml:"key_file" env:"TLS_KEY_FILE"`
+	MinVersion     string   `yaml:"min_version" toml:"min_version" env:"TLS_MIN_VERSION"`
+	CipherSuites   []string `yaml:"cipher_suites" toml:"cipher_suites" env:"TLS_CIPHER_SUITES"`
+	ClientCAFile   string   `yaml:"client_ca_file" toml:"client_ca_file" env:"TLS_CLIENT_CA_FILE"`
+	ClientAuth     string   `yaml:"client_auth" toml:"client_auth" env:"TLS_CLIENT_AUTH"`
+	ReloadInterval Duration `yaml:"reload_interval" toml:"reload_interval" env:"TLS_RELOAD_INTERVAL"`
+}
+
+// MinTLSVersion returns the configured minimum TLS version.
+func (c TLSConfig) MinTLSVersion() (uint16, error) {
+	switch c.MinVersion {
+	case "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	default:
+		return 0, fmt.Errorf("tls.min_version %q must be 1.2 or 1.3", c.MinVersion)
+	}
+}
+
+// CipherSuiteIDs resolves the configured cipher suite names. An empty list
+// selects Go's defaults.
+func (c TLSConfig) CipherSuiteIDs() ([]uint16, error) {
+	byName := make(map[string]uint16)
+	for _, suite := range tls.CipherSuites() {
+		byName[suite.Name] = suite.ID
+	}
+
+	var ids []uint16
+	for _, name := range c.CipherSuites {
+		id, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("tls.cipher_suites: unknown or insecure cipher suite %q", name)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// ClientAuthType returns how client certificates are verified.
+func (c TLSConfig) ClientAuthType() (tls.ClientAuthType, error) {
+	if c.ClientCAFile == "" {
+		return tls.NoClientCert, nil
+	}
+	switch c.ClientAuth {
+	case "require":
+		return tls.RequireAndVerifyClientCert, nil
+	case "optional":
+		return tls.VerifyClientCertIfGiven, nil
+	default:
+		return 0, fmt.Errorf("tls.client_auth %q must be require or optional", c.ClientAuth)
+	}
+}
+
+// GitHubConfig configures the GitHub API backend, a single host given by
+// BaseURL, e.g. a GitHub Enterprise Server's https://<host>/api/v3.
+// Token is an optional server credential used for the health probe; callers
+// always search with their own github-token, so there is no auth mode to
+// choose: client certificates only identify callers, see TLSConfig.
+type GitHubConfig struct {
+	BaseURL string        `yaml:"base_url" toml:"base_url" env:"GITHUB_BASE_URL"`
+	Token   string        `yaml:"token" toml:"token" env:"GITHUB_TOKEN" secret:"true"`
+	Timeout Duration      `yaml:"timeout" toml:"timeout" env:"GITHUB_TIMEOUT"`
+	Retry   RetryConfig   `yaml:"retry" toml:"retry"`
+	Breaker BreakerConfig `yaml:"breaker" toml:"breaker"`
+	Cache   CacheConfig   `yaml:"cache" toml:"cache"`
+}
+
+// RetryConfig configures retries of failed GitHub requests.
+type RetryConfig struct {
+	MaxAttempts    int      `yaml:"max_attempts" toml:"max_attempts" env:"GITHUB_RETRY_MAX_ATTEMPTS"`
+	InitialBackoff Duration `yaml:"initial_backoff" toml:"initial_backoff" env:"GITHUB_RETRY_INITIAL_BACKOFF"`
+	MaxBackoff     Duration `yaml:"max_backoff" toml:"max_backoff" env:"GITHUB_RETRY_MAX_BACKOFF"`
+}
+
+// BreakerConfig configures the circuit breaker kept for each GitHub host.
+type BreakerConfig struct {
+	FailureRatio float64  `yaml:"failure_ratio" toml:"failure_ratio" env:"GITHUB_BREAKER_FAILURE_RATIO"`
+	MinRequests  int      `yaml:"min_requests" toml:"min_requests" env:"GITHUB_BREAKER_MIN_REQUESTS"`
+	Window       Duration `yaml:"window" toml:"window" env:"GITHUB_BREAKER_WINDOW"`
+	CoolDown     Duration `yaml:"cool_down" toml:"cool_down" env:"GITHUB_BREAKER_COOL_DOWN"`
+}
+
+// QuotaConfig configures the per-caller quotas. Zero disables a quota.
+type QuotaConfig struct {
+	RequestsPerMinute int `yaml:"requests_per_minute" toml:"requests_per_minute" env:"QUOTA_REQUESTS_PER_MINUTE"`
+	ResultsPerDay     int `yaml:"results_per_day" toml:"results_per_day" env:"QUOTA_RESULTS_PER_DAY"`
+}
+
+// LoggingConfig configures the server log output.
+type LoggingConfig struct {
+	Level  string `yaml:"level" toml:"level" env:"LOG_LEVEL"`
+	Format string `yaml:"format" toml:"format" env:"LOG_FORMAT"`
+}
+
+// ReloadConfig configures how the configuration is reloaded at runtime.
+// SIGHUP always triggers a reload; WatchInterval additionally polls the
+// config file for changes (0 disables polling).
+type ReloadConfig struct {
+	WatchInterval Duration `yaml:"watch_interval" toml:"watch_interval" env:"RELOAD_WATCH_INTERVAL"`
+}
+
+// CacheConfig bounds the caches of what is looked up from GitHub: the
+// repositories and organizations used by result filters, deduplication and
+// ranking, cached for TTL, and the logins recorded in the audit log, cached
+// for LoginTTL. Each cache holds at most MaxEntries entries.
+type CacheConfig struct {
+	TTL        Duration `yaml:"ttl" toml:"ttl" env:"GITHUB_CACHE_TTL"`
+	LoginTTL   Duration `yaml:"login_ttl" toml:"login_ttl" env:"GITHUB_CACHE_LOGIN_TTL"`
+	MaxEntries int      `yaml:"max_entries" toml:"max_entries" env:"GITHUB_CACHE_MAX_ENTRIES"`
+}
+
+// HealthConfig configures the probe of the GitHub host that drives the
+// grpc.health.v1 status. On shutdown the server reports NOT_SERVING and keeps
+// serving for ShutdownDelay, so load balancers see the status and stop
+// routing to it before it stops accepting calls.
+type HealthConfig struct {
+	ProbeInterval Duration `yaml:"probe_interval" toml:"probe_interval" env:"HEALTH_PROBE_INTERVAL"`
+	ProbeTimeout  Duration `yaml:"probe_timeout" toml:"probe_timeout" env:"HEALTH_PROBE_TIMEOUT"`
+	ShutdownDelay Duration `yaml:"shutdown_delay" toml:"shutdown_delay" env:"HEALTH_SHUTDOWN_DELAY"`
+}
+
+// ReflectionConfig configures gRPC server reflection, which lets tools such as
+// grpcurl discover the API without the .proto files.
+type ReflectionConfig struct {
+	Enabled bool `yaml:"enabled" toml:"enabled" env:"REFLECTION_ENABLED"`
+}
+
+// GatewayConfig configures the HTTP listener for clients that cannot speak
+// native gRPC. It serves the REST/JSON gateway when Enabled and gRPC-Web when
+// GRPCWeb is set, with the TLS settings of the gRPC listener when TLS is
+// enabled. Browser origins in AllowedOrigins ("*" for any) pass CORS checks.
+type GatewayConfig struct {
+	Enabled        bool     `yaml:"enabled" toml:"enabled" env:"GATEWAY_ENABLED"`
+	GRPCWeb        bool     `yaml:"grpc_web" toml:"grpc_web" env:"GATEWAY_GRPC_WEB"`
+	Address        string   `yaml:"address" toml:"address" env:"GATEWAY_ADDRESS"`
+	AllowedOrigins []string `yaml:"allowed_origins" toml:"allowed_origins" env:"GATEWAY_ALLOWED_ORIGINS"`
+}
+
+// MetricsConfig configures the HTTP listener serving Prometheus metrics on /metrics.
+type MetricsConfig struct {
+	Enabled bool   `yaml:"enabled" toml:"enabled" env:"METRICS_ENABLED"`
+	Address string `yaml:"address" toml:"address" env:"METRICS_ADDRESS"`
+}
+
+// TracingConfig configures OpenTelemetry tracing. Exporter is "none", "stdout"
+// (for local testing) or "otlp", which sends spans over gRPC to the collector
+// at Endpoint. SampleRatio is the fraction of new traces that are recorded;
+// calls that arrive with a sampled parent are always recorded.
+type TracingConfig struct {
+	Exporter    string  `yaml:"exporter" toml:"exporter" env:"TRACING_EXPORTER"`
+	Endpoint    string  `yaml:"endpoint" toml:"endpoint" env:"TRACING_ENDPOINT"`
+	Insecure    bool    `yaml:"insecure" toml:"insecure" env:"TRACING_INSECURE"`
+	SampleRatio float64 `yaml:"sample_ratio" toml:"sample_ratio" env:"TRACING_SAMPLE_RATIO"`
+	ServiceName string  `yaml:"service_name" toml:"service_name" env:"TRACING_SERVICE_NAME"`
+}
+
+// AuditConfig configures the audit log of every call, written as JSON lines
+// to Path. The file is rotated once it exceeds MaxSizeMB and at most
+// MaxBackups rotated files are kept (0 keeps all). Fsync is "always",
+// "interval" (every FsyncInterval) or "never".
+type AuditConfig struct {
+	Enabled       bool     `yaml:"enabled" toml:"enabled" env:"AUDIT_ENABLED"`
+	Path          string   `yaml:"path" toml:"path" env:"AUDIT_PATH"`
+	MaxSizeMB     int      `yaml:"max_size_mb" toml:"max_size_mb" env:"AUDIT_MAX_SIZE_MB"`
+	MaxBackups    int      `yaml:"max_backups" toml:"max_backups" env:"AUDIT_MAX_BACKUPS"`
+	Fsync         string   `yaml:"fsync" toml:"fsync" env:"AUDIT_FSYNC"`
+	FsyncInterval Duration `yaml:"fsync_interval" toml:"fsync_interval" env:"AUDIT_FSYNC_INTERVAL"`
+}
+
+// StorageConfig locates the embedded database holding per-caller state such
+// as the search history and saved searches. It is only opened when a feature
+// needs it.
+type StorageConfig struct {
+	Path string `yaml:"path" toml:"path" env:"STORAGE_PATH"`
+}
+
+// HistoryConfig configures the per-caller search history. Each caller keeps
+// at most MaxEntries searches, none older than Retention; 0 disables a limit.
+type HistoryConfig struct {
+	Enabled    bool     `yaml:"enabled" toml:"enabled" env:"HISTORY_ENABLED"`
+	MaxEntries int      `yaml:"max_entries" toml:"max_entries" env:"HISTORY_MAX_ENTRIES"`
+	Retention  Duration `yaml:"retention" toml:"retention" env:"HISTORY_RETENTION"`
+}
+
+// SavedConfig enables the saved search RPCs.
+type SavedConfig struct {
+	Enabled bool `yaml:"enabled" toml:"enabled" env:"SAVED_SEARCHES_ENABLED"`
+}
+
+// WatchesConfig configures search watches, which re-run searches on a
+// schedule with the GitHub token of their owner and record the hits that
+// appear or disappear. The owner's token is stored encrypted with TokenKey,
+// 32 base64-encoded random bytes; changing the key breaks existing watches.
+// Due watches are looked up every PollInterval; each run compares up to
+// MaxResults hits and each watch keeps its last MaxEvents events (0 keeps all).
+type WatchesConfig struct {
+	Enabled      bool     `yaml:"enabled" toml:"enabled" env:"WATCHES_ENABLED"`
+	MinInterval  Duration `yaml:"min_interval" toml:"min_interval" env:"WATCHES_MIN_INTERVAL"`
+	PollInterval Duration `yaml:"poll_interval" toml:"poll_interval" env:"WATCHES_POLL_INTERVAL"`
+	MaxResults   int      `yaml:"max_results" toml:"max_results" env:"WATCHES_MAX_RESULTS"`
+	MaxEvents    int      `yaml:"max_events" toml:"max_events" env:"WATCHES_MAX_EVENTS"`
+	TokenKey     string   `yaml:"token_key" toml:"token_key" env:"WATCHES_TOKEN_KEY" secret:"true"`
+}
+
+// Key returns the decoded TokenKey.
+func (c WatchesConfig) Key() ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(c.TokenKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("key is %d bytes long, want 32", len(key))
+	}
+	return key, nil
+}
+
+// WebhooksConfig configures the delivery of new watch hits to the webhook
+// URLs of watches. A delivery is attempted up to MaxAttempts times, with a
+// backoff doubling from InitialBackoff up to MaxBackoff, before it becomes a
+// dead letter; each watch keeps its last MaxDeliveries deliveries (0 keeps all).
+// Webhook URLs are limited to AllowedHosts if it is set, and may only reach
+// public addresses unless AllowPrivateNetworks is set.
+type WebhooksConfig struct {
+	Enabled        bool     `yaml:"enabled" toml:"enabled" env:"WEBHOOKS_ENABLED"`
+	MaxAttempts    int      `yaml:"max_attempts" toml:"max_attempts" env:"WEBHOOKS_MAX_ATTEMPTS"`
+	InitialBackoff Duration `yaml:"initial_backoff" toml:"initial_backoff" env:"WEBHOOKS_INITIAL_BACKOFF"`
+	MaxBackoff     Duration `yaml:"max_backoff" toml:"max_backoff" env:"WEBHOOKS_MAX_BACKOFF"`
+	Timeout        Duration `yaml:"timeout" toml:"timeout" env:"WEBHOOKS_TIMEOUT"`
+	MaxDeliveries  int      `yaml:"max_deliveries" toml:"max_deliveries" env:"WEBHOOKS_MAX_DELIVERIES"`
+	AllowedHosts   []string `yaml:"allowed_hosts" toml:"allowed_hosts" env:"WEBHOOKS_ALLOWED_HOSTS"`
+	// AllowPrivateNetworks lets webhooks reach loopback, private and
+	// link-local addresses, e.g. for receivers on the server's own network.
+	AllowPrivateNetworks bool `yaml:"allow_private_networks" toml:"allow_private_networks" env:"WEBHOOKS_ALLOW_PRIVATE_NETWORKS"`
+}
+
+// SnapshotsConfig configures search snapshots and DiffSearch. Each snapshot,
+// and each run of a search compared with one, records up to MaxResults hits;
+// GitHub returns at most 1000. A caller may keep MaxPerCaller snapshots (0
+// allows any number).
+type SnapshotsConfig struct {
+	Enabled      bool `yaml:"enabled" toml:"enabled" env:"SNAPSHOTS_ENABLED"`
+	MaxResults   int  `yaml:"max_results" toml:"max_results" env:"SNAPSHOTS_MAX_RESULTS"`
+	MaxPerCaller int  `yaml:"max_per_caller" toml:"max_per_caller" env:"SNAPSHOTS_MAX_PER_CALLER"`
+}
+
+// BatchConfig limits BatchSearch and StreamBatchSearch: a batch holds at
+// most MaxQueries searches (0 disables batches), of which at most
+// MaxConcurrency run at the same time.
+type BatchConfig struct {
+	MaxQueries     int `yaml:"max_queries" toml:"max_queries" env:"BATCH_MAX_QUERIES"`
+	MaxConcurrency int `yaml:"max_concurrency" toml:"max_concurrency" env:"BATCH_MAX_CONCURRENCY"`
+}
+
+// FacetsConfig limits SearchFacets to analyzing MaxResults hits per call
+// (0 disables it); GitHub returns at most 1000.
+type FacetsConfig struct {
+	MaxResults int `yaml:"max_results" toml:"max_results" env:"FACETS_MAX_RESULTS"`
+}
+
+// ExhaustiveConfig limits ExhaustiveSearch to running MaxQueries GitHub
+// searches (0 disables it) and returning MaxResults hits per call.
+type ExhaustiveConfig struct {
+	MaxQueries int `yaml:"max_queries" toml:"max_queries" env:"EXHAUSTIVE_MAX_QUERIES"`
+	MaxResults int `yaml:"max_results" toml:"max_results" env:"EXHAUSTIVE_MAX_RESULTS"`
+}
+
+// StorageRequired reports whether an enabled feature keeps state in the store.
+func (c Config) StorageRequired() bool {
+	return c.History.Enabled || c.Saved.Enabled || c.Watches.Enabled || c.Snapshots.Enabled
+}
+
+// Default returns the configuration used when nothing is overridden.
+func Default() Config {
+	return Config{
+		Listen: ListenConfig{
+			Address:         ":50051",
+			ShutdownTimeout: Duration(30 * time.Second),
+		},
+		TLS: TLSConfig{
+			MinVersion:     "1.2",
+			ClientAuth:     "require",
+			ReloadInterval: Duration(time.Minute),
+		},
+		GitHub: GitHubConfig{
+			BaseURL: "https://api.github.com",
+			Timeout: Duration(5 * time.Second),
+			Retry: RetryConfig{
+				MaxAttempts:    3,
+				InitialBackoff: Duration(200 * time.Millisecond),
+				MaxBackoff:     Duration(2 * time.Second),
+			},
+			Breaker: BreakerConfig{
+				FailureRatio: 0.5,
+				MinRequests:  10,
+				Window:       Duration(time.Minute),
+				CoolDown:     Duration(30 * time.Second),
+			},
+			Cache: CacheConfig{
+				TTL:        Duration(5 * time.Minute),
+				LoginTTL:   Duration(time.Hour),
+				MaxEntries: 10000,
+			},
+		},
+		Logging: LoggingConfig{
+			Level:  "info",
+			Format: "text",
+		},
+		Gateway: GatewayConfig{
+			Address: ":8080",
+		},
+		Metrics: MetricsConfig{
+			Address: ":9090",
+		},
+		Tracing: TracingConfig{
+			Exporter:    "none",
+			Endpoint:    "localhost:4317",
+			Insecure:    true,
+			SampleRatio: 1,
+			ServiceName: "github-search-service",
+		},
+		Audit: AuditConfig{
+			Path:          "audit.jsonl",
+			MaxSizeMB:     100,
+			Fsync:         "interval",
+			FsyncInterval: Duration(time.Second),
+		},
+		Storage: StorageConfig{
+			Path: "github-search-service.db",
+		},
+		History: HistoryConfig{
+			MaxEntries: 100,
+			Retention:  Duration(30 * 24 * time.Hour),
+		},
+		Watches: WatchesConfig{
+			MinInterval:  Duration(5 * time.Minute),
+			PollInterval: Duration(15 * time.Second),
+			MaxResults:   100,
+			MaxEvents:    1000,
+		},
+		Webhooks: WebhooksConfig{
+			MaxAttempts:    8,
+			InitialBackoff: Duration(10 * time.Second),
+			MaxBackoff:     Duration(time.Hour),
+			Timeout:        Duration(10 * time.Second),
+			MaxDeliveries:  100,
+		},
+		Snapshots: SnapshotsConfig{
+			MaxResults:   1000,
+			MaxPerCaller: 100,
+		},
+		Batch: BatchConfig{
+			MaxQueries:     500,
+			MaxConcurrency: 8,
+		},
+		Facets: FacetsConfig{
+			MaxResults: 1000,
+		},
+		Exhaustive: ExhaustiveConfig{
+			MaxQueries: 100,
+			MaxResults: 10000,
+		},
+		Health: HealthConfig{
+			ProbeInterval: Duration(30 * time.Second),
+			ProbeTimeout:  Duration(5 * time.Second),
+			ShutdownDelay: Duration(5 * time.Second),
+		},
+	}
+}
+
+// Load builds the configuration from the defaults, the optional config file
+// at path (YAML or TOML, chosen by extension) and the environment, in that
+// order of precedence. The result is not validated.
+func Load(path string) (Config, error) {
+	cfg := Default()
+	if path != "" {
+		if err := loadFile(path, &cfg); err != nil {
+			return Config{}, err
+		}
+	}
+	if err := applyEnv(&cfg, os.LookupEnv); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+func loadFile(path string, cfg *Config) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("failed to parse config file %s: %w", path, err)
+		}
+	case ".toml":
+		md, err := toml.Decode(string(data), cfg)
+		if err != nil {
+			return fmt.Errorf("failed to parse config file %s: %w", path, err)
+		}
+		if undecoded := md.Undecoded(); len(undecoded) > 0 {
+			return fmt.Errorf("unknown keys in config file %s: %v", path, undecoded)
+		}
+	default:
+		return fmt.Errorf("unsupported config file extension %q: use .yaml, .yml or .toml", ext)
+	}
+	return nil
+}
+
+// applyEnv overrides every field carrying an env tag whose variable is set.
+func applyEnv(cfg *Config, lookup func(string) (string, bool)) error {
+	// GITHUB_BASE_URL predates the config file and is still honoured.
+	if v, ok := lookup("GITHUB_BASE_URL"); ok {
+		cfg.GitHub.BaseURL = v
+	}
+
+	var errs []error
+	walkFields(reflect.ValueOf(cfg).Elem(), func(field reflect.StructField, value reflect.Value) {
+		name, ok := field.Tag.Lookup("env")
+		if !ok {
+			return
+		}
+		raw, ok := lookup(EnvPrefix + name)
+		if !ok {
+			return
+		}
+		if err := setFromString(value, raw); err != nil {
+			errs = append(errs, fmt.Errorf("invalid value for %s%s: %w", EnvPrefix, name, err))
+		}
+	})
+	return errors.Join(errs...)
+}
+
+// walkFields calls fn for every leaf field of the struct value v.
+func walkFields(v reflect.Value, fn func(reflect.StructField, reflect.Value)) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field, value := t.Field(i), v.Field(i)
+		if value.Kind() == reflect.Struct {
+			walkFields(value, fn)
+			continue
+		}
+		fn(field, value)
+	}
+}
+
+func setFromString(value reflect.Value, raw string) error {
+	if value.Type() == reflect.TypeOf(Duration(0)) {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		value.SetInt(int64(d))
+		return nil
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		value.SetBool(b)
+	case reflect.Int:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return err
+		}
+		value.SetInt(int64(n))
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return err
+		}
+		value.SetFloat(f)
+	case reflect.Slice:
+		var items []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		value.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("unsupported field type %s", value.Type())
+	}
+	return nil
+}
+
+// Validate reports every invalid setting at once.
+func (c Config) Validate() error {
+	var errs []error
+	add := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	if _, _, err := net.SplitHostPort(c.Listen.Address); err != nil {
+		add("listen.address %q is invalid: %v", c.Listen.Address, err)
+	}
+	if c.Listen.ShutdownTimeout < 0 {
+		add("listen.shutdown_timeout must not be negative")
+	}
+	if c.Metrics.Enabled {
+		if _, _, err := net.SplitHostPort(c.Metrics.Address); err != nil {
+			add("metrics.address %q is invalid: %v", c.Metrics.Address, err)
+		}
+	}
+	if c.Gateway.Enabled || c.Gateway.GRPCWeb {
+		if _, _, err := net.SplitHostPort(c.Gateway.Address); err != nil {
+			add("gateway.address %q is invalid: %v", c.Gateway.Address, err)
+		}
+	}
+
+	if c.TLS.Enabled && (c.TLS.CertFile == "" || c.TLS.KeyFile == "") {
+		add("tls.cert_file and tls.key_file are required when TLS is enabled")
+	}
+	if _, err := c.TLS.MinTLSVersion(); err != nil {
+		errs = append(errs, err)
+	}
+	if _, err := c.TLS.CipherSuiteIDs(); err != nil {
+		errs = append(errs, err)
+	}
+	if _, err := c.TLS.ClientAuthType(); err != nil {
+		errs = append(errs, err)
+	}
+	if c.TLS.ReloadInterval < 0 {
+		add("tls.reload_interval must not be negative")
+	}
+
+	if u, err := url.Parse(c.GitHub.BaseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		add("github.base_url %q must be an absolute http(s) URL", c.GitHub.BaseURL)
+	}
+	if c.GitHub.Timeout <= 0 {
+		add("github.timeout must be positive")
+	}
+	if c.GitHub.Retry.MaxAttempts < 1 {
+		add("github.retry.max_attempts must be at least 1")
+	}
+	if c.GitHub.Retry.InitialBackoff < 0 || c.GitHub.Retry.MaxBackoff < c.GitHub.Retry.InitialBackoff {
+		add("github.retry backoffs must satisfy 0 <= initial_backoff <= max_backoff")
+	}
+	if c.GitHub.Breaker.FailureRatio <= 0 || c.GitHub.Breaker.FailureRatio > 1 {
+		add("github.breaker.failure_ratio must be in (0, 1]")
+	}
+	if c.GitHub.Breaker.MinRequests < 1 {
+		add("github.breaker.min_requests must be at least 1")
+	}
+	if c.GitHub.Breaker.Window <= 0 || c.GitHub.Breaker.CoolDown <= 0 {
+		add("github.breaker.window and github.breaker.cool_down must be positive")
+	}
+	if c.GitHub.Cache.TTL <= 0 || c.GitHub.Cache.LoginTTL <= 0 {
+		add("github.cache.ttl and github.cache.login_ttl must be positive")
+	}
+	if c.GitHub.Cache.MaxEntries < 1 {
+		add("github.cache.max_entries must be at least 1")
+	}
+
+	if c.Health.ProbeInterval <= 0 || c.Health.ProbeTimeout <= 0 {
+		add("health.probe_interval and health.probe_timeout must be positive")
+	}
+	if c.Health.ShutdownDelay < 0 {
+		add("health.shutdown_delay must not be negative")
+	}
+
+	if c.Reload.WatchInterval < 0 {
+		add("reload.watch_interval must not be negative")
+	}
+
+	if c.Quota.RequestsPerMinute < 0 || c.Quota.ResultsPerDay < 0 {
+		add("quota limits must not be negative")
+	}
+
+	switch c.Tracing.Exporter {
+	case "none", "stdout":
+	case "otlp":
+		if c.Tracing.Endpoint == "" {
+			add("tracing.endpoint is required with the otlp exporter")
+		}
+	default:
+		add("tracing.exporter %q must be one of none, stdout, otlp", c.Tracing.Exporter)
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		add("tracing.sample_ratio must be in [0, 1]")
+	}
+
+	if c.Audit.Enabled {
+		if c.Audit.Path == "" {
+			add("audit.path is required when the audit log is enabled")
+		}
+		if c.Audit.MaxSizeMB < 0 || c.Audit.MaxBackups < 0 {
+			add("audit.max_size_mb and audit.max_backups must not be negative")
+		}
+		switch c.Audit.Fsync {
+		case "always", "never":
+		case "interval":
+			if c.Audit.FsyncInterval <= 0 {
+				add("audit.fsync_interval must be positive")
+			}
+		default:
+			add("audit.fsync %q must be one of always, interval, never", c.Audit.Fsync)
+		}
+	}
+
+	if c.StorageRequired() && c.Storage.Path == "" {
+		add("storage.path is required when the search history, saved searches, watches or snapshots are enabled")
+	}
+	if c.Watches.Enabled {
+		if c.Watches.MinInterval <= 0 || c.Watches.PollInterval <= 0 {
+			add("watches.min_interval and watches.poll_interval must be positive")
+		}
+		if c.Watches.MaxResults < 1 || c.Watches.MaxEvents < 0 {
+			add("watches.max_results must be at least 1 and watches.max_events must not be negative")
+		}
+		if _, err := c.Watches.Key(); err != nil {
+			add("watches.token_key must hold 32 base64-encoded bytes: %v", err)
+		}
+	}
+	if c.Webhooks.Enabled {
+		if !c.Watches.Enabled {
+			add("webhooks require watches.enabled")
+		}
+		if c.Webhooks.MaxAttempts < 1 || c.Webhooks.MaxDeliveries < 0 {
+			add("webhooks.max_attempts must be at least 1 and webhooks.max_deliveries must not be negative")
+		}
+		if c.Webhooks.InitialBackoff <= 0 || c.Webhooks.MaxBackoff < c.Webhooks.InitialBackoff || c.Webhooks.Timeout <= 0 {
+			add("webhooks.initial_backoff and webhooks.timeout must be positive and webhooks.max_backoff must not be below webhooks.initial_backoff")
+		}
+	}
+	if c.Snapshots.Enabled && (c.Snapshots.MaxResults < 1 || c.Snapshots.MaxResults > 1000 || c.Snapshots.MaxPerCaller < 0) {
+		add("snapshots.max_results must be between 1 and 1000 and snapshots.max_per_caller must not be negative")
+	}
+	if c.Batch.MaxQueries < 0 || c.Batch.MaxConcurrency < 1 {
+		add("batch.max_queries must not be negative and batch.max_concurrency must be at least 1")
+	}
+	if c.Facets.MaxResults < 0 || c.Facets.MaxResults > 1000 {
+		add("facets.max_results must be between 0 and 1000")
+	}
+	if c.Exhaustive.MaxQueries < 0 || c.Exhaustive.MaxResults < 1 {
+		add("exhaustive.max_queries must not be negative and exhaustive.max_results must be at least 1")
+	}
+	if c.History.MaxEntries < 0 || c.History.Retention < 0 {
+		add("history.max_entries and history.retention must not be negative")
+	}
+
+	switch c.Logging.Level {
+	case "debug", "info", "warn", "error":
+	default:
+		add("logging.level %q must be one of debug, info, warn, error", c.Logging.Level)
+	}
+	switch c.Logging.Format {
+	case "text", "json":
+	default:
+		add("logging.format %q must be text or json", c.Logging.Format)
+	}
+
+	return errors.Join(errs...)
+}
+
+// RestartRequired lists the settings that differ between c and next but only
+// take effect after a restart: the listener, TLS, the GitHub caches, the log
+// format, the health probe schedule and shutdown delay, reflection, the
+// gateway, metrics, tracing, the audit log, storage, the search history,
+// saved searches, watches, webhooks, snapshots, batch, facet and exhaustive
+// search limits and reloading itself.
+func (c Config) RestartRequired(next Config) []string {
+	var changed []string
+	if c.Listen != next.Listen {
+		changed = append(changed, "listen")
+	}
+	if !reflect.DeepEqual(c.TLS, next.TLS) {
+		changed = append(changed, "tls")
+	}
+	if c.GitHub.Cache != next.GitHub.Cache {
+		changed = append(changed, "github.cache")
+	}
+	if c.Logging.Format != next.Logging.Format {
+		changed = append(changed, "logging.format")
+	}
+	if c.Health != next.Health {
+		changed = append(changed, "health")
+	}
+	if c.Reflection != next.Reflection {
+		changed = append(changed, "reflection")
+	}
+	if !reflect.DeepEqual(c.Gateway, next.Gateway) {
+		changed = append(changed, "gateway")
+	}
+	if c.Metrics != next.Metrics {
+		changed = append(changed, "metrics")
+	}
+	if c.Tracing != next.Tracing {
+		changed = append(changed, "tracing")
+	}
+	if c.Audit != next.Audit {
+		changed = append(changed, "audit")
+	}
+	if c.Storage != next.Storage {
+		changed = append(changed, "storage")
+	}
+	if c.History != next.History {
+		changed = append(changed, "history")
+	}
+	if c.Saved != next.Saved {
+		changed = append(changed, "saved_searches")
+	}
+	if c.Watches != next.Watches {
+		changed = append(changed, "watches")
+	}
+	if !reflect.DeepEqual(c.Webhooks, next.Webhooks) {
+		changed = append(changed, "webhooks")
+	}
+	if c.Snapshots != next.Snapshots {
+		changed = append(changed, "snapshots")
+	}
+	if c.Batch != next.Batch {
+		changed = append(changed, "batch")
+	}
+	if c.Facets != next.Facets {
+		changed = append(changed, "facets")
+	}
+	if c.Exhaustive != next.Exhaustive {
+		changed = append(changed, "exhaustive")
+	}
+	if c.Reload != next.Reload {
+		changed = append(changed, "reload")
+	}
+	return changed
+}
+
+// Redacted returns a copy of the configuration with every field tagged
+// secret:"true" masked, suitable for printing.
+func (c Config) Redacted() Config {
+	walkFields(reflect.ValueOf(&c).Elem(), func(field reflect.StructField, value reflect.Value) {
+		if field.Tag.Get("secret") != "true" || value.IsZero() {
+			return
+		}
+		switch value.Kind() {
+		case reflect.String:
+			value.SetString("REDACTED")
+		case reflect.Slice:
+			masked := make([]string, value.Len())
+			for i := range masked {
+				masked[i] = "REDACTED"
+			}
+			value.Set(reflect.ValueOf(masked))
+		}
+	})
+	return c
+}
+
+// Marshal renders the configuration as YAML.
+func (c Config) Marshal() ([]byte, error) {
+	return yaml.Marshal(c)
+}
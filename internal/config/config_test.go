@@ -0,0 +1,190 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeConfig writes content to a config file with the given name in a
+// temporary directory and returns its path.
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	return path
+}
+
+func TestDefaultIsValid(t *testing.T) {
+	if err := Default().Validate(); err != nil {
+		t.Errorf("Default().Validate() error = %v", err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		env     map[string]string
+		check   func(Config) bool
+	}{
+		{
+			name: "defaults without a file",
+			check: func(c Config) bool {
+				return c.GitHub.Cache.TTL.Duration() == 5*time.Minute && c.GitHub.Cache.MaxEntries == 10000
+			},
+		},
+		{
+			name:    "yaml overrides defaults",
+			file:    "config.yaml",
+			content: "github:\n  cache:\n    ttl: 1m\n",
+			check: func(c Config) bool {
+				return c.GitHub.Cache.TTL.Duration() == time.Minute && c.GitHub.Cache.LoginTTL.Duration() == time.Hour
+			},
+		},
+		{
+			name:    "toml overrides defaults",
+			file:    "config.toml",
+			content: "[github.cache]\nmax_entries = 5\n",
+			check:   func(c Config) bool { return c.GitHub.Cache.MaxEntries == 5 },
+		},
+		{
+			name:    "env overrides the file",
+			file:    "config.yaml",
+			content: "github:\n  cache:\n    login_ttl: 2h\n",
+			env:     map[string]string{"GSS_GITHUB_CACHE_LOGIN_TTL": "30m", "GSS_LISTEN_ADDRESS": ":1234"},
+			check: func(c Config) bool {
+				return c.GitHub.Cache.LoginTTL.Duration() == 30*time.Minute && c.Listen.Address == ":1234"
+			},
+		},
+		{
+			name:  "legacy GITHUB_BASE_URL",
+			env:   map[string]string{"GITHUB_BASE_URL": "https://ghe.example.com/api/v3"},
+			check: func(c Config) bool { return c.GitHub.BaseURL == "https://ghe.example.com/api/v3" },
+		},
+		{
+			name: "list from env",
+			env:  map[string]string{"GSS_WEBHOOKS_ALLOWED_HOSTS": "a.example.com, b.example.com,"},
+			check: func(c Config) bool {
+				return slices.Equal(c.Webhooks.AllowedHosts, []string{"a.example.com", "b.example.com"})
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+			path := ""
+			if tt.file != "" {
+				path = writeConfig(t, tt.file, tt.content)
+			}
+			cfg, err := Load(path)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if !tt.check(cfg) {
+				t.Errorf("Load() = %+v", cfg)
+			}
+		})
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		env     map[string]string
+	}{
+		{"unknown yaml key", "config.yaml", "github:\n  hosts: [a]\n", nil},
+		{"unknown toml key", "config.toml", "[github]\nauth_mode = \"token\"\n", nil},
+		{"unsupported extension", "config.json", "{}", nil},
+		{"invalid env duration", "", "", map[string]string{"GSS_GITHUB_CACHE_TTL": "soon"}},
+		{"invalid env number", "", "", map[string]string{"GSS_GITHUB_CACHE_MAX_ENTRIES": "many"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+			path := ""
+			if tt.file != "" {
+				path = writeConfig(t, tt.file, tt.content)
+			}
+			if _, err := Load(path); err == nil {
+				t.Errorf("Load() error = nil, want an error")
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		want   string
+	}{
+		{"listen address", func(c *Config) { c.Listen.Address = "nope" }, "listen.address"},
+		{"base url", func(c *Config) { c.GitHub.BaseURL = "api.github.com" }, "github.base_url"},
+		{"timeout", func(c *Config) { c.GitHub.Timeout = 0 }, "github.timeout"},
+		{"retry attempts", func(c *Config) { c.GitHub.Retry.MaxAttempts = 0 }, "github.retry.max_attempts"},
+		{"breaker ratio", func(c *Config) { c.GitHub.Breaker.FailureRatio = 1.5 }, "github.breaker.failure_ratio"},
+		{"cache ttl", func(c *Config) { c.GitHub.Cache.TTL = 0 }, "github.cache.ttl"},
+		{"cache login ttl", func(c *Config) { c.GitHub.Cache.LoginTTL = -1 }, "github.cache.login_ttl"},
+		{"cache size", func(c *Config) { c.GitHub.Cache.MaxEntries = 0 }, "github.cache.max_entries"},
+		{"log level", func(c *Config) { c.Logging.Level = "loud" }, "logging.level"},
+		{"tls version", func(c *Config) { c.TLS.MinVersion = "1.0" }, "tls.min_version"},
+		{"watch key", func(c *Config) { c.Watches.Enabled = true }, "watches.token_key"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default()
+			tt.modify(&cfg)
+			err := cfg.Validate()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Validate() error = %v, want one about %s", err, tt.want)
+			}
+		})
+	}
+
+	// Every invalid setting is reported at once
+	cfg := Default()
+	cfg.GitHub.Timeout, cfg.GitHub.Cache.MaxEntries = 0, 0
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "github.timeout") || !strings.Contains(err.Error(), "github.cache.max_entries") {
+		t.Errorf("Validate() error = %v, want both errors", err)
+	}
+}
+
+func TestRestartRequired(t *testing.T) {
+	current := Default()
+	next := Default()
+	next.GitHub.Timeout = Duration(time.Minute)
+	next.GitHub.Cache.TTL = Duration(time.Minute)
+	next.Quota.RequestsPerMinute = 10
+	next.Logging.Format = "json"
+	if got, want := current.RestartRequired(next), []string{"github.cache", "logging.format"}; !slices.Equal(got, want) {
+		t.Errorf("RestartRequired() = %v, want %v", got, want)
+	}
+}
+
+func TestRedacted(t *testing.T) {
+	cfg := Default()
+	cfg.GitHub.Token = "ghp_secret"
+	redacted := cfg.Redacted()
+	if redacted.GitHub.Token != "REDACTED" {
+		t.Errorf("Redacted() token = %q, want REDACTED", redacted.GitHub.Token)
+	}
+	if cfg.GitHub.Token != "ghp_secret" {
+		t.Errorf("Redacted() modified the original configuration")
+	}
+	if redacted.Watches.TokenKey != "" {
+		t.Errorf("Redacted() masked an empty secret as %q", redacted.Watches.TokenKey)
+	}
+}
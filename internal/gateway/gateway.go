@@ -0,0 +1,614 @@
+package gateway
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/Pratham700/github-search-service/internal/logging"
+	pb "github.com/Pratham700/github-search-service/proto/proto"
+)
+
+var marshalOptions = protojson.MarshalOptions{UseProtoNames: true}
+
+// maxBodySize bounds the size of JSON request bodies.
+const maxBodySize = 1 << 20
+
+// Gateway serves a REST/JSON mapping of GithubSearchService for clients that
+// cannot speak gRPC. Requests go through the same interceptor chain as gRPC
+// calls, so authentication and quotas apply unchanged.
+type Gateway struct {
+	service     pb.GithubSearchServiceServer
+	interceptor grpc.UnaryServerInterceptor
+	mux         *http.ServeMux
+}
+
+// New creates a Gateway calling service through interceptor.
+func New(service pb.GithubSearchServiceServer, interceptor grpc.UnaryServerInterceptor) *Gateway {
+	g := &Gateway{
+		service:     service,
+		interceptor: interceptor,
+		mux:         http.NewServeMux(),
+	}
+	g.mux.HandleFunc("GET /v1/search/code", g.handleSearch)
+	g.mux.HandleFunc("GET /v1/quota", g.handleGetQuota)
+	g.mux.HandleFunc("GET /v1/history", g.handleListRecentSearches)
+	g.mux.HandleFunc("DELETE /v1/history", g.handleDeleteSearchHistory)
+	g.mux.HandleFunc("POST /v1/saved-searches", g.handleCreateSavedSearch)
+	g.mux.HandleFunc("GET /v1/saved-searches", g.handleListSavedSearches)
+	g.mux.HandleFunc("GET /v1/saved-searches/{id}", g.handleGetSavedSearch)
+	g.mux.HandleFunc("PATCH /v1/saved-searches/{id}", g.handleUpdateSavedSearch)
+	g.mux.HandleFunc("DELETE /v1/saved-searches/{id}", g.handleDeleteSavedSearch)
+	g.mux.HandleFunc("POST /v1/saved-searches/{id}/run", g.handleRunSavedSearch)
+	g.mux.HandleFunc("POST /v1/watches", g.handleCreateWatch)
+	g.mux.HandleFunc("GET /v1/watches", g.handleListWatches)
+	g.mux.HandleFunc("GET /v1/watches/{id}", g.handleGetWatch)
+	g.mux.HandleFunc("DELETE /v1/watches/{id}", g.handleDeleteWatch)
+	g.mux.HandleFunc("GET /v1/watches/{id}/events", g.handleListWatchEvents)
+	g.mux.HandleFunc("GET /v1/watches/{id}/deliveries", g.handleListDeliveries)
+	g.mux.HandleFunc("POST /v1/snapshots", g.handleCreateSnapshot)
+	g.mux.HandleFunc("GET /v1/snapshots", g.handleListSnapshots)
+	g.mux.HandleFunc("GET /v1/snapshots/{id}", g.handleGetSnapshot)
+	g.mux.HandleFunc("DELETE /v1/snapshots/{id}", g.handleDeleteSnapshot)
+	g.mux.HandleFunc("POST /v1/search/diff", g.handleDiffSearch)
+	g.mux.HandleFunc("POST /v1/search/batch", g.handleBatchSearch)
+	g.mux.HandleFunc("GET /v1/search/facets", g.handleSearchFacets)
+	g.mux.HandleFunc("POST /v1/search/exhaustive", g.handleExhaustiveSearch)
+	return g
+}
+
+// ServeHTTP implements http.Handler.
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.mux.ServeHTTP(w, r)
+}
+
+// handleSearch maps GET /v1/search/code?q=...&user=...&sort=...&order=...&per_page=...&page=...
+// onto Search.
+func (g *Gateway) handleSearch(w http.ResponseWriter, r *http.Request) {
+	req, err := searchRequestFromQuery(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	g.call(w, r, pb.GithubSearchService_Search_FullMethodName, req, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return g.service.Search(ctx, req.(*pb.SearchRequest))
+	})
+}
+
+// handleGetQuota maps GET /v1/quota onto GetQuota.
+func (g *Gateway) handleGetQuota(w http.ResponseWriter, r *http.Request) {
+	g.call(w, r, pb.GithubSearchService_GetQuota_FullMethodName, &pb.GetQuotaRequest{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return g.service.GetQuota(ctx, req.(*pb.GetQuotaRequest))
+	})
+}
+
+// handleListRecentSearches maps GET /v1/history?page_size=...&page_token=...
+// onto ListRecentSearches.
+func (g *Gateway) handleListRecentSearches(w http.ResponseWriter, r *http.Request) {
+	pageSize, err := pageSizeFromQuery(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	req := &pb.ListRecentSearchesRequest{PageSize: pageSize, PageToken: r.URL.Query().Get("page_token")}
+	g.call(w, r, pb.GithubSearchService_ListRecentSearches_FullMethodName, req, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return g.service.ListRecentSearches(ctx, req.(*pb.ListRecentSearchesRequest))
+	})
+}
+
+// handleDeleteSearchHistory maps DELETE /v1/history?before=<RFC 3339 time>
+// onto DeleteSearchHistory.
+func (g *Gateway) handleDeleteSearchHistory(w http.ResponseWriter, r *http.Request) {
+	req := &pb.DeleteSearchHistoryRequest{}
+	if raw := r.URL.Query().Get("before"); raw != "" {
+		before, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			writeError(w, status.Errorf(codes.InvalidArgument, "invalid value for 'before': must be an RFC 3339 time"))
+			return
+		}
+		req.Before = timestamppb.New(before)
+	}
+	g.call(w, r, pb.GithubSearchService_DeleteSearchHistory_FullMethodName, req, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return g.service.DeleteSearchHistory(ctx, req.(*pb.DeleteSearchHistoryRequest))
+	})
+}
+
+// handleCreateSavedSearch maps POST /v1/saved-searches with a SavedSearch
+// body onto CreateSavedSearch.
+func (g *Gateway) handleCreateSavedSearch(w http.ResponseWriter, r *http.Request) {
+	req := &pb.CreateSavedSearchRequest{SavedSearch: &pb.SavedSearch{}}
+	if err := readBody(r, req.SavedSearch); err != nil {
+		writeError(w, err)
+		return
+	}
+	g.call(w, r, pb.GithubSearchService_CreateSavedSearch_FullMethodName, req, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return g.service.CreateSavedSearch(ctx, req.(*pb.CreateSavedSearchRequest))
+	})
+}
+
+// handleListSavedSearches maps GET /v1/saved-searches?tag=...&page_size=...&page_token=...
+// onto ListSavedSearches.
+func (g *Gateway) handleListSavedSearches(w http.ResponseWriter, r *http.Request) {
+	pageSize, err := pageSizeFromQuery(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	query := r.URL.Query()
+	req := &pb.ListSavedSearchesRequest{Tag: query.Get("tag"), PageSize: pageSize, PageToken: query.Get("page_token")}
+	g.call(w, r, pb.GithubSearchService_ListSavedSearches_FullMethodName, req, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return g.service.ListSavedSearches(ctx, req.(*pb.ListSavedSearchesRequest))
+	})
+}
+
+// handleGetSavedSearch maps GET /v1/saved-searches/{id} onto GetSavedSearch.
+func (g *Gateway) handleGetSavedSearch(w http.ResponseWriter, r *http.Request) {
+	req := &pb.GetSavedSearchRequest{Id: r.PathValue("id")}
+	g.call(w, r, pb.GithubSearchService_GetSavedSearch_FullMethodName, req, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return g.service.GetSavedSearch(ctx, req.(*pb.GetSavedSearchRequest))
+	})
+}
+
+// handleUpdateSavedSearch maps PATCH /v1/saved-searches/{id}?update_mask=name,tags
+// with a SavedSearch body onto UpdateSavedSearch.
+func (g *Gateway) handleUpdateSavedSearch(w http.ResponseWriter, r *http.Request) {
+	req := &pb.UpdateSavedSearchRequest{SavedSearch: &pb.SavedSearch{}}
+	if err := readBody(r, req.SavedSearch); err != nil {
+		writeError(w, err)
+		return
+	}
+	req.SavedSearch.Id = r.PathValue("id")
+	if mask := r.URL.Query().Get("update_mask"); mask != "" {
+		req.UpdateMask = &fieldmaskpb.FieldMask{Paths: strings.Split(mask, ",")}
+	}
+	g.call(w, r, pb.GithubSearchService_UpdateSavedSearch_FullMethodName, req, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return g.service.UpdateSavedSearch(ctx, req.(*pb.UpdateSavedSearchRequest))
+	})
+}
+
+// handleDeleteSavedSearch maps DELETE /v1/saved-searches/{id} onto DeleteSavedSearch.
+func (g *Gateway) handleDeleteSavedSearch(w http.ResponseWriter, r *http.Request) {
+	req := &pb.DeleteSavedSearchRequest{Id: r.PathValue("id")}
+	g.call(w, r, pb.GithubSearchService_DeleteSavedSearch_FullMethodName, req, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return g.service.DeleteSavedSearch(ctx, req.(*pb.DeleteSavedSearchRequest))
+	})
+}
+
+// handleRunSavedSearch maps POST /v1/saved-searches/{id}/run?page=... onto RunSavedSearch.
+func (g *Gateway) handleRunSavedSearch(w http.ResponseWriter, r *http.Request) {
+	req := &pb.RunSavedSearchRequest{Id: r.PathValue("id")}
+	if raw := r.URL.Query().Get("page"); raw != "" {
+		n, err := strconv.ParseInt(raw, 10, 32)
+		if err != nil {
+			writeError(w, status.Errorf(codes.InvalidArgument, "invalid value for 'page': must be an integer"))
+			return
+		}
+		req.Page = proto.Int32(int32(n))
+	}
+	g.call(w, r, pb.GithubSearchService_RunSavedSearch_FullMethodName, req, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return g.service.RunSavedSearch(ctx, req.(*pb.RunSavedSearchRequest))
+	})
+}
+
+// handleCreateWatch maps POST /v1/watches with a Watch body onto CreateWatch.
+func (g *Gateway) handleCreateWatch(w http.ResponseWriter, r *http.Request) {
+	req := &pb.CreateWatchRequest{Watch: &pb.Watch{}}
+	if err := readBody(r, req.Watch); err != nil {
+		writeError(w, err)
+		return
+	}
+	g.call(w, r, pb.GithubSearchService_CreateWatch_FullMethodName, req, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return g.service.CreateWatch(ctx, req.(*pb.CreateWatchRequest))
+	})
+}
+
+// handleListWatches maps GET /v1/watches?page_size=...&page_token=... onto ListWatches.
+func (g *Gateway) handleListWatches(w http.ResponseWriter, r *http.Request) {
+	pageSize, err := pageSizeFromQuery(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	req := &pb.ListWatchesRequest{PageSize: pageSize, PageToken: r.URL.Query().Get("page_token")}
+	g.call(w, r, pb.GithubSearchService_ListWatches_FullMethodName, req, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return g.service.ListWatches(ctx, req.(*pb.ListWatchesRequest))
+	})
+}
+
+// handleGetWatch maps GET /v1/watches/{id} onto GetWatch.
+func (g *Gateway) handleGetWatch(w http.ResponseWriter, r *http.Request) {
+	req := &pb.GetWatchRequest{Id: r.PathValue("id")}
+	g.call(w, r, pb.GithubSearchService_GetWatch_FullMethodName, req, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return g.service.GetWatch(ctx, req.(*pb.GetWatchRequest))
+	})
+}
+
+// handleDeleteWatch maps DELETE /v1/watches/{id} onto DeleteWatch.
+func (g *Gateway) handleDeleteWatch(w http.ResponseWriter, r *http.Request) {
+	req := &pb.DeleteWatchRequest{Id: r.PathValue("id")}
+	g.call(w, r, pb.GithubSearchService_DeleteWatch_FullMethodName, req, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return g.service.DeleteWatch(ctx, req.(*pb.DeleteWatchRequest))
+	})
+}
+
+// handleListWatchEvents maps GET /v1/watches/{id}/events?page_size=...&page_token=...
+// onto ListWatchEvents.
+func (g *Gateway) handleListWatchEvents(w http.ResponseWriter, r *http.Request) {
+	pageSize, err := pageSizeFromQuery(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	req := &pb.ListWatchEventsRequest{
+		WatchId:   r.PathValue("id"),
+		PageSize:  pageSize,
+		PageToken: r.URL.Query().Get("page_token"),
+	}
+	g.call(w, r, pb.GithubSearchService_ListWatchEvents_FullMethodName, req, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return g.service.ListWatchEvents(ctx, req.(*pb.ListWatchEventsRequest))
+	})
+}
+
+// handleListDeliveries maps GET /v1/watches/{id}/deliveries?state=...&page_size=...&page_token=...
+// onto ListDeliveries; state is one of pending, succeeded, dead_letter.
+func (g *Gateway) handleListDeliveries(w http.ResponseWriter, r *http.Request) {
+	pageSize, err := pageSizeFromQuery(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	req := &pb.ListDeliveriesRequest{
+		WatchId:   r.PathValue("id"),
+		PageSize:  pageSize,
+		PageToken: r.URL.Query().Get("page_token"),
+	}
+	if state := r.URL.Query().Get("state"); state != "" {
+		value, ok := pb.WebhookDelivery_State_value["STATE_"+strings.ToUpper(state)]
+		if !ok {
+			writeError(w, status.Errorf(codes.InvalidArgument, "invalid state option: %s", state))
+			return
+		}
+		req.State = pb.WebhookDelivery_State(value)
+	}
+	g.call(w, r, pb.GithubSearchService_ListDeliveries_FullMethodName, req, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return g.service.ListDeliveries(ctx, req.(*pb.ListDeliveriesRequest))
+	})
+}
+
+// handleCreateSnapshot maps POST /v1/snapshots with a SearchRequest body onto CreateSnapshot.
+func (g *Gateway) handleCreateSnapshot(w http.ResponseWriter, r *http.Request) {
+	req := &pb.CreateSnapshotRequest{Request: &pb.SearchRequest{}}
+	if err := readBody(r, req.Request); err != nil {
+		writeError(w, err)
+		return
+	}
+	g.call(w, r, pb.GithubSearchService_CreateSnapshot_FullMethodName, req, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return g.service.CreateSnapshot(ctx, req.(*pb.CreateSnapshotRequest))
+	})
+}
+
+// handleListSnapshots maps GET /v1/snapshots?page_size=...&page_token=... onto ListSnapshots.
+func (g *Gateway) handleListSnapshots(w http.ResponseWriter, r *http.Request) {
+	pageSize, err := pageSizeFromQuery(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	req := &pb.ListSnapshotsRequest{PageSize: pageSize, PageToken: r.URL.Query().Get("page_token")}
+	g.call(w, r, pb.GithubSearchService_ListSnapshots_FullMethodName, req, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return g.service.ListSnapshots(ctx, req.(*pb.ListSnapshotsRequest))
+	})
+}
+
+// handleGetSnapshot maps GET /v1/snapshots/{id} onto GetSnapshot.
+func (g *Gateway) handleGetSnapshot(w http.ResponseWriter, r *http.Request) {
+	req := &pb.GetSnapshotRequest{Id: r.PathValue("id")}
+	g.call(w, r, pb.GithubSearchService_GetSnapshot_FullMethodName, req, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return g.service.GetSnapshot(ctx, req.(*pb.GetSnapshotRequest))
+	})
+}
+
+// handleDeleteSnapshot maps DELETE /v1/snapshots/{id} onto DeleteSnapshot.
+func (g *Gateway) handleDeleteSnapshot(w http.ResponseWriter, r *http.Request) {
+	req := &pb.DeleteSnapshotRequest{Id: r.PathValue("id")}
+	g.call(w, r, pb.GithubSearchService_DeleteSnapshot_FullMethodName, req, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return g.service.DeleteSnapshot(ctx, req.(*pb.DeleteSnapshotRequest))
+	})
+}
+
+// handleDiffSearch maps POST /v1/search/diff with a DiffSearchRequest body onto DiffSearch.
+func (g *Gateway) handleDiffSearch(w http.ResponseWriter, r *http.Request) {
+	req := &pb.DiffSearchRequest{}
+	if err := readBody(r, req); err != nil {
+		writeError(w, err)
+		return
+	}
+	g.call(w, r, pb.GithubSearchService_DiffSearch_FullMethodName, req, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return g.service.DiffSearch(ctx, req.(*pb.DiffSearchRequest))
+	})
+}
+
+// handleBatchSearch maps POST /v1/search/batch with a BatchSearchRequest body onto BatchSearch.
+func (g *Gateway) handleBatchSearch(w http.ResponseWriter, r *http.Request) {
+	req := &pb.BatchSearchRequest{}
+	if err := readBody(r, req); err != nil {
+		writeError(w, err)
+		return
+	}
+	g.call(w, r, pb.GithubSearchService_BatchSearch_FullMethodName, req, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return g.service.BatchSearch(ctx, req.(*pb.BatchSearchRequest))
+	})
+}
+
+// handleSearchFacets maps GET /v1/search/facets?q=...&user=...&max_results=...&facet_size=...
+// onto SearchFacets.
+func (g *Gateway) handleSearchFacets(w http.ResponseWriter, r *http.Request) {
+	searchReq, err := searchRequestFromQuery(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	req := &pb.SearchFacetsRequest{Request: searchReq}
+	for name, field := range map[string]*int32{"max_results": &req.MaxResults, "facet_size": &req.FacetSize} {
+		raw := r.URL.Query().Get(name)
+		if raw == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(raw, 10, 32)
+		if err != nil {
+			writeError(w, status.Errorf(codes.InvalidArgument, "invalid value for '%s': must be an integer", name))
+			return
+		}
+		*field = int32(n)
+	}
+	g.call(w, r, pb.GithubSearchService_SearchFacets_FullMethodName, req, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return g.service.SearchFacets(ctx, req.(*pb.SearchFacetsRequest))
+	})
+}
+
+// handleExhaustiveSearch maps POST /v1/search/exhaustive with an
+// ExhaustiveSearchRequest body onto ExhaustiveSearch.
+func (g *Gateway) handleExhaustiveSearch(w http.ResponseWriter, r *http.Request) {
+	req := &pb.ExhaustiveSearchRequest{}
+	if err := readBody(r, req); err != nil {
+		writeError(w, err)
+		return
+	}
+	g.call(w, r, pb.GithubSearchService_ExhaustiveSearch_FullMethodName, req, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return g.service.ExhaustiveSearch(ctx, req.(*pb.ExhaustiveSearchRequest))
+	})
+}
+
+// pageSizeFromQuery parses the optional page_size query parameter.
+func pageSizeFromQuery(r *http.Request) (int32, error) {
+	raw := r.URL.Query().Get("page_size")
+	if raw == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseInt(raw, 10, 32)
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid value for 'page_size': must be an integer")
+	}
+	return int32(n), nil
+}
+
+// readBody decodes the JSON request body into msg.
+func readBody(r *http.Request, msg proto.Message) error {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize))
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "failed to read request body: %v", err)
+	}
+	if err := protojson.Unmarshal(body, msg); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid request body: %v", err)
+	}
+	return nil
+}
+
+// call runs handler through the interceptor chain with the request headers
+// translated into incoming gRPC metadata, and writes the JSON response. The
+// request ID is echoed in the X-Request-Id response header. The connection's
+// TLS state is passed on as the gRPC peer, so a client certificate verified
+// by the gateway identifies the caller as it would over gRPC.
+func (g *Gateway) call(w http.ResponseWriter, r *http.Request, fullMethod string, req proto.Message, handler grpc.UnaryHandler) {
+	md := metadataFromHeaders(r.Header)
+	requestID := r.Header.Get(logging.RequestIDHeader)
+	if !logging.ValidRequestID(requestID) {
+		requestID = logging.NewRequestID()
+	}
+	md.Set(logging.RequestIDHeader, requestID)
+	w.Header().Set(logging.RequestIDHeader, requestID)
+
+	ctx := peer.NewContext(metadata.NewIncomingContext(r.Context(), md), peerFromRequest(r))
+	info := &grpc.UnaryServerInfo{Server: g.service, FullMethod: fullMethod}
+
+	resp, err := g.interceptor(ctx, req, info, handler)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	body, err := marshalOptions.Marshal(resp.(proto.Message))
+	if err != nil {
+		writeError(w, status.Errorf(codes.Internal, "failed to encode response: %v", err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
+
+// peerFromRequest describes the client of r as a gRPC peer, with the TLS
+// state of the connection if it uses TLS.
+func peerFromRequest(r *http.Request) *peer.Peer {
+	p := &peer.Peer{}
+	if addr, err := netip.ParseAddrPort(r.RemoteAddr); err == nil {
+		p.Addr = net.TCPAddrFromAddrPort(addr)
+	}
+	if r.TLS != nil {
+		p.AuthInfo = credentials.TLSInfo{
+			State:          *r.TLS,
+			CommonAuthInfo: credentials.CommonAuthInfo{SecurityLevel: credentials.PrivacyAndIntegrity},
+		}
+	}
+	return p
+}
+
+// metadataFromHeaders builds the gRPC metadata the interceptors expect. The
+// GitHub token is taken from X-GitHub-Token or from an Authorization header
+// using the Bearer or token scheme. W3C trace context headers are passed
+// through so gateway calls join the caller's trace.
+func metadataFromHeaders(header http.Header) metadata.MD {
+	md := metadata.MD{}
+	for _, key := range []string{"traceparent", "tracestate", "baggage"} {
+		if value := header.Get(key); value != "" {
+			md.Set(key, value)
+		}
+	}
+	if token := header.Get("X-GitHub-Token"); token != "" {
+		md.Set("github-token", token)
+	} else if scheme, token, ok := strings.Cut(header.Get("Authorization"), " "); ok &&
+		(strings.EqualFold(scheme, "Bearer") || strings.EqualFold(scheme, "token")) {
+		md.Set("github-token", strings.TrimSpace(token))
+	}
+	return md
+}
+
+func searchRequestFromQuery(r *http.Request) (*pb.SearchRequest, error) {
+	query := r.URL.Query()
+	req := &pb.SearchRequest{
+		SearchTerm: query.Get("q"),
+		User:       query.Get("user"),
+	}
+
+	if sort := query.Get("sort"); sort != "" {
+		value, ok := pb.SortOption_value["SORT_"+strings.ToUpper(sort)]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid sort option: %s", sort)
+		}
+		req.Sort = pb.SortOption(value)
+	}
+	if order := query.Get("order"); order != "" {
+		value, ok := pb.OrderOption_value["ORDER_"+strings.ToUpper(order)]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid order option: %s", order)
+		}
+		req.Order = pb.OrderOption(value)
+	}
+	if dedup := query.Get("dedup"); dedup != "" {
+		value, ok := pb.DedupMode_value["DEDUP_"+strings.ToUpper(dedup)]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid dedup option: %s", dedup)
+		}
+		req.Dedup = pb.DedupMode(value)
+	}
+	if representative := query.Get("representative"); representative != "" {
+		value, ok := pb.Representative_value["REPRESENTATIVE_"+strings.ToUpper(representative)]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid representative option: %s", representative)
+		}
+		req.Representative = pb.Representative(value)
+	}
+
+	req.IncludePaths, req.ExcludePaths = query["include_path"], query["exclude_path"]
+	req.IncludeRepos, req.ExcludeRepos = query["include_repo"], query["exclude_repo"]
+	for name, field := range map[string]*bool{"exclude_forks": &req.ExcludeForks, "exclude_archived": &req.ExcludeArchived} {
+		raw := query.Get(name)
+		if raw == "" {
+			continue
+		}
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid value for '%s': must be a boolean", name)
+		}
+		*field = value
+	}
+
+	weights := &pb.RankingWeights{}
+	for name, field := range map[string]*float64{
+		"rank_path_depth": &weights.PathDepth, "rank_non_test": &weights.NonTest, "rank_stars": &weights.Stars,
+		"rank_recency": &weights.Recency, "rank_caller_owned": &weights.CallerOwned, "rank_text_matches": &weights.TextMatches,
+	} {
+		raw := query.Get(name)
+		if raw == "" {
+			continue
+		}
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid value for '%s': must be a number", name)
+		}
+		*field = value
+		req.Ranking = weights
+	}
+
+	for name, field := range map[string]**int32{"per_page": &req.PerPage, "page": &req.Page} {
+		raw := query.Get(name)
+		if raw == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(raw, 10, 32)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid value for '%s': must be an integer", name)
+		}
+		*field = proto.Int32(int32(n))
+	}
+	return req, nil
+}
+
+// writeError writes the gRPC status of err as a JSON google.rpc.Status body
+// with the corresponding HTTP status code.
+func writeError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+	body, marshalErr := marshalOptions.Marshal(st.Proto())
+	if marshalErr != nil {
+		body = []byte(`{"code":13,"message":"failed to encode error"}`)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(HTTPStatusFromCode(st.Code()))
+	w.Write(body)
+}
+
+// HTTPStatusFromCode maps a gRPC status code to the equivalent HTTP status,
+// following the mapping documented in google/rpc/code.proto.
+func HTTPStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499 // Client Closed Request
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
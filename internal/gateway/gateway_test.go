@@ -0,0 +1,313 @@
+package gateway
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/Pratham700/github-search-service/internal/logging"
+	"github.com/Pratham700/github-search-service/internal/server"
+	"github.com/Pratham700/github-search-service/internal/util"
+	pb "github.com/Pratham700/github-search-service/proto/proto"
+)
+
+// quotaService answers GetQuota with an empty quota.
+type quotaService struct {
+	pb.UnimplementedGithubSearchServiceServer
+}
+
+func (quotaService) GetQuota(context.Context, *pb.GetQuotaRequest) (*pb.GetQuotaResponse, error) {
+	return &pb.GetQuotaResponse{}, nil
+}
+
+func TestCallerIdentity(t *testing.T) {
+	verified := &tls.ConnectionState{
+		VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: "alice"}}}},
+	}
+	tests := []struct {
+		name  string
+		tls   *tls.ConnectionState
+		token string
+		want  string
+	}{
+		{"token", nil, "secret", "token:" + util.HashToken("secret")},
+		{"client certificate", verified, "secret", "cert:alice"},
+		{"unverified certificate", &tls.ConnectionState{}, "secret", "token:" + util.HashToken("secret")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			interceptor := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+				return server.AuthInterceptor(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+					got, _ = server.GetCallerIdentityFromContext(ctx)
+					return handler(ctx, req)
+				})
+			}
+			r := httptest.NewRequest(http.MethodGet, "/v1/quota", nil)
+			r.TLS = tt.tls
+			r.Header.Set("X-GitHub-Token", tt.token)
+			w := httptest.NewRecorder()
+			New(quotaService{}, interceptor).ServeHTTP(w, r)
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+			}
+			if got != tt.want {
+				t.Errorf("identity = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// echoInterceptor records the method, request and metadata of each call and
+// answers with the request instead of calling the service.
+type echoInterceptor struct {
+	method string
+	req    proto.Message
+	md     metadata.MD
+}
+
+func (e *echoInterceptor) intercept(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, _ grpc.UnaryHandler) (interface{}, error) {
+	e.method, e.req = info.FullMethod, req.(proto.Message)
+	e.md, _ = metadata.FromIncomingContext(ctx)
+	return req, nil
+}
+
+func TestRoutes(t *testing.T) {
+	before := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		method, target, body string
+		wantMethod           string
+		wantReq              proto.Message
+	}{
+		{
+			"GET", "/v1/search/code?q=foo&user=o&sort=indexed&order=asc&per_page=5&include_path=a&include_path=b&exclude_forks=true", "",
+			pb.GithubSearchService_Search_FullMethodName,
+			&pb.SearchRequest{
+				SearchTerm: "foo", User: "o", Sort: pb.SortOption_SORT_INDEXED, Order: pb.OrderOption_ORDER_ASC,
+				PerPage: proto.Int32(5), IncludePaths: []string{"a", "b"}, ExcludeForks: true,
+			},
+		},
+		{"GET", "/v1/quota", "", pb.GithubSearchService_GetQuota_FullMethodName, &pb.GetQuotaRequest{}},
+		{
+			"GET", "/v1/history?page_size=10&page_token=t", "", pb.GithubSearchService_ListRecentSearches_FullMethodName,
+			&pb.ListRecentSearchesRequest{PageSize: 10, PageToken: "t"},
+		},
+		{
+			"DELETE", "/v1/history?before=" + before.Format(time.RFC3339), "", pb.GithubSearchService_DeleteSearchHistory_FullMethodName,
+			&pb.DeleteSearchHistoryRequest{Before: timestamppb.New(before)},
+		},
+		{
+			"POST", "/v1/saved-searches", `{"name":"n","request":{"search_term":"foo"}}`, pb.GithubSearchService_CreateSavedSearch_FullMethodName,
+			&pb.CreateSavedSearchRequest{SavedSearch: &pb.SavedSearch{Name: "n", Request: &pb.SearchRequest{SearchTerm: "foo"}}},
+		},
+		{
+			"GET", "/v1/saved-searches?tag=x", "", pb.GithubSearchService_ListSavedSearches_FullMethodName,
+			&pb.ListSavedSearchesRequest{Tag: "x"},
+		},
+		{"GET", "/v1/saved-searches/s1", "", pb.GithubSearchService_GetSavedSearch_FullMethodName, &pb.GetSavedSearchRequest{Id: "s1"}},
+		{
+			"PATCH", "/v1/saved-searches/s1?update_mask=name,tags", `{"id":"ignored","name":"n"}`, pb.GithubSearchService_UpdateSavedSearch_FullMethodName,
+			&pb.UpdateSavedSearchRequest{SavedSearch: &pb.SavedSearch{Id: "s1", Name: "n"}, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"name", "tags"}}},
+		},
+		{"DELETE", "/v1/saved-searches/s1", "", pb.GithubSearchService_DeleteSavedSearch_FullMethodName, &pb.DeleteSavedSearchRequest{Id: "s1"}},
+		{
+			"POST", "/v1/saved-searches/s1/run?page=2", "", pb.GithubSearchService_RunSavedSearch_FullMethodName,
+			&pb.RunSavedSearchRequest{Id: "s1", Page: proto.Int32(2)},
+		},
+		{
+			"POST", "/v1/watches", `{"request":{"search_term":"foo"}}`, pb.GithubSearchService_CreateWatch_FullMethodName,
+			&pb.CreateWatchRequest{Watch: &pb.Watch{Request: &pb.SearchRequest{SearchTerm: "foo"}}},
+		},
+		{"GET", "/v1/watches", "", pb.GithubSearchService_ListWatches_FullMethodName, &pb.ListWatchesRequest{}},
+		{"GET", "/v1/watches/w1", "", pb.GithubSearchService_GetWatch_FullMethodName, &pb.GetWatchRequest{Id: "w1"}},
+		{"DELETE", "/v1/watches/w1", "", pb.GithubSearchService_DeleteWatch_FullMethodName, &pb.DeleteWatchRequest{Id: "w1"}},
+		{
+			"GET", "/v1/watches/w1/events?page_size=3", "", pb.GithubSearchService_ListWatchEvents_FullMethodName,
+			&pb.ListWatchEventsRequest{WatchId: "w1", PageSize: 3},
+		},
+		{
+			"GET", "/v1/watches/w1/deliveries?state=dead_letter", "", pb.GithubSearchService_ListDeliveries_FullMethodName,
+			&pb.ListDeliveriesRequest{WatchId: "w1", State: pb.WebhookDelivery_STATE_DEAD_LETTER},
+		},
+		{
+			"POST", "/v1/snapshots", `{"search_term":"foo"}`, pb.GithubSearchService_CreateSnapshot_FullMethodName,
+			&pb.CreateSnapshotRequest{Request: &pb.SearchRequest{SearchTerm: "foo"}},
+		},
+		{"GET", "/v1/snapshots", "", pb.GithubSearchService_ListSnapshots_FullMethodName, &pb.ListSnapshotsRequest{}},
+		{"GET", "/v1/snapshots/p1", "", pb.GithubSearchService_GetSnapshot_FullMethodName, &pb.GetSnapshotRequest{Id: "p1"}},
+		{"DELETE", "/v1/snapshots/p1", "", pb.GithubSearchService_DeleteSnapshot_FullMethodName, &pb.DeleteSnapshotRequest{Id: "p1"}},
+		{
+			"POST", "/v1/search/diff", `{"base_snapshot_id":"p1"}`, pb.GithubSearchService_DiffSearch_FullMethodName,
+			&pb.DiffSearchRequest{BaseSnapshotId: "p1"},
+		},
+		{
+			"POST", "/v1/search/batch", `{"requests":[{"search_term":"foo"}]}`, pb.GithubSearchService_BatchSearch_FullMethodName,
+			&pb.BatchSearchRequest{Requests: []*pb.SearchRequest{{SearchTerm: "foo"}}},
+		},
+		{
+			"GET", "/v1/search/facets?q=foo&max_results=50&facet_size=5", "", pb.GithubSearchService_SearchFacets_FullMethodName,
+			&pb.SearchFacetsRequest{Request: &pb.SearchRequest{SearchTerm: "foo"}, MaxResults: 50, FacetSize: 5},
+		},
+		{
+			"POST", "/v1/search/exhaustive", `{"request":{"search_term":"foo"}}`, pb.GithubSearchService_ExhaustiveSearch_FullMethodName,
+			&pb.ExhaustiveSearchRequest{Request: &pb.SearchRequest{SearchTerm: "foo"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.target, func(t *testing.T) {
+			var e echoInterceptor
+			r := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			New(quotaService{}, e.intercept).ServeHTTP(w, r)
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+			}
+			if e.method != tt.wantMethod {
+				t.Errorf("method = %s, want %s", e.method, tt.wantMethod)
+			}
+			if !proto.Equal(e.req, tt.wantReq) {
+				t.Errorf("request = %v, want %v", e.req, tt.wantReq)
+			}
+			if got := w.Header().Get("Content-Type"); got != "application/json" {
+				t.Errorf("Content-Type = %q, want application/json", got)
+			}
+		})
+	}
+}
+
+func TestRouteErrors(t *testing.T) {
+	tests := []struct {
+		method, target, body string
+		want                 int
+	}{
+		{"GET", "/v1/search/code?sort=size", "", http.StatusBadRequest},
+		{"GET", "/v1/search/code?per_page=many", "", http.StatusBadRequest},
+		{"GET", "/v1/search/code?exclude_forks=maybe", "", http.StatusBadRequest},
+		{"GET", "/v1/search/code?rank_stars=lots", "", http.StatusBadRequest},
+		{"GET", "/v1/history?page_size=all", "", http.StatusBadRequest},
+		{"DELETE", "/v1/history?before=yesterday", "", http.StatusBadRequest},
+		{"POST", "/v1/saved-searches", "{", http.StatusBadRequest},
+		{"POST", "/v1/saved-searches", `{"unknown":1}`, http.StatusBadRequest},
+		{"GET", "/v1/watches/w1/deliveries?state=lost", "", http.StatusBadRequest},
+		{"GET", "/v1/search/facets?facet_size=big", "", http.StatusBadRequest},
+		{"GET", "/v1/unknown", "", http.StatusNotFound},
+		{"PUT", "/v1/watches/w1", "", http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.target, func(t *testing.T) {
+			var e echoInterceptor
+			r := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			New(quotaService{}, e.intercept).ServeHTTP(w, r)
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.want, w.Body)
+			}
+			if e.method != "" {
+				t.Errorf("called %s for an invalid request", e.method)
+			}
+		})
+	}
+}
+
+func TestServiceErrors(t *testing.T) {
+	interceptor := func(context.Context, interface{}, *grpc.UnaryServerInfo, grpc.UnaryHandler) (interface{}, error) {
+		return nil, status.Errorf(codes.NotFound, "saved search not found")
+	}
+	r := httptest.NewRequest(http.MethodGet, "/v1/saved-searches/s1", nil)
+	w := httptest.NewRecorder()
+	New(quotaService{}, interceptor).ServeHTTP(w, r)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+	if body := w.Body.String(); !strings.Contains(body, `"code":5`) || !strings.Contains(body, "saved search not found") {
+		t.Errorf("body = %s, want the gRPC status", body)
+	}
+}
+
+func TestRequestMetadata(t *testing.T) {
+	tests := []struct {
+		name      string
+		header    map[string]string
+		wantToken string
+		wantID    func(string) bool
+	}{
+		{
+			"X-GitHub-Token wins", map[string]string{"X-GitHub-Token": "a", "Authorization": "Bearer b"}, "a",
+			logging.ValidRequestID,
+		},
+		{"bearer scheme", map[string]string{"Authorization": "Bearer b"}, "b", logging.ValidRequestID},
+		{"token scheme", map[string]string{"Authorization": "token  c "}, "c", logging.ValidRequestID},
+		{"basic scheme ignored", map[string]string{"Authorization": "Basic d"}, "", logging.ValidRequestID},
+		{
+			"request id kept", map[string]string{logging.RequestIDHeader: "req-1"}, "",
+			func(id string) bool { return id == "req-1" },
+		},
+		{
+			"invalid request id replaced", map[string]string{logging.RequestIDHeader: strings.Repeat("x", 200)}, "",
+			func(id string) bool { return logging.ValidRequestID(id) && len(id) < 200 },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var e echoInterceptor
+			r := httptest.NewRequest(http.MethodGet, "/v1/quota", nil)
+			for key, value := range tt.header {
+				r.Header.Set(key, value)
+			}
+			r.Header.Set("traceparent", "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+			w := httptest.NewRecorder()
+			New(quotaService{}, e.intercept).ServeHTTP(w, r)
+
+			if got := strings.Join(e.md.Get("github-token"), ","); got != tt.wantToken {
+				t.Errorf("github-token = %q, want %q", got, tt.wantToken)
+			}
+			id := w.Header().Get(logging.RequestIDHeader)
+			if !tt.wantID(id) || strings.Join(e.md.Get(logging.RequestIDHeader), ",") != id {
+				t.Errorf("request ID = %q, metadata %q", id, e.md.Get(logging.RequestIDHeader))
+			}
+			if len(e.md.Get("traceparent")) != 1 {
+				t.Errorf("traceparent was not passed on")
+			}
+		})
+	}
+}
+
+func TestHTTPStatusFromCode(t *testing.T) {
+	tests := []struct {
+		code codes.Code
+		want int
+	}{
+		{codes.OK, http.StatusOK},
+		{codes.Canceled, 499},
+		{codes.InvalidArgument, http.StatusBadRequest},
+		{codes.FailedPrecondition, http.StatusBadRequest},
+		{codes.DeadlineExceeded, http.StatusGatewayTimeout},
+		{codes.NotFound, http.StatusNotFound},
+		{codes.AlreadyExists, http.StatusConflict},
+		{codes.PermissionDenied, http.StatusForbidden},
+		{codes.Unauthenticated, http.StatusUnauthorized},
+		{codes.ResourceExhausted, http.StatusTooManyRequests},
+		{codes.Unimplemented, http.StatusNotImplemented},
+		{codes.Unavailable, http.StatusServiceUnavailable},
+		{codes.DataLoss, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		if got := HTTPStatusFromCode(tt.code); got != tt.want {
+			t.Errorf("HTTPStatusFromCode(%v) = %d, want %d", tt.code, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package gateway
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/improbable-eng/grpc-web/go/grpcweb"
+	"google.golang.org/grpc"
+)
+
+// OriginAllowed returns a function reporting whether a browser origin may call
+// the service. An allowed origin of "*" admits every origin.
+func OriginAllowed(allowedOrigins []string) func(origin string) bool {
+	return func(origin string) bool {
+		return slices.Contains(allowedOrigins, "*") || slices.Contains(allowedOrigins, origin)
+	}
+}
+
+// WithGRPCWeb serves gRPC-Web requests, including server streaming and their
+// CORS preflights, from grpcServer and hands every other request to next.
+func WithGRPCWeb(grpcServer *grpc.Server, allowedOrigins []string, next http.Handler) http.Handler {
+	wrapped := grpcweb.WrapServer(grpcServer,
+		grpcweb.WithOriginFunc(OriginAllowed(allowedOrigins)),
+		grpcweb.WithAllowNonRootResource(false),
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if wrapped.IsGrpcWebRequest(r) || wrapped.IsAcceptableGrpcCorsRequest(r) {
+			wrapped.ServeHTTP(w, r)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// WithCORS answers CORS preflights and sets the CORS response headers for
+// requests from allowed origins, so browser tools can call the REST gateway.
+func WithCORS(allowedOrigins []string, next http.Handler) http.Handler {
+	allowed := OriginAllowed(allowedOrigins)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin == "" || !allowed(origin) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		w.Header().Add("Vary", "Origin")
+		w.Header().Set("Access-Control-Expose-Headers", "X-Request-Id")
+		if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PATCH, DELETE, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Authorization, X-GitHub-Token, Content-Type, X-Request-Id")
+			w.Header().Set("Access-Control-Max-Age", "600")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,164 @@
+package gateway
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+
+	pb "github.com/Pratham700/github-search-service/proto/proto"
+)
+
+// nextHandler records whether a request reached the handler after the CORS layer.
+type nextHandler struct{ called bool }
+
+func (n *nextHandler) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	n.called = true
+	w.WriteHeader(http.StatusOK)
+}
+
+func TestOriginAllowed(t *testing.T) {
+	tests := []struct {
+		name    string
+		allowed []string
+		origin  string
+		want    bool
+	}{
+		{"listed", []string{"https://a.example.com"}, "https://a.example.com", true},
+		{"not listed", []string{"https://a.example.com"}, "https://b.example.com", false},
+		{"no origins", nil, "https://a.example.com", false},
+		{"wildcard", []string{"*"}, "https://b.example.com", true},
+		{"no partial match", []string{"https://a.example.com"}, "https://a.example.com.evil", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := OriginAllowed(tt.allowed)(tt.origin); got != tt.want {
+				t.Errorf("OriginAllowed(%v)(%q) = %v, want %v", tt.allowed, tt.origin, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithCORS(t *testing.T) {
+	const allowed = "https://a.example.com"
+	tests := []struct {
+		name          string
+		method        string
+		origin        string
+		preflight     string
+		wantNext      bool
+		wantStatus    int
+		wantOrigin    string
+		wantMethods   []string
+		wantHeaders   []string
+		wantExposeIDs bool
+	}{
+		{
+			name: "preflight for a write method", method: http.MethodOptions, origin: allowed, preflight: http.MethodPatch,
+			wantStatus: http.StatusNoContent, wantOrigin: allowed,
+			wantMethods:   []string{"GET", "POST", "PATCH", "DELETE"},
+			wantHeaders:   []string{"Authorization", "X-GitHub-Token", "Content-Type", "X-Request-Id"},
+			wantExposeIDs: true,
+		},
+		{
+			name: "request from an allowed origin", method: http.MethodDelete, origin: allowed,
+			wantNext: true, wantStatus: http.StatusOK, wantOrigin: allowed, wantExposeIDs: true,
+		},
+		{
+			name: "preflight from another origin", method: http.MethodOptions, origin: "https://b.example.com", preflight: http.MethodPost,
+			wantNext: true, wantStatus: http.StatusOK,
+		},
+		{name: "same-origin request", method: http.MethodGet, wantNext: true, wantStatus: http.StatusOK},
+		{
+			name: "OPTIONS without a requested method", method: http.MethodOptions, origin: allowed,
+			wantNext: true, wantStatus: http.StatusOK, wantOrigin: allowed, wantExposeIDs: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var next nextHandler
+			r := httptest.NewRequest(tt.method, "/v1/saved-searches/s1", nil)
+			if tt.origin != "" {
+				r.Header.Set("Origin", tt.origin)
+			}
+			if tt.preflight != "" {
+				r.Header.Set("Access-Control-Request-Method", tt.preflight)
+			}
+			w := httptest.NewRecorder()
+			WithCORS([]string{allowed}, &next).ServeHTTP(w, r)
+
+			if next.called != tt.wantNext || w.Code != tt.wantStatus {
+				t.Fatalf("next called = %v, status = %d, want %v, %d", next.called, w.Code, tt.wantNext, tt.wantStatus)
+			}
+			header := w.Header()
+			if got := header.Get("Access-Control-Allow-Origin"); got != tt.wantOrigin {
+				t.Errorf("Access-Control-Allow-Origin = %q, want %q", got, tt.wantOrigin)
+			}
+			if got := header.Get("Access-Control-Expose-Headers") == "X-Request-Id"; got != tt.wantExposeIDs {
+				t.Errorf("Access-Control-Expose-Headers = %q", header.Get("Access-Control-Expose-Headers"))
+			}
+			for _, method := range tt.wantMethods {
+				if !strings.Contains(header.Get("Access-Control-Allow-Methods"), method) {
+					t.Errorf("Access-Control-Allow-Methods = %q, want %s", header.Get("Access-Control-Allow-Methods"), method)
+				}
+			}
+			for _, name := range tt.wantHeaders {
+				if !strings.Contains(header.Get("Access-Control-Allow-Headers"), name) {
+					t.Errorf("Access-Control-Allow-Headers = %q, want %s", header.Get("Access-Control-Allow-Headers"), name)
+				}
+			}
+		})
+	}
+}
+
+func TestWithGRPCWeb(t *testing.T) {
+	const allowed = "https://a.example.com"
+	tests := []struct {
+		name       string
+		method     string
+		origin     string
+		header     map[string]string
+		wantNext   bool
+		wantOrigin string
+	}{
+		{
+			name: "gRPC-Web preflight", method: http.MethodOptions, origin: allowed,
+			header:     map[string]string{"Access-Control-Request-Method": "POST", "Access-Control-Request-Headers": "x-grpc-web, content-type"},
+			wantOrigin: allowed,
+		},
+		{
+			name: "REST preflight", method: http.MethodOptions, origin: allowed,
+			header:   map[string]string{"Access-Control-Request-Method": "POST", "Access-Control-Request-Headers": "content-type"},
+			wantNext: true,
+		},
+		{
+			name: "gRPC-Web call", method: http.MethodPost, origin: allowed,
+			header:     map[string]string{"Content-Type": "application/grpc-web+proto"},
+			wantOrigin: allowed,
+		},
+		{name: "REST call", method: http.MethodGet, origin: allowed, wantNext: true},
+	}
+	grpcServer := grpc.NewServer()
+	pb.RegisterGithubSearchServiceServer(grpcServer, quotaService{})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var next nextHandler
+			r := httptest.NewRequest(tt.method, pb.GithubSearchService_GetQuota_FullMethodName, nil)
+			r.Header.Set("Origin", tt.origin)
+			for key, value := range tt.header {
+				r.Header.Set(key, value)
+			}
+			w := httptest.NewRecorder()
+			WithGRPCWeb(grpcServer, []string{allowed}, &next).ServeHTTP(w, r)
+
+			if next.called != tt.wantNext {
+				t.Errorf("next called = %v, want %v", next.called, tt.wantNext)
+			}
+			if got := w.Header().Get("Access-Control-Allow-Origin"); got != tt.wantOrigin {
+				t.Errorf("Access-Control-Allow-Origin = %q, want %q", got, tt.wantOrigin)
+			}
+		})
+	}
+}
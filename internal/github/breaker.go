@@ -0,0 +1,171 @@
+package github
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/Pratham700/github-search-service/internal/metrics"
+)
+
+// ErrCircuitOpen is returned when a request is rejected because the circuit
+// breaker for the GitHub host is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// BreakerState is the state of a circuit breaker.
+type BreakerState int
+
+const (
+	BreakerClosed BreakerState = iota
+	BreakerOpen
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// BreakerConfig configures the circuit breaker kept for each GitHub host.
+type BreakerConfig struct {
+	// FailureRatio is the share of failed requests within Window that trips the breaker.
+	FailureRatio float64
+	// MinRequests is the number of requests within Window required before the ratio is evaluated.
+	MinRequests int
+	// Window is the length of the fixed window over which failures are
+	// counted: the counts reset once a request arrives more than Window after
+	// the window started, so a burst of failures that straddles two windows
+	// may not trip the breaker.
+	Window time.Duration
+	// CoolDown is how long the breaker stays open before letting a probe request through.
+	CoolDown time.Duration
+}
+
+// circuitBreaker implements a closed/open/half-open breaker over a fixed window.
+type circuitBreaker struct {
+	host        string
+	mu          sync.Mutex
+	config      BreakerConfig
+	state       BreakerState
+	requests    int
+	failures    int
+	windowStart time.Time
+	openedAt    time.Time
+	probing     bool
+	now         func() time.Time
+}
+
+func newCircuitBreaker(host string, config BreakerConfig) *circuitBreaker {
+	b := &circuitBreaker{host: host, config: config, now: time.Now}
+	b.setState(BreakerClosed)
+	return b
+}
+
+// allow reports whether a request may be sent, returning ErrCircuitOpen otherwise.
+// In the half-open state only a single probe request is let through at a time.
+func (b *circuitBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.currentState() {
+	case BreakerOpen:
+		return ErrCircuitOpen
+	case BreakerHalfOpen:
+		if b.probing {
+			return ErrCircuitOpen
+		}
+		b.probing = true
+	}
+	return nil
+}
+
+// record feeds the outcome of a request allowed by allow back into the breaker.
+func (b *circuitBreaker) record(success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	switch b.currentState() {
+	case BreakerHalfOpen:
+		b.probing = false
+		if success {
+			b.reset(BreakerClosed, now)
+		} else {
+			b.trip(now)
+		}
+		return
+	case BreakerOpen:
+		// A request allowed before the breaker tripped; its outcome is stale.
+		return
+	}
+
+	if now.Sub(b.windowStart) > b.config.Window {
+		b.reset(BreakerClosed, now)
+	}
+	b.requests++
+	if !success {
+		b.failures++
+	}
+	if b.requests >= b.config.MinRequests &&
+		float64(b.failures)/float64(b.requests) >= b.config.FailureRatio {
+		b.trip(now)
+	}
+}
+
+// abandon releases a request allowed by allow without recording an outcome.
+func (b *circuitBreaker) abandon() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+// setConfig applies config from now on, keeping the state and the counts of
+// the current window.
+func (b *circuitBreaker) setConfig(config BreakerConfig) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.config = config
+}
+
+// State returns the current state of the breaker.
+func (b *circuitBreaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.currentState()
+}
+
+// currentState moves an open breaker to half-open once the cool-down has
+// elapsed. The caller must hold b.mu.
+func (b *circuitBreaker) currentState() BreakerState {
+	if b.state == BreakerOpen && b.now().Sub(b.openedAt) >= b.config.CoolDown {
+		b.setState(BreakerHalfOpen)
+		b.probing = false
+	}
+	return b.state
+}
+
+// setState changes the state and exports it as a metric.
+func (b *circuitBreaker) setState(state BreakerState) {
+	b.state = state
+	metrics.SetGitHubBreakerState(b.host, int(state))
+}
+
+func (b *circuitBreaker) trip(now time.Time) {
+	b.reset(BreakerOpen, now)
+	b.openedAt = now
+}
+
+func (b *circuitBreaker) reset(state BreakerState, now time.Time) {
+	b.setState(state)
+	b.requests = 0
+	b.failures = 0
+	b.windowStart = now
+}
@@ -0,0 +1,117 @@
+package github
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeClock is a settable time source for circuit breakers.
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time          { return c.t }
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newTestBreaker(clock *fakeClock) *circuitBreaker {
+	b := newCircuitBreaker("test.invalid", BreakerConfig{
+		FailureRatio: 0.5,
+		MinRequests:  4,
+		Window:       time.Minute,
+		CoolDown:     10 * time.Second,
+	})
+	b.now = clock.now
+	b.windowStart = clock.t
+	return b
+}
+
+// send runs one request through b with the given outcome, reporting whether
+// it was allowed.
+func send(b *circuitBreaker, success bool) bool {
+	if err := b.allow(); err != nil {
+		return false
+	}
+	b.record(success)
+	return true
+}
+
+func TestCircuitBreakerTransitions(t *testing.T) {
+	// A step is a request with the given outcome, or a pause when wait is set.
+	type step struct {
+		wait    time.Duration
+		success bool
+		allowed bool
+	}
+	ok := step{success: true, allowed: true}
+	fail := step{success: false, allowed: true}
+	rejected := step{allowed: false}
+	wait := func(d time.Duration) step { return step{wait: d} }
+
+	tests := []struct {
+		name  string
+		steps []step
+		want  BreakerState
+	}{
+		{"closed below min requests", []step{fail, fail, fail}, BreakerClosed},
+		{"closed below failure ratio", []step{ok, ok, ok, fail, ok}, BreakerClosed},
+		{"trips at failure ratio", []step{ok, fail, ok, fail}, BreakerOpen},
+		{"open rejects", []step{fail, fail, fail, fail, rejected}, BreakerOpen},
+		{"half-open after cool-down", []step{fail, fail, fail, fail, wait(10 * time.Second)}, BreakerHalfOpen},
+		{"successful probe closes", []step{fail, fail, fail, fail, wait(10 * time.Second), ok}, BreakerClosed},
+		{"failed probe reopens", []step{fail, fail, fail, fail, wait(10 * time.Second), fail, rejected}, BreakerOpen},
+		{"window resets counts", []step{ok, fail, fail, wait(2 * time.Minute), fail, ok, ok}, BreakerClosed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := &fakeClock{t: time.Unix(1700000000, 0)}
+			b := newTestBreaker(clock)
+			for i, s := range tt.steps {
+				if s.wait > 0 {
+					clock.advance(s.wait)
+					continue
+				}
+				clock.advance(time.Millisecond)
+				if got := send(b, s.success); got != s.allowed {
+					t.Fatalf("step %d: allowed = %v, want %v", i, got, s.allowed)
+				}
+			}
+			if got := b.State(); got != tt.want {
+				t.Errorf("State() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCircuitBreakerSingleProbe(t *testing.T) {
+	clock := &fakeClock{t: time.Unix(1700000000, 0)}
+	b := newTestBreaker(clock)
+	for range 4 {
+		send(b, false)
+	}
+	clock.advance(10 * time.Second)
+
+	if err := b.allow(); err != nil {
+		t.Fatalf("allow() of the probe error = %v", err)
+	}
+	if err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("allow() during the probe error = %v, want ErrCircuitOpen", err)
+	}
+	b.abandon()
+	if err := b.allow(); err != nil {
+		t.Fatalf("allow() after an abandoned probe error = %v", err)
+	}
+}
+
+func TestReconfiguredKeepsBreakers(t *testing.T) {
+	config := BreakerConfig{FailureRatio: 0.5, MinRequests: 1, Window: time.Minute, CoolDown: time.Hour}
+	c := NewGitHubClient(ClientConfig{Breaker: config})
+	send(c.breakerFor("api.github.com"), false)
+
+	config.MinRequests = 10
+	next := c.Reconfigured(ClientConfig{Breaker: config})
+	if got := next.BreakerStates()["api.github.com"]; got != BreakerOpen {
+		t.Errorf("breaker state after Reconfigured = %v, want %v", got, BreakerOpen)
+	}
+	if got := next.breakerFor("api.github.com").config.MinRequests; got != 10 {
+		t.Errorf("MinRequests after Reconfigured = %d, want 10", got)
+	}
+}
@@ -7,7 +7,16 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+
+	"github.com/Pratham700/github-search-service/internal/metrics"
+	"github.com/Pratham700/github-search-service/internal/util"
 )
 
 // GitHubClient is a client for interacting with the GitHub API.
@@ -16,6 +25,13 @@ type GitHubClient struct {
 
 	// Base URL for the GitHub API
 	baseURL string
+
+	retryConfig RetryConfig
+
+	// Circuit breakers keyed by GitHub host
+	breakerConfig BreakerConfig
+	breakersMu    sync.Mutex
+	breakers      map[string]*circuitBreaker
 }
 
 type GithubSearchCodeResponse struct {
@@ -27,8 +43,20 @@ type GithubSearchCodeResponse struct {
 type GitHubSearchItem struct {
 	Name       string `json:"name"`
 	Path       string `json:"path"`
+	SHA        string `json:"sha"`
 	HTMLURL    string `json:"html_url"`
 	Repository GitHubRepository
+	// Only set by SearchCodeWithTextMatches
+	TextMatches []GitHubTextMatch `json:"text_matches"`
+}
+
+// GitHubTextMatch is a fragment of a file that matched the search term.
+type GitHubTextMatch struct {
+	Fragment string `json:"fragment"`
+	Matches  []struct {
+		Text    string `json:"text"`
+		Indices []int  `json:"indices"`
+	} `json:"matches"`
 }
 
 type GitHubRepository struct {
@@ -39,19 +67,149 @@ type GitHubRepository struct {
 	Private     bool   `json:"private"`
 	HTMLURL     string `json:"html_url"`
 	Description string `json:"description"`
+	Fork        bool   `json:"fork"`
+
+	// Only set by GetRepository; code search returns a minimal repository
+	StargazersCount int               `json:"stargazers_count"`
+	Archived        bool              `json:"archived"`
+	PushedAt        time.Time         `json:"pushed_at"`
+	Parent          *GitHubRepository `json:"parent"` // The repository a fork was forked from
+	Source          *GitHubRepository `json:"source"` // The root of a fork's network
+}
+
+// ClientConfig holds the settings of a GitHubClient.
+type ClientConfig struct {
+	BaseURL string
+	Timeout time.Duration
+	Retry   RetryConfig
+	Breaker BreakerConfig
 }
 
 // NewGitHubClient creates a new GitHubClient.
-// It now takes the base URL as argument.
-func NewGitHubClient(baseURL string) *GitHubClient {
+// It now takes the full client configuration as argument.
+func NewGitHubClient(config ClientConfig) *GitHubClient {
 	return &GitHubClient{
-		client:  &http.Client{Timeout: time.Second * 5},
-		baseURL: baseURL,
+		client:        &http.Client{Timeout: config.Timeout},
+		baseURL:       config.BaseURL,
+		retryConfig:   config.Retry,
+		breakerConfig: config.Breaker,
+		breakers:      make(map[string]*circuitBreaker),
+	}
+}
+
+// Reconfigured returns a client with the settings of config that keeps the
+// circuit breakers of c, so that a configuration reload does not close a
+// breaker that is open. The breakers adopt config.Breaker.
+func (c *GitHubClient) Reconfigured(config ClientConfig) *GitHubClient {
+	next := NewGitHubClient(config)
+	c.breakersMu.Lock()
+	defer c.breakersMu.Unlock()
+	for host, b := range c.breakers {
+		b.setConfig(config.Breaker)
+		next.breakers[host] = b
+	}
+	return next
+}
+
+// breakerFor returns the circuit breaker for the host of the given URL.
+func (c *GitHubClient) breakerFor(host string) *circuitBreaker {
+	c.breakersMu.Lock()
+	defer c.breakersMu.Unlock()
+
+	b, ok := c.breakers[host]
+	if !ok {
+		b = newCircuitBreaker(host, c.breakerConfig)
+		c.breakers[host] = b
+	}
+	return b
+}
+
+// BreakerStates returns the circuit breaker state of every GitHub host contacted so far.
+func (c *GitHubClient) BreakerStates() map[string]BreakerState {
+	c.breakersMu.Lock()
+	defer c.breakersMu.Unlock()
+
+	states := make(map[string]BreakerState, len(c.breakers))
+	for host, b := range c.breakers {
+		states[host] = b.State()
+	}
+	return states
+}
+
+// do sends the request through the circuit breaker of its host. Transport
+// errors and 5xx responses count as failures; a request abandoned by the
+// caller is not held against the host. route is the endpoint's path template,
+// e.g. "/repos/{owner}/{repo}", which labels the metrics and names the span
+// so that their cardinality does not grow with the paths requested.
+func (c *GitHubClient) do(req *http.Request, route string) (*http.Response, error) {
+	breaker := c.breakerFor(req.URL.Host)
+	if err := breaker.allow(); err != nil {
+		return nil, fmt.Errorf("GitHub host %s is unavailable: %w", req.URL.Host, err)
+	}
+
+	ctx, span := startRequestSpan(req, route)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+	observe := metrics.ObserveGitHubRequest(route)
+	resp, err := c.client.Do(req)
+	if err != nil {
+		observe("error")
+	} else {
+		observe(strconv.Itoa(resp.StatusCode))
+		recordRateLimit(req, resp)
+	}
+	endRequestSpan(span, resp, err)
+
+	switch {
+	case err != nil && req.Context().Err() != nil:
+		breaker.abandon()
+	case err != nil:
+		breaker.record(false)
+	default:
+		breaker.record(resp.StatusCode < http.StatusInternalServerError)
 	}
+	return resp, err
+}
+
+// recordRateLimit exports the rate-limit budget GitHub reports for the token
+// that sent the request, identified only by its hash.
+func recordRateLimit(req *http.Request, resp *http.Response) {
+	remaining, err := strconv.ParseFloat(resp.Header.Get("X-RateLimit-Remaining"), 64)
+	if err != nil {
+		return
+	}
+	identity := "anonymous"
+	if token := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer "); token != "" {
+		identity = util.HashToken(token)
+	}
+	resource := resp.Header.Get("X-RateLimit-Resource")
+	if resource == "" {
+		resource = "unknown"
+	}
+	metrics.SetGitHubRateLimitRemaining(identity, resource, remaining)
 }
 
 // SearchFiles searches for files on GitHub based on the provided search term and user.
 func (c *GitHubClient) SearchFiles(ctx context.Context, searchTerm string, user string, authToken string, githubParams map[string]string) ([]GitHubSearchItem, error) {
+	result, err := c.SearchCode(ctx, searchTerm, user, authToken, githubParams)
+	if err != nil {
+		return nil, err
+	}
+	return result.Items, nil
+}
+
+// SearchCode is like SearchFiles but returns the whole response, including
+// the total number of matches.
+func (c *GitHubClient) SearchCode(ctx context.Context, searchTerm string, user string, authToken string, githubParams map[string]string) (*GithubSearchCodeResponse, error) {
+	return c.searchCode(ctx, searchTerm, user, authToken, githubParams, "application/vnd.github+json")
+}
+
+// SearchCodeWithTextMatches is like SearchCode but also returns the fragments
+// of each file that matched the search term.
+func (c *GitHubClient) SearchCodeWithTextMatches(ctx context.Context, searchTerm string, user string, authToken string, githubParams map[string]string) (*GithubSearchCodeResponse, error) {
+	return c.searchCode(ctx, searchTerm, user, authToken, githubParams, "application/vnd.github.text-match+json")
+}
+
+func (c *GitHubClient) searchCode(ctx context.Context, searchTerm string, user string, authToken string, githubParams map[string]string, accept string) (*GithubSearchCodeResponse, error) {
 	// Construct the API URL
 	const relativeURL = "/search/code" // Relative URL for the search endpoint
 	apiURL := c.baseURL + relativeURL
@@ -75,14 +233,14 @@ func (c *GitHubClient) SearchFiles(ctx context.Context, searchTerm string, user
 	}
 
 	// Set the Accept header to specify the desired API version
-	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Accept", accept)
 
 	// Add the Authorization header with the Personal Access Token
 	req.Header.Set("Authorization", "Bearer "+authToken)
 	req.Header.Set("X-GitHub-Api-Version", "2022-11-28") // Add the API version header
 
 	// Make the API request
-	resp, err := c.client.Do(req)
+	resp, err := c.doWithRetry(req, "/search/code")
 	if err != nil {
 		return nil, fmt.Errorf("failed to make request: %w", err)
 	}
@@ -110,7 +268,134 @@ func (c *GitHubClient) SearchFiles(ctx context.Context, searchTerm string, user
 	if err := json.Unmarshal(bodyBytes, &result); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
-	return result.Items, nil
+	return &result, nil
+}
+
+// CheckRateLimit calls the lightweight /rate_limit endpoint to verify that the
+// GitHub host is reachable. The call does not count against the rate limit, and
+// the token is optional.
+func (c *GitHubClient) CheckRateLimit(ctx context.Context, authToken string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/rate_limit", nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if authToken != "" {
+		req.Header.Set("Authorization", "Bearer "+authToken)
+	}
+
+	resp, err := c.do(req, "/rate_limit")
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GitHub API returned an error: %s (status code: %d)", resp.Status, resp.StatusCode)
+	}
+	return nil
+}
+
+// GetLogin returns the login of the GitHub user the token belongs to.
+func (c *GitHubClient) GetLogin(ctx context.Context, authToken string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/user", nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	req.Header.Set("Authorization", "Bearer "+authToken)
+
+	resp, err := c.do(req, "/user")
+	if err != nil {
+		return "", fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		io.Copy(io.Discard, resp.Body)
+		return "", fmt.Errorf("GitHub API returned an error: %s (status code: %d)", resp.Status, resp.StatusCode)
+	}
+
+	var user struct {
+		Login string `json:"login"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
+		return "", fmt.Errorf("failed to decode response: %w", err)
+	}
+	return user.Login, nil
+}
+
+// GetOrganizations returns the logins of the organizations the owner of the
+// token belongs to.
+func (c *GitHubClient) GetOrganizations(ctx context.Context, authToken string) ([]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/user/orgs?per_page=100", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	req.Header.Set("Authorization", "Bearer "+authToken)
+
+	resp, err := c.do(req, "/user/orgs")
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		io.Copy(io.Discard, resp.Body)
+		return nil, fmt.Errorf("GitHub API returned an error: %s (status code: %d)", resp.Status, resp.StatusCode)
+	}
+
+	var orgs []struct {
+		Login string `json:"login"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&orgs); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	logins := make([]string, len(orgs))
+	for i, org := range orgs {
+		logins[i] = org.Login
+	}
+	return logins, nil
+}
+
+// GetRepository returns the repository with the given full name, e.g.
+// octocat/hello-world, including its star count and, for forks, its parent
+// and source repositories.
+func (c *GitHubClient) GetRepository(ctx context.Context, fullName string, authToken string) (*GitHubRepository, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/repos/"+fullName, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	req.Header.Set("Authorization", "Bearer "+authToken)
+
+	resp, err := c.doWithRetry(req, "/repos/{owner}/{repo}")
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		io.Copy(io.Discard, resp.Body)
+		return nil, fmt.Errorf("GitHub API returned an error: %s (status code: %d)", resp.Status, resp.StatusCode)
+	}
+
+	var repo GitHubRepository
+	if err := json.NewDecoder(resp.Body).Decode(&repo); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &repo, nil
+}
+
+// BaseURL returns the base URL of the GitHub API the client talks to.
+func (c *GitHubClient) BaseURL() string {
+	return c.baseURL
 }
 
 // ExtractFileURL extracts the file URL from the search result.
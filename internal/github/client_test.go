@@ -0,0 +1,124 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/Pratham700/github-search-service/internal/metrics"
+	"github.com/Pratham700/github-search-service/internal/util"
+)
+
+// newTestClient returns a client of a fake GitHub that answers every request
+// with handler.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *GitHubClient {
+	t.Helper()
+	ts := httptest.NewServer(handler)
+	t.Cleanup(ts.Close)
+	return NewGitHubClient(ClientConfig{BaseURL: ts.URL, Timeout: time.Second, Retry: RetryConfig{MaxAttempts: 1}})
+}
+
+// metricValue returns the value of the counter or gauge name with exactly
+// the given labels, and whether it exists.
+func metricValue(t *testing.T, name string, labels map[string]string) (float64, bool) {
+	t.Helper()
+	families, err := metrics.Registry.Gather()
+	if err != nil {
+		t.Fatalf("Gather() error = %v", err)
+	}
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			if hasLabels(metric, labels) {
+				if metric.GetCounter() != nil {
+					return metric.GetCounter().GetValue(), true
+				}
+				return metric.GetGauge().GetValue(), true
+			}
+		}
+	}
+	return 0, false
+}
+
+func hasLabels(metric *dto.Metric, labels map[string]string) bool {
+	if len(metric.GetLabel()) != len(labels) {
+		return false
+	}
+	for _, label := range metric.GetLabel() {
+		if labels[label.GetName()] != label.GetValue() {
+			return false
+		}
+	}
+	return true
+}
+
+func TestRequestMetrics(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "42")
+		w.Header().Set("X-RateLimit-Resource", "core")
+		w.Write([]byte(`{"full_name":"octocat/metrics-test"}`))
+	})
+	before, _ := metricValue(t, "github_search_github_requests_total", map[string]string{"endpoint": "/repos/{owner}/{repo}", "status": "200"})
+	if _, err := client.GetRepository(context.Background(), "octocat/metrics-test", "ghp_metrics_test"); err != nil {
+		t.Fatalf("GetRepository() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		metric string
+		labels map[string]string
+		want   float64
+	}{
+		{
+			"labelled by path template", "github_search_github_requests_total",
+			map[string]string{"endpoint": "/repos/{owner}/{repo}", "status": "200"}, before + 1,
+		},
+		{
+			"rate limit by token hash", "github_search_github_rate_limit_remaining",
+			map[string]string{"identity": util.HashToken("ghp_metrics_test"), "resource": "core"}, 42,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, ok := metricValue(t, tt.metric, tt.labels); !ok || got != tt.want {
+				t.Errorf("%s%v = %v (found %v), want %v", tt.metric, tt.labels, got, ok, tt.want)
+			}
+		})
+	}
+
+	// Neither the repository nor the token may appear in a label
+	for _, labels := range []map[string]string{
+		{"endpoint": "/repos/octocat/metrics-test", "status": "200"},
+		{"identity": "ghp_metrics_test", "resource": "core"},
+	} {
+		for _, name := range []string{"github_search_github_requests_total", "github_search_github_rate_limit_remaining"} {
+			if _, ok := metricValue(t, name, labels); ok {
+				t.Errorf("%s has a series labelled %v", name, labels)
+			}
+		}
+	}
+}
+
+func TestRequestMetricsAnonymous(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "7")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	before, _ := metricValue(t, "github_search_github_requests_total", map[string]string{"endpoint": "/rate_limit", "status": "503"})
+	if err := client.CheckRateLimit(context.Background(), ""); err == nil {
+		t.Fatalf("CheckRateLimit() error = nil, want an error")
+	}
+	if got, _ := metricValue(t, "github_search_github_requests_total", map[string]string{"endpoint": "/rate_limit", "status": "503"}); got != before+1 {
+		t.Errorf("requests{status=503} = %v, want %v", got, before+1)
+	}
+	labels := map[string]string{"identity": "anonymous", "resource": "unknown"}
+	if got, ok := metricValue(t, "github_search_github_rate_limit_remaining", labels); !ok || got != 7 {
+		t.Errorf("rate limit remaining%v = %v (found %v), want 7", labels, got, ok)
+	}
+}
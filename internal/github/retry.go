@@ -0,0 +1,59 @@
+package github
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/Pratham700/github-search-service/internal/tracing"
+)
+
+// RetryConfig configures how failed GitHub requests are retried.
+type RetryConfig struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry; it doubles on each subsequent retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between retries.
+	MaxBackoff time.Duration
+}
+
+// doWithRetry sends a body-less request, retrying transport errors and 5xx
+// responses with exponential backoff. Requests rejected by an open circuit
+// breaker or abandoned by the caller are not retried.
+func (c *GitHubClient) doWithRetry(req *http.Request, route string) (*http.Response, error) {
+	backoff := c.retryConfig.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		resp, err := c.do(req, route)
+		if attempt >= c.retryConfig.MaxAttempts || !retryable(resp, err) || req.Context().Err() != nil {
+			return resp, err
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		_, span := tracing.StartSpan(req.Context(), "github.retry_backoff",
+			attribute.Int("github.attempt", attempt),
+			attribute.Int64("github.backoff_ms", backoff.Milliseconds()),
+		)
+		timer := time.NewTimer(backoff)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			tracing.EndSpan(span, req.Context().Err())
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+		span.End()
+		backoff = min(backoff*2, c.retryConfig.MaxBackoff)
+	}
+}
+
+func retryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, ErrCircuitOpen)
+	}
+	return resp.StatusCode >= http.StatusInternalServerError
+}
@@ -0,0 +1,54 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Pratham700/github-search-service/internal/tracing"
+)
+
+// startRequestSpan starts a client span for a single GitHub API request,
+// i.e. one page fetch or one retry attempt. The query string is left out
+// because it carries the caller's search term.
+func startRequestSpan(req *http.Request, route string) (context.Context, trace.Span) {
+	page := req.URL.Query().Get("page")
+	if page == "" {
+		page = "1"
+	}
+	return tracing.Tracer().Start(req.Context(), "GitHub "+req.Method+" "+route,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(req.Method),
+			semconv.ServerAddress(req.URL.Host),
+			semconv.URLPath(req.URL.Path),
+			attribute.String("github.page", page),
+		),
+	)
+}
+
+// endRequestSpan records the response status and the rate-limit headers.
+func endRequestSpan(span trace.Span, resp *http.Response, err error) {
+	defer span.End()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+	if remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining")); err == nil {
+		span.SetAttributes(attribute.Int("github.rate_limit.remaining", remaining))
+	}
+	if resource := resp.Header.Get("X-RateLimit-Resource"); resource != "" {
+		span.SetAttributes(attribute.String("github.rate_limit.resource", resource))
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+}
@@ -0,0 +1,121 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestRequestSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+
+	var traceparent string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.Header().Set("X-RateLimit-Remaining", "9")
+		w.Header().Set("X-RateLimit-Resource", "search")
+		if r.URL.Path == "/repos/octocat/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"total_count":0,"items":[]}`))
+	})
+
+	tests := []struct {
+		name       string
+		call       func(context.Context) error
+		wantName   string
+		wantAttrs  map[attribute.Key]attribute.Value
+		wantStatus codes.Code
+	}{
+		{
+			name: "search page",
+			call: func(ctx context.Context) error {
+				_, err := client.SearchCode(ctx, "secret term", "", "t", map[string]string{"page": "3"})
+				return err
+			},
+			wantName: "GitHub GET /search/code",
+			wantAttrs: map[attribute.Key]attribute.Value{
+				"http.request.method":         attribute.StringValue("GET"),
+				"url.path":                    attribute.StringValue("/search/code"),
+				"github.page":                 attribute.StringValue("3"),
+				"http.response.status_code":   attribute.IntValue(http.StatusOK),
+				"github.rate_limit.remaining": attribute.IntValue(9),
+				"github.rate_limit.resource":  attribute.StringValue("search"),
+			},
+			wantStatus: codes.Unset,
+		},
+		{
+			name: "error response",
+			call: func(ctx context.Context) error {
+				_, err := client.GetRepository(ctx, "octocat/missing", "t")
+				return err
+			},
+			wantName: "GitHub GET /repos/{owner}/{repo}",
+			wantAttrs: map[attribute.Key]attribute.Value{
+				"url.path":                  attribute.StringValue("/repos/octocat/missing"),
+				"github.page":               attribute.StringValue("1"),
+				"http.response.status_code": attribute.IntValue(http.StatusNotFound),
+			},
+			wantStatus: codes.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder.Reset()
+			ctx, parent := otel.Tracer("test").Start(context.Background(), "parent")
+			tt.call(ctx)
+			parent.End()
+
+			var span sdktrace.ReadOnlySpan
+			for _, s := range recorder.Ended() {
+				if s.Name() == tt.wantName {
+					span = s
+				}
+			}
+			if span == nil {
+				t.Fatalf("no span named %q", tt.wantName)
+			}
+			if span.SpanKind() != trace.SpanKindClient || span.Parent().SpanID() != parent.SpanContext().SpanID() {
+				t.Errorf("span kind = %v, parent = %v, want a client child of the caller's span", span.SpanKind(), span.Parent())
+			}
+			attrs := make(map[attribute.Key]attribute.Value)
+			for _, attr := range span.Attributes() {
+				attrs[attr.Key] = attr.Value
+			}
+			for key, want := range tt.wantAttrs {
+				if attrs[key] != want {
+					t.Errorf("%s = %v, want %v", key, attrs[key].Emit(), want.Emit())
+				}
+			}
+			// The search term travels in the query string, which is left out
+			for key, value := range attrs {
+				if strings.Contains(value.Emit(), "secret") {
+					t.Errorf("%s = %q carries the search term", key, value.Emit())
+				}
+			}
+			if span.Status().Code != tt.wantStatus {
+				t.Errorf("status = %v, want %v", span.Status().Code, tt.wantStatus)
+			}
+			if want := span.SpanContext().TraceID().String(); !strings.Contains(traceparent, want) {
+				t.Errorf("traceparent sent to GitHub = %q, want trace %s", traceparent, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+// RequestIDHeader is the metadata key, and HTTP header, carrying the request ID.
+const RequestIDHeader = "x-request-id"
+
+const redacted = "[REDACTED]"
+
+// secretPatterns match values that look like credentials wherever they appear
+// in a log message or attribute, including inside search queries.
+var secretPatterns = []struct {
+	re   *regexp.Regexp
+	repl string
+}{
+	// GitHub personal access, OAuth, user-to-server, server-to-server and refresh tokens
+	{regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{20,}|github_pat_[A-Za-z0-9_]{20,})\b`), redacted},
+	// JSON Web Tokens
+	{regexp.MustCompile(`\beyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`), redacted},
+	// AWS access key IDs
+	{regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`), redacted},
+	// PEM private keys
+	{regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?(?:-----END [A-Z ]*PRIVATE KEY-----|$)`), redacted},
+	// Authorization header values
+	{regexp.MustCompile(`(?i)\b(bearer|token|basic)\s+[A-Za-z0-9._~+/=-]{8,}`), "${1} " + redacted},
+	// key=value and key: value assignments of secret-sounding keys
+	{regexp.MustCompile(`(?i)\b((?:access|api|client|private|secret)?[_-]?(?:token|secret|password|passwd|pwd|api[_-]?key|access[_-]?key))(\s*[:=]\s*)("?)[^\s"'&,;]+`), "${1}${2}${3}" + redacted},
+}
+
+// sensitiveKeys are attribute keys whose values are never logged.
+var sensitiveKeys = []string{"token", "secret", "password", "authorization", "api_key", "apikey", "cookie"}
+
+// IdentityKey is the attribute key of caller identities. Identities are
+// certificate names or token hashes ("token:<hash>"), so they are logged as is.
+const IdentityKey = "identity"
+
+// Redact masks everything in s that looks like a credential.
+func Redact(s string) string {
+	for _, p := range secretPatterns {
+		s = p.re.ReplaceAllString(s, p.repl)
+	}
+	return s
+}
+
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, sensitive := range sensitiveKeys {
+		if strings.Contains(key, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+// NewHandler returns a text or JSON handler writing to w. Values of sensitive
+// keys are dropped and secrets are masked in every message and string
+// attribute, and records logged with a context carrying a request ID are
+// tagged with it.
+func NewHandler(w io.Writer, format string, level slog.Leveler) slog.Handler {
+	opts := &slog.HandlerOptions{Level: level, ReplaceAttr: replaceAttr}
+	var handler slog.Handler = slog.NewTextHandler(w, opts)
+	if format == "json" {
+		handler = slog.NewJSONHandler(w, opts)
+	}
+	return contextHandler{handler}
+}
+
+func replaceAttr(_ []string, a slog.Attr) slog.Attr {
+	if a.Value.Kind() == slog.KindGroup || a.Key == IdentityKey {
+		return a
+	}
+	if isSensitiveKey(a.Key) {
+		return slog.String(a.Key, redacted)
+	}
+	switch a.Value.Kind() {
+	case slog.KindString:
+		return slog.String(a.Key, Redact(a.Value.String()))
+	case slog.KindAny:
+		if err, ok := a.Value.Any().(error); ok {
+			return slog.String(a.Key, Redact(err.Error()))
+		}
+	}
+	return a
+}
+
+// contextHandler adds the request ID stored in the context to each record.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id, ok := RequestIDFromContext(ctx); ok {
+		r.AddAttrs(slog.String("request_id", id))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
+
+type requestIDKey struct{}
+
+// WithRequestID returns a context carrying the request ID.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID stored in ctx.
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey{}).(string)
+	return id, ok
+}
+
+// NewRequestID generates a random request ID.
+func NewRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// ValidRequestID reports whether a client-supplied request ID is safe to log
+// and echo back: at most 128 letters, digits, dashes, underscores and dots.
+func ValidRequestID(id string) bool {
+	if id == "" || len(id) > 128 {
+		return false
+	}
+	for _, c := range id {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_' || c == '.') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,160 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const namespace = "github_search"
+
+// Registry holds every metric exported by the service.
+var Registry = prometheus.NewRegistry()
+
+var (
+	rpcRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rpc_requests_total",
+		Help:      "RPCs handled, by method and gRPC status code.",
+	}, []string{"method", "code"})
+
+	rpcDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "rpc_duration_seconds",
+		Help:      "RPC latency, by method and gRPC status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "code"})
+
+	rpcInFlight = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "rpc_in_flight",
+		Help:      "RPCs currently being handled, by method.",
+	}, []string{"method"})
+
+	githubRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "github_requests_total",
+		Help:      "Requests sent to the GitHub API, by endpoint and HTTP status (\"error\" for transport failures).",
+	}, []string{"endpoint", "status"})
+
+	githubDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "github_request_duration_seconds",
+		Help:      "GitHub API request latency, by endpoint and HTTP status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"endpoint", "status"})
+
+	githubInFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "github_requests_in_flight",
+		Help:      "Requests to the GitHub API currently awaiting a response.",
+	})
+
+	githubRateLimitRemaining = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "github_rate_limit_remaining",
+		Help:      "Last reported GitHub rate-limit budget, by identity hash and resource.",
+	}, []string{"identity", "resource"})
+
+	githubBreakerState = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "github_circuit_breaker_state",
+		Help:      "Circuit breaker state per GitHub host: 0 closed, 1 open, 2 half-open.",
+	}, []string{"host"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		rpcRequests, rpcDuration, rpcInFlight,
+		githubRequests, githubDuration, githubInFlight,
+		githubRateLimitRemaining, githubBreakerState,
+	)
+}
+
+// Handler serves the metrics in the Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
+
+// UnaryServerInterceptor records count, latency and in-flight gauge of unary RPCs.
+// It should be the outermost interceptor so rejected calls are counted too.
+func UnaryServerInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	done := observeRPC(info.FullMethod)
+	resp, err := handler(ctx, req)
+	done(err)
+	return resp, err
+}
+
+// StreamServerInterceptor records count, latency and in-flight gauge of streaming RPCs.
+func StreamServerInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	done := observeRPC(info.FullMethod)
+	err := handler(srv, ss)
+	done(err)
+	return err
+}
+
+func observeRPC(method string) func(error) {
+	start := time.Now()
+	inFlight := rpcInFlight.WithLabelValues(method)
+	inFlight.Inc()
+	return func(err error) {
+		inFlight.Dec()
+		code := statusCode(err).String()
+		rpcRequests.WithLabelValues(method, code).Inc()
+		rpcDuration.WithLabelValues(method, code).Observe(time.Since(start).Seconds())
+	}
+}
+
+// statusCode returns the code gRPC reports to the client for an error
+// returned by a handler: context errors that are not statuses become
+// Canceled or DeadlineExceeded rather than Unknown.
+func statusCode(err error) codes.Code {
+	if st, ok := status.FromError(err); ok {
+		return st.Code()
+	}
+	return status.FromContextError(err).Code()
+}
+
+// ObserveGitHubRequest marks the start of a GitHub API request to endpoint, a
+// path template such as "/repos/{owner}/{repo}". The returned function
+// records its outcome; status is the HTTP status code, or "error" when no
+// response was received.
+func ObserveGitHubRequest(endpoint string) func(status string) {
+	start := time.Now()
+	githubInFlight.Inc()
+	return func(status string) {
+		githubInFlight.Dec()
+		githubRequests.WithLabelValues(endpoint, status).Inc()
+		githubDuration.WithLabelValues(endpoint, status).Observe(time.Since(start).Seconds())
+	}
+}
+
+// SetGitHubRateLimitRemaining records the rate-limit budget GitHub reported
+// for the identity hash and resource (e.g. "search" or "core").
+func SetGitHubRateLimitRemaining(identity, resource string, remaining float64) {
+	githubRateLimitRemaining.WithLabelValues(identity, resource).Set(remaining)
+}
+
+// SetGitHubBreakerState records the circuit breaker state of a GitHub host.
+func SetGitHubBreakerState(host string, state int) {
+	githubBreakerState.WithLabelValues(host).Set(float64(state))
+}
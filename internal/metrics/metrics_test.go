@@ -0,0 +1,84 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		err    error
+		code   string
+	}{
+		{"ok", "/test.Service/Ok", nil, "OK"},
+		{"status error", "/test.Service/Missing", status.Error(codes.NotFound, "missing"), "NotFound"},
+		{"context error", "/test.Service/Canceled", context.Canceled, "Canceled"},
+		{"deadline", "/test.Service/Slow", context.DeadlineExceeded, "DeadlineExceeded"},
+		{"plain error", "/test.Service/Broken", errors.New("broken"), "Unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var inFlight float64
+			info := &grpc.UnaryServerInfo{FullMethod: tt.method}
+			_, err := UnaryServerInterceptor(context.Background(), nil, info, func(context.Context, interface{}) (interface{}, error) {
+				inFlight = testutil.ToFloat64(rpcInFlight.WithLabelValues(tt.method))
+				return nil, tt.err
+			})
+			if err != tt.err {
+				t.Errorf("error = %v, want %v", err, tt.err)
+			}
+			if inFlight != 1 {
+				t.Errorf("in flight during the call = %v, want 1", inFlight)
+			}
+			if got := testutil.ToFloat64(rpcInFlight.WithLabelValues(tt.method)); got != 0 {
+				t.Errorf("in flight after the call = %v, want 0", got)
+			}
+			if got := testutil.ToFloat64(rpcRequests.WithLabelValues(tt.method, tt.code)); got != 1 {
+				t.Errorf("requests{code=%s} = %v, want 1", tt.code, got)
+			}
+			if got := testutil.CollectAndCount(rpcDuration, "github_search_rpc_duration_seconds"); got == 0 {
+				t.Errorf("no duration was observed")
+			}
+		})
+	}
+}
+
+func TestObserveGitHubRequest(t *testing.T) {
+	const endpoint = "/test/{id}"
+	before := testutil.ToFloat64(githubInFlight)
+	done := ObserveGitHubRequest(endpoint)
+	if got := testutil.ToFloat64(githubInFlight); got != before+1 {
+		t.Errorf("in flight during the request = %v, want %v", got, before+1)
+	}
+	done("503")
+	ObserveGitHubRequest(endpoint)("error")
+	ObserveGitHubRequest(endpoint)("503")
+
+	if got := testutil.ToFloat64(githubInFlight); got != before {
+		t.Errorf("in flight after the requests = %v, want %v", got, before)
+	}
+	for status, want := range map[string]float64{"503": 2, "error": 1, "200": 0} {
+		if got := testutil.ToFloat64(githubRequests.WithLabelValues(endpoint, status)); got != want {
+			t.Errorf("requests{status=%s} = %v, want %v", status, got, want)
+		}
+	}
+}
+
+func TestRegistryLint(t *testing.T) {
+	problems, err := testutil.GatherAndLint(Registry, "github_search_rpc_requests_total", "github_search_github_requests_total",
+		"github_search_github_rate_limit_remaining", "github_search_github_circuit_breaker_state")
+	if err != nil {
+		t.Fatalf("GatherAndLint() error = %v", err)
+	}
+	for _, problem := range problems {
+		t.Errorf("%s: %s", problem.Metric, problem.Text)
+	}
+}
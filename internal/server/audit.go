@@ -0,0 +1,358 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/Pratham700/github-search-service/internal/logging"
+	"github.com/Pratham700/github-search-service/internal/util"
+	pb "github.com/Pratham700/github-search-service/proto/proto"
+)
+
+const (
+	// loginCacheTTL is the default of CacheConfig.LoginTTL.
+	loginCacheTTL    = time.Hour
+	loginLookupLimit = 2 * time.Second
+	// maxLoginLookups is the number of logins resolved at the same time.
+	maxLoginLookups = 16
+
+	// WatchRunAuditMethod is the method of the audit events of watch runs,
+	// which the scheduler makes on behalf of the watch's owner.
+	WatchRunAuditMethod = "watch-run"
+)
+
+// AuditEvent is one entry of the audit log: who called which method with
+// which query, against which GitHub host, and with what outcome. Batch
+// searches list their queries in Queries instead of Query and User, and
+// ResultCount counts the results, or hits, of all of them.
+type AuditEvent struct {
+	Time        time.Time    `json:"time"`
+	RequestID   string       `json:"request_id,omitempty"`
+	Identity    string       `json:"identity"`
+	Login       string       `json:"login,omitempty"` // Empty until the token's login is resolved, see Auditor
+	Method      string       `json:"method"`
+	WatchID     string       `json:"watch_id,omitempty"`
+	Query       string       `json:"query,omitempty"`
+	User        string       `json:"user,omitempty"`
+	Queries     []AuditQuery `json:"queries,omitempty"`
+	Host        string       `json:"host"`
+	ResultCount int          `json:"result_count"`
+	Code        string       `json:"code"`
+	Error       string       `json:"error,omitempty"`
+}
+
+// AuditQuery is one of the searches of a batch.
+type AuditQuery struct {
+	Query string `json:"query"`
+	User  string `json:"user,omitempty"`
+}
+
+// AuditSink stores audit events. Implementations must be safe for concurrent use.
+type AuditSink interface {
+	Write(event AuditEvent) error
+	Close() error
+}
+
+// AuditSinkFunc adapts a function to an AuditSink, so other destinations can
+// be hooked in without a dedicated type.
+type AuditSinkFunc func(event AuditEvent) error
+
+// Write calls f(event).
+func (f AuditSinkFunc) Write(event AuditEvent) error {
+	return f(event)
+}
+
+// Close does nothing.
+func (f AuditSinkFunc) Close() error {
+	return nil
+}
+
+// MultiAuditSink writes every event to each of its sinks.
+type MultiAuditSink []AuditSink
+
+// Write writes event to every sink, even if some of them fail.
+func (m MultiAuditSink) Write(event AuditEvent) error {
+	var errs []error
+	for _, sink := range m {
+		if err := sink.Write(event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Close closes every sink.
+func (m MultiAuditSink) Close() error {
+	var errs []error
+	for _, sink := range m {
+		if err := sink.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// nestedSearch is implemented by requests that wrap a search.
+type nestedSearch interface {
+	GetRequest() *pb.SearchRequest
+}
+
+// Auditor records every call to the search service in an AuditSink. The
+// GitHub login behind a token is resolved in the background and cached for
+// CacheConfig.LoginTTL, so the events of a token's first calls, and of its
+// first calls after the cache expired, have no login; their identity links
+// them to the later events.
+type Auditor struct {
+	sink         AuditSink
+	githubServer *GithubSearchServer
+	logins       *ttlCache[string]
+
+	mu sync.Mutex
+	// resolving holds the hashes of the tokens whose login is being resolved.
+	resolving map[string]bool
+}
+
+// NewAuditor creates an Auditor writing to sink.
+func NewAuditor(sink AuditSink, githubServer *GithubSearchServer) *Auditor {
+	return &Auditor{
+		sink:         sink,
+		githubServer: githubServer,
+		logins:       newTTLCache[string](githubServer.cache.LoginTTL, githubServer.cache.MaxEntries),
+		resolving:    make(map[string]bool),
+	}
+}
+
+// UnaryInterceptor writes an audit event for every call to a non-public
+// method. It runs ahead of AuthInterceptor so that calls rejected by
+// authentication or quotas are recorded too.
+func (a *Auditor) UnaryInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	if isPublicMethod(info.FullMethod) {
+		return handler(ctx, req)
+	}
+
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	a.record(ctx, info.FullMethod, start, req, resp, err)
+	return resp, err
+}
+
+// StreamInterceptor is the streaming counterpart of UnaryInterceptor; the
+// call is recorded when the stream ends.
+func (a *Auditor) StreamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	if isPublicMethod(info.FullMethod) {
+		return handler(srv, ss)
+	}
+
+	start := time.Now()
+	stream := &auditedStream{ServerStream: ss}
+	err := handler(srv, stream)
+	a.record(ss.Context(), info.FullMethod, start, stream.req, stream, err)
+	return err
+}
+
+// auditedStream keeps the first message a stream receives, which is the
+// request of server-streaming methods, and counts the results it sends.
+type auditedStream struct {
+	grpc.ServerStream
+	req     interface{}
+	results int
+}
+
+func (s *auditedStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil && s.req == nil {
+		s.req = m
+	}
+	return err
+}
+
+func (s *auditedStream) SendMsg(m interface{}) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		s.results += auditResultCount(m)
+	}
+	return err
+}
+
+// record writes the audit event of a call. For streams, resp is the
+// auditedStream.
+func (a *Auditor) record(ctx context.Context, fullMethod string, start time.Time, req, resp interface{}, err error) {
+	event := AuditEvent{
+		Time:     start.UTC(),
+		Identity: identityFromIncomingContext(ctx),
+		Method:   fullMethod,
+		Host:     a.gitHubHost(),
+		Code:     rpcCode(err).String(),
+	}
+	event.RequestID, _ = logging.RequestIDFromContext(ctx)
+	a.auditRequest(&event, req, err)
+	if err == nil {
+		event.ResultCount = auditResultCount(resp)
+	}
+	if err != nil {
+		event.Error = logging.Redact(status.Convert(err).Message())
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if tokens := md.Get("github-token"); len(tokens) > 0 {
+			event.Login = a.login(ctx, tokens[0])
+		}
+	}
+
+	if writeErr := a.sink.Write(event); writeErr != nil {
+		slog.ErrorContext(ctx, "Failed to write audit event", "error", writeErr)
+	}
+}
+
+// auditRequest sets the queries of event from the search, or searches, that
+// req runs. A saved search is looked up once it ran, i.e. the caller may see it.
+func (a *Auditor) auditRequest(event *AuditEvent, req interface{}, err error) {
+	var search *pb.SearchRequest
+	switch req := req.(type) {
+	case *pb.SearchRequest:
+		search = req
+	case nestedSearch:
+		search = req.GetRequest()
+	case *pb.CreateWatchRequest:
+		search = req.GetWatch().GetRequest()
+	case *pb.CreateSavedSearchRequest:
+		search = req.GetSavedSearch().GetRequest()
+	case *pb.RunSavedSearchRequest:
+		if err == nil {
+			if saved, err := a.githubServer.visibleSavedSearch(event.Identity, req.GetId()); err == nil {
+				search = saved.GetRequest()
+			}
+		}
+	case *pb.BatchSearchRequest:
+		for _, search := range req.GetRequests() {
+			event.Queries = append(event.Queries, AuditQuery{Query: normalizeQuery(search.GetSearchTerm()), User: search.GetUser()})
+		}
+	}
+	if search != nil {
+		event.Query = normalizeQuery(search.GetSearchTerm())
+		event.User = search.GetUser()
+	}
+}
+
+// auditResultCount returns the number of results, or hits, in resp.
+func auditResultCount(resp interface{}) int {
+	switch resp := resp.(type) {
+	case resultCounter:
+		return len(resp.GetResults())
+	case *pb.BatchSearchResponse:
+		count := 0
+		for _, result := range resp.GetResponses() {
+			count += len(result.GetResponse().GetResults())
+		}
+		return count
+	case *pb.BatchSearchResult:
+		return len(resp.GetResponse().GetResults())
+	case *pb.Snapshot:
+		return int(resp.GetHitCount())
+	case *pb.DiffSearchResponse:
+		return int(resp.GetAddedCount() + resp.GetRemovedCount() + resp.GetChangedCount())
+	case *pb.SearchFacetsResponse:
+		return int(resp.GetAnalyzedCount())
+	case *pb.ExhaustiveSearchResponse:
+		return int(resp.GetHitCount())
+	case *auditedStream:
+		return resp.results
+	default:
+		return 0
+	}
+}
+
+// recordWatchRun writes the audit event of a run of watch, which found hits
+// unless it failed with runErr.
+func (a *Auditor) recordWatchRun(watch *pb.Watch, start time.Time, hits int, runErr error) {
+	event := AuditEvent{
+		Time:        start.UTC(),
+		Identity:    watch.GetOwner(),
+		Method:      WatchRunAuditMethod,
+		WatchID:     watch.GetId(),
+		Query:       normalizeQuery(watch.GetRequest().GetSearchTerm()),
+		User:        watch.GetRequest().GetUser(),
+		Host:        a.gitHubHost(),
+		ResultCount: hits,
+		Code:        "OK",
+	}
+	if runErr != nil {
+		event.Code = rpcCode(runErr).String()
+		event.Error = logging.Redact(runErr.Error())
+		event.ResultCount = 0
+	}
+	if err := a.sink.Write(event); err != nil {
+		slog.Error("Failed to write audit event", "error", err)
+	}
+}
+
+// Close closes the sink.
+func (a *Auditor) Close() error {
+	return a.sink.Close()
+}
+
+// normalizeQuery collapses whitespace and masks secrets in a search term.
+func normalizeQuery(query string) string {
+	return logging.Redact(strings.Join(strings.Fields(query), " "))
+}
+
+func (a *Auditor) gitHubHost() string {
+	baseURL := a.githubServer.GitHubClient().BaseURL()
+	if u, err := url.Parse(baseURL); err == nil {
+		return u.Host
+	}
+	return baseURL
+}
+
+// login returns the cached GitHub login of token. An uncached token is
+// resolved in the background, so that audited calls never wait for GitHub,
+// and its login is recorded from the next call on; the identity, a hash of
+// the token, links the events before it. Failures are cached as an empty
+// login so that invalid tokens do not trigger a lookup on every call.
+func (a *Auditor) login(ctx context.Context, token string) string {
+	key := util.HashToken(token)
+	if login, ok := a.logins.get(key); ok {
+		return login
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if !a.resolving[key] && len(a.resolving) < maxLoginLookups {
+		a.resolving[key] = true
+		go a.resolveLogin(context.WithoutCancel(ctx), key, token)
+	}
+	return ""
+}
+
+// resolveLogin looks up the GitHub login of token and caches it under key.
+func (a *Auditor) resolveLogin(ctx context.Context, key, token string) {
+	ctx, cancel := context.WithTimeout(ctx, loginLookupLimit)
+	defer cancel()
+	login, err := a.githubServer.GitHubClient().GetLogin(ctx, token)
+	if err != nil {
+		slog.DebugContext(ctx, "Failed to resolve GitHub login for audit log", "error", err)
+	}
+	a.logins.put(key, login)
+
+	a.mu.Lock()
+	delete(a.resolving, key)
+	a.mu.Unlock()
+}
@@ -0,0 +1,225 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Fsync policies of FileAuditSink.
+const (
+	FsyncAlways   = "always"   // after every event
+	FsyncInterval = "interval" // periodically, in the background
+	FsyncNever    = "never"    // left to the operating system
+)
+
+// backupTimeFormat is the suffix of rotated audit files, the UTC time of the
+// rotation; its lexical order is chronological.
+const backupTimeFormat = "20060102T150405.000000000Z"
+
+// FileAuditOptions configures a FileAuditSink.
+type FileAuditOptions struct {
+	Path string
+	// MaxSize is the size in bytes after which the file is rotated; 0 disables rotation.
+	MaxSize int64
+	// MaxBackups is the number of rotated files kept; 0 keeps all of them.
+	MaxBackups    int
+	Fsync         string
+	FsyncInterval time.Duration
+}
+
+// FileAuditSink appends audit events to a file as JSON lines. When the file
+// grows past MaxSize it is renamed with a timestamp suffix and a new one is
+// started; rotated files are never modified.
+type FileAuditSink struct {
+	options FileAuditOptions
+
+	mu    sync.Mutex
+	file  *os.File
+	size  int64
+	dirty bool
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewFileAuditSink opens, or creates, the audit file for appending.
+func NewFileAuditSink(options FileAuditOptions) (*FileAuditSink, error) {
+	switch options.Fsync {
+	case FsyncAlways, FsyncNever:
+	case FsyncInterval:
+		if options.FsyncInterval <= 0 {
+			return nil, fmt.Errorf("fsync interval must be positive")
+		}
+	default:
+		return nil, fmt.Errorf("unknown fsync policy %q", options.Fsync)
+	}
+
+	s := &FileAuditSink{options: options}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	if options.Fsync == FsyncInterval {
+		s.stop = make(chan struct{})
+		s.done = make(chan struct{})
+		go s.syncLoop()
+	}
+	return s, nil
+}
+
+// Write appends event to the file, rotating it first if it is full.
+func (s *FileAuditSink) Write(event AuditEvent) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit event: %w", err)
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return fmt.Errorf("audit log %s is closed", s.options.Path)
+	}
+	if s.options.MaxSize > 0 && s.size > 0 && s.size+int64(len(line)) > s.options.MaxSize {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := s.file.Write(line)
+	s.size += int64(n)
+	if err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	if s.options.Fsync == FsyncAlways {
+		return s.file.Sync()
+	}
+	s.dirty = true
+	return nil
+}
+
+// Close flushes and closes the file.
+func (s *FileAuditSink) Close() error {
+	if s.stop != nil {
+		close(s.stop)
+		<-s.done
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Sync()
+	if closeErr := s.file.Close(); err == nil {
+		err = closeErr
+	}
+	s.file = nil
+	return err
+}
+
+func (s *FileAuditSink) open() error {
+	file, err := os.OpenFile(s.options.Path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to stat audit log: %w", err)
+	}
+	s.file, s.size, s.dirty = file, info.Size(), false
+	return nil
+}
+
+// rotate moves the current file aside and opens a new one. s.mu must be held.
+func (s *FileAuditSink) rotate() error {
+	if err := s.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync audit log: %w", err)
+	}
+	if err := s.file.Close(); err != nil {
+		return fmt.Errorf("failed to close audit log: %w", err)
+	}
+	s.file = nil
+
+	rotated := s.options.Path + "." + time.Now().UTC().Format(backupTimeFormat)
+	if err := os.Rename(s.options.Path, rotated); err != nil {
+		return fmt.Errorf("failed to rotate audit log: %w", err)
+	}
+	if err := s.open(); err != nil {
+		return err
+	}
+	s.removeOldBackups()
+	return nil
+}
+
+// removeOldBackups deletes the oldest rotated files beyond MaxBackups. Only
+// files named like rotate names them are considered, so other files next to
+// the audit log, such as "audit.jsonl.lock", are left alone.
+func (s *FileAuditSink) removeOldBackups() {
+	if s.options.MaxBackups <= 0 {
+		return
+	}
+	backups, err := s.backups()
+	if err != nil {
+		slog.Error("Failed to list old audit logs", "error", err)
+		return
+	}
+	if len(backups) <= s.options.MaxBackups {
+		return
+	}
+	for _, backup := range backups[:len(backups)-s.options.MaxBackups] {
+		if err := os.Remove(backup); err != nil {
+			slog.Error("Failed to remove old audit log", "path", backup, "error", err)
+		}
+	}
+}
+
+// backups returns the paths of the rotated files, oldest first.
+func (s *FileAuditSink) backups() ([]string, error) {
+	dir, prefix := filepath.Dir(s.options.Path), filepath.Base(s.options.Path)+"."
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var backups []string
+	for _, entry := range entries {
+		suffix, ok := strings.CutPrefix(entry.Name(), prefix)
+		if !ok || !entry.Type().IsRegular() {
+			continue
+		}
+		if _, err := time.Parse(backupTimeFormat, suffix); err == nil {
+			backups = append(backups, filepath.Join(dir, entry.Name()))
+		}
+	}
+	// ReadDir sorts by name, which the timestamp suffix makes chronological
+	return backups, nil
+}
+
+func (s *FileAuditSink) syncLoop() {
+	defer close(s.done)
+	ticker := time.NewTicker(s.options.FsyncInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+		}
+
+		s.mu.Lock()
+		if s.file != nil && s.dirty {
+			if err := s.file.Sync(); err != nil {
+				slog.Error("Failed to sync audit log", "error", err)
+			} else {
+				s.dirty = false
+			}
+		}
+		s.mu.Unlock()
+	}
+}
@@ -0,0 +1,87 @@
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// auditFiles returns the names of the files in dir.
+func auditFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	return names
+}
+
+func TestFileAuditSinkRotation(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "audit.jsonl")
+	// Files next to the audit log that rotation must leave alone
+	siblings := []string{"audit.jsonl.lock", "audit.jsonl.gz", "audit.jsonl.20000101"}
+	for _, name := range siblings {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o600); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+	}
+	oldest := "audit.jsonl." + time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC).Format(backupTimeFormat)
+	if err := os.WriteFile(filepath.Join(dir, oldest), nil, 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	event := AuditEvent{Identity: "token:x", Method: "/m", Code: "OK"}
+	sink, err := NewFileAuditSink(FileAuditOptions{Path: path, MaxSize: 1, MaxBackups: 2, Fsync: FsyncNever})
+	if err != nil {
+		t.Fatalf("NewFileAuditSink() error = %v", err)
+	}
+	// Every event after the first rotates the file
+	for range 4 {
+		if err := sink.Write(event); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	var backups, others []string
+	for _, name := range auditFiles(t, dir) {
+		suffix, _ := strings.CutPrefix(name, "audit.jsonl.")
+		if _, err := time.Parse(backupTimeFormat, suffix); err == nil {
+			backups = append(backups, name)
+		} else {
+			others = append(others, name)
+		}
+	}
+	if len(backups) != 2 || slices.Contains(backups, oldest) {
+		t.Errorf("backups = %v, want the 2 newest", backups)
+	}
+	if want := append([]string{"audit.jsonl"}, siblings...); !sameElements(others, want) {
+		t.Errorf("other files = %v, want %v", others, want)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if lines := strings.Count(string(data), "\n"); lines != 1 {
+		t.Errorf("current file has %d events, want 1", lines)
+	}
+}
+
+// sameElements reports whether a and b hold the same strings in any order.
+func sameElements(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
@@ -5,12 +5,34 @@ import (
 	"fmt"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
+	"strings"
+
+	"github.com/Pratham700/github-search-service/internal/util"
 )
 
 type authTokenKey struct{} // Define a private custom key type
 
+// publicServices lists the services that can be called without a github-token.
+var publicServices = []string{
+	"/grpc.health.v1.Health/",
+	"/grpc.reflection.v1.ServerReflection/",
+	"/grpc.reflection.v1alpha.ServerReflection/",
+}
+
+// isPublicMethod reports whether the full gRPC method name belongs to a public service.
+func isPublicMethod(fullMethod string) bool {
+	for _, prefix := range publicServices {
+		if strings.HasPrefix(fullMethod, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
 // setAuthTokenInContext stores the authentication token in the context.
 func setAuthTokenInContext(ctx context.Context, token string) context.Context {
 	return context.WithValue(ctx, authTokenKey{}, token)
@@ -25,13 +47,86 @@ func GetAuthTokenFromContext(ctx context.Context) (string, error) {
 	return token, nil
 }
 
+// GetClientCertIdentityFromContext returns the identity of a caller that presented
+// a verified client certificate over mutual TLS: the subject common name, or the
+// first URI or DNS SAN when the common name is empty.
+func GetClientCertIdentityFromContext(ctx context.Context) (string, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "", false
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return "", false
+	}
+
+	leaf := tlsInfo.State.VerifiedChains[0][0]
+	switch {
+	case leaf.Subject.CommonName != "":
+		return "cert:" + leaf.Subject.CommonName, true
+	case len(leaf.URIs) > 0:
+		return "cert:" + leaf.URIs[0].String(), true
+	case len(leaf.DNSNames) > 0:
+		return "cert:" + leaf.DNSNames[0], true
+	}
+	return "", false
+}
+
+// GetCallerIdentityFromContext returns a stable identifier for the caller. A verified
+// client certificate takes precedence; otherwise the identity is derived from a hash
+// of the GitHub token so the token itself is never used as a key.
+func GetCallerIdentityFromContext(ctx context.Context) (string, error) {
+	if identity, ok := GetClientCertIdentityFromContext(ctx); ok {
+		return identity, nil
+	}
+
+	token, err := GetAuthTokenFromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+	return "token:" + util.HashToken(token), nil
+}
+
 // AuthInterceptor is a gRPC interceptor that extracts the GitHub token from the metadata.
 func AuthInterceptor(
 	ctx context.Context,
 	req interface{},
-	_ *grpc.UnaryServerInfo,
+	info *grpc.UnaryServerInfo,
 	handler grpc.UnaryHandler,
 ) (interface{}, error) {
+	if isPublicMethod(info.FullMethod) {
+		return handler(ctx, req)
+	}
+
+	newCtx, err := authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Call the handler with the modified context
+	return handler(newCtx, req)
+}
+
+// AuthStreamInterceptor is the streaming counterpart of AuthInterceptor.
+func AuthStreamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	if isPublicMethod(info.FullMethod) {
+		return handler(srv, ss)
+	}
+
+	ctx, err := authenticate(ss.Context())
+	if err != nil {
+		return err
+	}
+	return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+}
+
+// authenticate returns ctx with the GitHub token from the incoming metadata.
+func authenticate(ctx context.Context) (context.Context, error) {
 	// Retrieve the GitHub token from the incoming metadata
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
@@ -43,8 +138,5 @@ func AuthInterceptor(
 		return nil, status.Error(codes.Unauthenticated, "github-token is required in metadata")
 	}
 
-	newCtx := setAuthTokenInContext(ctx, tokenValues[0])
-
-	// Call the handler with the modified context
-	return handler(newCtx, req)
+	return setAuthTokenInContext(ctx, tokenValues[0]), nil
 }
@@ -0,0 +1,141 @@
+package server
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/Pratham700/github-search-service/proto/proto"
+)
+
+// BatchConfig limits batch searches.
+type BatchConfig struct {
+	// MaxQueries is the number of searches a batch may hold; 0 disables batch searches.
+	MaxQueries int
+	// MaxConcurrency is the number of searches of a batch run at the same time.
+	MaxConcurrency int
+}
+
+// BatchSearch implements the BatchSearch gRPC method.
+func (s *GithubSearchServer) BatchSearch(ctx context.Context, req *pb.BatchSearchRequest) (*pb.BatchSearchResponse, error) {
+	resp := &pb.BatchSearchResponse{Responses: make([]*pb.BatchSearchResult, len(req.GetRequests()))}
+	err := s.runBatch(ctx, req, func(result *pb.BatchSearchResult) error {
+		resp.Responses[result.GetIndex()] = result
+		if result.GetError() != nil {
+			resp.FailedCount++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// StreamBatchSearch implements the StreamBatchSearch gRPC method. Results
+// are sent as the searches complete, so not in the order of the requests.
+func (s *GithubSearchServer) StreamBatchSearch(req *pb.BatchSearchRequest, stream grpc.ServerStreamingServer[pb.BatchSearchResult]) error {
+	return s.runBatch(stream.Context(), req, stream.Send)
+}
+
+// runBatch runs the searches of req concurrently and passes each outcome to
+// emit as it completes; calls to emit are serialized. A failed search does
+// not stop the others, but an error from emit does.
+//
+// Every search is charged to the caller's quotas like a separate Search
+// call, the first one having been charged for the batch call itself.
+func (s *GithubSearchServer) runBatch(ctx context.Context, req *pb.BatchSearchRequest, emit func(*pb.BatchSearchResult) error) error {
+	identity, err := GetCallerIdentityFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	if s.batch.MaxQueries == 0 {
+		return status.Errorf(codes.Unimplemented, "batch searches are disabled on this server")
+	}
+	switch n := len(req.GetRequests()); {
+	case n == 0:
+		return status.Errorf(codes.InvalidArgument, "'requests' must not be empty")
+	case n > s.batch.MaxQueries:
+		return status.Errorf(codes.InvalidArgument, "'requests' must hold at most %d searches", s.batch.MaxQueries)
+	}
+	if req.GetMaxConcurrency() < 0 {
+		return status.Errorf(codes.InvalidArgument, "invalid value for 'max_concurrency': must not be negative")
+	}
+	concurrency := s.batch.MaxConcurrency
+	if req.GetMaxConcurrency() > 0 {
+		concurrency = min(concurrency, int(req.GetMaxConcurrency()))
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		emitErr error
+	)
+	slots := make(chan struct{}, concurrency)
+	for i, searchReq := range req.GetRequests() {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			result := s.batchSearch(ctx, identity, i, searchReq)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if emitErr != nil {
+				return
+			}
+			if emitErr = emit(result); emitErr != nil {
+				cancel()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if emitErr != nil {
+		return emitErr
+	}
+	if ctx.Err() != nil {
+		return status.FromContextError(ctx.Err()).Err()
+	}
+	return nil
+}
+
+// batchSearch runs the search at index i of a batch.
+func (s *GithubSearchServer) batchSearch(ctx context.Context, identity string, i int, req *pb.SearchRequest) *pb.BatchSearchResult {
+	result := &pb.BatchSearchResult{Index: int32(i)}
+	if s.quota != nil && i > 0 {
+		if err := s.quota.Allow(identity); err != nil {
+			result.Error = batchSearchError(err)
+			return result
+		}
+	}
+
+	resp, err := s.Search(ctx, req)
+	if err != nil {
+		result.Error = batchSearchError(err)
+		return result
+	}
+	if s.quota != nil {
+		s.quota.RecordResults(identity, len(resp.GetResults()))
+	}
+	result.Response = resp
+	return result
+}
+
+func batchSearchError(err error) *pb.BatchSearchError {
+	st := status.Convert(err)
+	return &pb.BatchSearchError{Code: int32(st.Code()), Message: st.Message()}
+}
@@ -0,0 +1,229 @@
+package server
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Pratham700/github-search-service/internal/github"
+	pb "github.com/Pratham700/github-search-service/proto/proto"
+)
+
+// newSearchTest returns a server searching a fake GitHub that finds the
+// hits search(q) for query q, paged by the page and per_page parameters, and
+// a counter of the pages it returned.
+func newSearchTest(t *testing.T, options Options, search func(q string) []github.GitHubSearchItem) (*GithubSearchServer, *atomic.Int32) {
+	t.Helper()
+	var searches atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		searches.Add(1)
+		query := r.URL.Query()
+		items := search(query.Get("q"))
+		resp := github.GithubSearchCodeResponse{TotalCount: len(items), Items: items}
+		if perPage, err := strconv.Atoi(query.Get("per_page")); err == nil {
+			page, _ := strconv.Atoi(cmp.Or(query.Get("page"), "1"))
+			start := min((page-1)*perPage, len(items))
+			resp.Items = items[start:min(start+perPage, len(items))]
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(ts.Close)
+	client := github.NewGitHubClient(github.ClientConfig{BaseURL: ts.URL, Timeout: 5 * time.Second, Retry: github.RetryConfig{MaxAttempts: 1}})
+	s, err := NewGithubSearchServer(client, options)
+	if err != nil {
+		t.Fatalf("NewGithubSearchServer() error = %v", err)
+	}
+	return s, &searches
+}
+
+// linkedItem returns a search hit for path in repo with the URLs a search
+// result needs.
+func linkedItem(repo, path string) github.GitHubSearchItem {
+	item := searchItem(repo, path)
+	item.HTMLURL = "https://github.com/" + repo + "/blob/main/" + path
+	item.Repository.HTMLURL = "https://github.com/" + repo
+	return item
+}
+
+// batchOf returns a batch searching each of terms.
+func batchOf(concurrency int32, terms ...string) *pb.BatchSearchRequest {
+	req := &pb.BatchSearchRequest{MaxConcurrency: concurrency}
+	for _, term := range terms {
+		req.Requests = append(req.Requests, &pb.SearchRequest{SearchTerm: term})
+	}
+	return req
+}
+
+func TestBatchSearchOrder(t *testing.T) {
+	s, _ := newSearchTest(t, Options{Batch: BatchConfig{MaxQueries: 10, MaxConcurrency: 4}}, func(q string) []github.GitHubSearchItem {
+		// Later searches finish first
+		delay := map[string]time.Duration{"a": 30 * time.Millisecond, "b": 20 * time.Millisecond, "c": 10 * time.Millisecond}[q]
+		time.Sleep(delay)
+		if q == "fail" {
+			return nil
+		}
+		return []github.GitHubSearchItem{linkedItem("o/r", q+".go")}
+	})
+	ctx := setAuthTokenInContext(context.Background(), "owner")
+	resp, err := s.BatchSearch(ctx, batchOf(0, "a", "b", "", "c"))
+	if err != nil {
+		t.Fatalf("BatchSearch() error = %v", err)
+	}
+
+	if len(resp.GetResponses()) != 4 || resp.GetFailedCount() != 1 {
+		t.Fatalf("BatchSearch() = %d responses, %d failed, want 4, 1", len(resp.GetResponses()), resp.GetFailedCount())
+	}
+	for i, term := range []string{"a", "b", "", "c"} {
+		result := resp.GetResponses()[i]
+		if result.GetIndex() != int32(i) {
+			t.Errorf("responses[%d].index = %d", i, result.GetIndex())
+		}
+		if term == "" {
+			if result.GetError().GetCode() != int32(codes.InvalidArgument) {
+				t.Errorf("responses[%d].error = %v, want InvalidArgument", i, result.GetError())
+			}
+			continue
+		}
+		results := result.GetResponse().GetResults()
+		if len(results) != 1 || !strings.HasSuffix(results[0].GetFileUrl(), "/"+term+".go") {
+			t.Errorf("responses[%d] = %v, want the hit of %q", i, results, term)
+		}
+	}
+}
+
+func TestBatchSearchQuota(t *testing.T) {
+	tests := []struct {
+		name  string
+		quota QuotaConfig
+		want  []codes.Code
+	}{
+		// The first search is charged for the call itself, by the quota interceptor
+		{"requests", QuotaConfig{RequestsPerMinute: 2}, []codes.Code{codes.OK, codes.OK, codes.OK, codes.ResourceExhausted}},
+		{"results", QuotaConfig{ResultsPerDay: 5}, []codes.Code{codes.OK, codes.OK, codes.OK, codes.ResourceExhausted}},
+		{"unlimited", QuotaConfig{}, []codes.Code{codes.OK, codes.OK, codes.OK, codes.OK}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quota := NewQuotaLimiter(tt.quota)
+			options := Options{Quota: quota, Batch: BatchConfig{MaxQueries: 10, MaxConcurrency: 4}}
+			s, searches := newSearchTest(t, options, func(q string) []github.GitHubSearchItem {
+				return []github.GitHubSearchItem{linkedItem("o/r", "a.go"), linkedItem("o/r", "b.go")}
+			})
+			ctx := setAuthTokenInContext(context.Background(), "owner")
+			// One search at a time, so that the quotas run out at the last one
+			resp, err := s.BatchSearch(ctx, batchOf(1, "a", "b", "c", "d"))
+			if err != nil {
+				t.Fatalf("BatchSearch() error = %v", err)
+			}
+
+			ran := 0
+			for i, result := range resp.GetResponses() {
+				if got := codes.Code(result.GetError().GetCode()); got != tt.want[i] {
+					t.Errorf("responses[%d] = %v, want %v", i, got, tt.want[i])
+				}
+				if result.GetError() == nil {
+					ran++
+				}
+			}
+			if int(searches.Load()) != ran {
+				t.Errorf("GitHub ran %d searches, want %d: a search refused by the quotas must not reach it", searches.Load(), ran)
+			}
+		})
+	}
+}
+
+func TestRunBatchEmitError(t *testing.T) {
+	errSend := errors.New("send failed")
+	tests := []struct {
+		name         string
+		concurrency  int32
+		wantSearches int32
+	}{
+		{"stops starting searches", 1, 1},
+		{"stops emitting", 4, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			release := make(chan struct{})
+			s, searches := newSearchTest(t, Options{Batch: BatchConfig{MaxQueries: 10, MaxConcurrency: 4}}, func(q string) []github.GitHubSearchItem {
+				if q != "a" {
+					<-release
+				}
+				return nil
+			})
+			ctx := setAuthTokenInContext(context.Background(), "owner")
+			emitted := 0
+			err := s.runBatch(ctx, batchOf(tt.concurrency, "a", "b", "c", "d"), func(result *pb.BatchSearchResult) error {
+				emitted++
+				if emitted == 1 {
+					close(release)
+				}
+				return errSend
+			})
+			if !errors.Is(err, errSend) {
+				t.Errorf("runBatch() error = %v, want %v", err, errSend)
+			}
+			if emitted != 1 {
+				t.Errorf("emitted %d results, want 1", emitted)
+			}
+			if got := searches.Load(); got > tt.wantSearches {
+				t.Errorf("GitHub ran %d searches, want at most %d", got, tt.wantSearches)
+			}
+		})
+	}
+}
+
+func TestRunBatchCanceled(t *testing.T) {
+	s, _ := newSearchTest(t, Options{Batch: BatchConfig{MaxQueries: 10, MaxConcurrency: 1}}, func(string) []github.GitHubSearchItem {
+		return nil
+	})
+	ctx, cancel := context.WithCancel(setAuthTokenInContext(context.Background(), "owner"))
+	err := s.runBatch(ctx, batchOf(0, "a", "b"), func(*pb.BatchSearchResult) error {
+		cancel()
+		return nil
+	})
+	if status.Code(err) != codes.Canceled {
+		t.Errorf("runBatch() error = %v, want Canceled", err)
+	}
+}
+
+func TestRunBatchValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		config BatchConfig
+		req    *pb.BatchSearchRequest
+		want   codes.Code
+	}{
+		{"disabled", BatchConfig{}, batchOf(0, "a"), codes.Unimplemented},
+		{"empty", BatchConfig{MaxQueries: 2, MaxConcurrency: 1}, batchOf(0), codes.InvalidArgument},
+		{"too many searches", BatchConfig{MaxQueries: 2, MaxConcurrency: 1}, batchOf(0, "a", "b", "c"), codes.InvalidArgument},
+		{"negative concurrency", BatchConfig{MaxQueries: 2, MaxConcurrency: 1}, batchOf(-1, "a"), codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, searches := newSearchTest(t, Options{Batch: tt.config}, func(string) []github.GitHubSearchItem { return nil })
+			ctx := setAuthTokenInContext(context.Background(), "owner")
+			if _, err := s.BatchSearch(ctx, tt.req); status.Code(err) != tt.want {
+				t.Errorf("BatchSearch() error = %v, want %v", err, tt.want)
+			}
+			if searches.Load() != 0 {
+				t.Errorf("GitHub ran %d searches for a rejected batch", searches.Load())
+			}
+		})
+	}
+	s, _ := newSearchTest(t, Options{Batch: BatchConfig{MaxQueries: 2, MaxConcurrency: 1}}, func(string) []github.GitHubSearchItem { return nil })
+	if _, err := s.BatchSearch(context.Background(), batchOf(0, "a")); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("BatchSearch() without a token error = %v, want Unauthenticated", err)
+	}
+}
@@ -0,0 +1,164 @@
+package server
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/Pratham700/github-search-service/internal/github"
+	pb "github.com/Pratham700/github-search-service/proto/proto"
+)
+
+// vendoredDirectories are directory names that usually hold copies of
+// third-party code.
+var vendoredDirectories = []string{"vendor", "third_party", "third-party", "node_modules", "external"}
+
+// resultDedup collapses search results holding the same file. A nil
+// resultDedup keeps every result.
+type resultDedup struct {
+	mode           pb.DedupMode
+	representative pb.Representative
+	// lookup holds the details of the repositories that were looked up.
+	lookup *repositoryLookup
+}
+
+// newResultDedup creates the resultDedup of req, looking up the repositories
+// it needs: the forks for DEDUP_FORK, and the repositories of duplicated
+// files for REPRESENTATIVE_MOST_STARS. A repository whose lookup failed is
+// deduplicated by what the search returned about it.
+func newResultDedup(ctx context.Context, req *pb.SearchRequest, lookup *repositoryLookup, files []github.GitHubSearchItem) *resultDedup {
+	d := &resultDedup{
+		mode:           req.GetDedup(),
+		representative: req.GetRepresentative(),
+		lookup:         lookup,
+	}
+	if d.mode == pb.DedupMode_DEDUP_FORK {
+		var forks []string
+		for _, file := range files {
+			if file.Repository.Fork {
+				forks = append(forks, file.Repository.FullName)
+			}
+		}
+		lookup.lookup(ctx, forks)
+	}
+	if d.representative == pb.Representative_REPRESENTATIVE_MOST_STARS {
+		var names []string
+		for _, group := range d.groups(files) {
+			if len(group) > 1 {
+				for _, i := range group {
+					names = append(names, files[i].Repository.FullName)
+				}
+			}
+		}
+		lookup.lookup(ctx, names)
+	}
+	return d
+}
+
+// groups returns the indices of files grouped into sets of duplicates, in
+// the order of their first file.
+func (d *resultDedup) groups(files []github.GitHubSearchItem) [][]int {
+	parent := make([]int, len(files))
+	for i := range parent {
+		parent[i] = i
+	}
+	var root func(i int) int
+	root = func(i int) int {
+		if parent[i] != i {
+			parent[i] = root(parent[i])
+		}
+		return parent[i]
+	}
+
+	if d != nil && d.mode != pb.DedupMode_DEDUP_UNSPECIFIED {
+		first := make(map[string]int)
+		union := func(key string, i int) {
+			if j, ok := first[key]; ok {
+				parent[root(i)] = root(j)
+			} else {
+				first[key] = i
+			}
+		}
+		for i, file := range files {
+			if file.SHA != "" {
+				union("sha:"+file.SHA, i)
+			}
+			if d.mode == pb.DedupMode_DEDUP_FORK {
+				union("path:"+d.network(file.Repository)+"\x00"+file.Path, i)
+			}
+		}
+	}
+
+	var groups [][]int
+	index := make(map[int]int)
+	for i := range files {
+		r := root(i)
+		if g, ok := index[r]; ok {
+			groups[g] = append(groups[g], i)
+			continue
+		}
+		index[r] = len(groups)
+		groups = append(groups, []int{i})
+	}
+	return groups
+}
+
+// network returns the full name of the root of the fork network of repo.
+func (d *resultDedup) network(repo github.GitHubRepository) string {
+	if details := d.lookup.get(repo.FullName); details != nil && details.Source != nil {
+		return details.Source.FullName
+	}
+	return repo.FullName
+}
+
+// pick returns the index of the file representing group.
+func (d *resultDedup) pick(files []github.GitHubSearchItem, group []int) int {
+	best := group[0]
+	if d == nil {
+		return best
+	}
+	for _, i := range group[1:] {
+		if d.better(files[i], files[best]) {
+			best = i
+		}
+	}
+	return best
+}
+
+// better reports whether a represents its duplicates better than b.
+func (d *resultDedup) better(a, b github.GitHubSearchItem) bool {
+	if d.representative == pb.Representative_REPRESENTATIVE_MOST_STARS {
+		if starsA, starsB := d.stars(a.Repository), d.stars(b.Repository); starsA != starsB {
+			return starsA > starsB
+		}
+	}
+	if d.fork(a.Repository) != d.fork(b.Repository) {
+		return !d.fork(a.Repository)
+	}
+	return !vendored(a.Path) && vendored(b.Path)
+}
+
+func (d *resultDedup) fork(repo github.GitHubRepository) bool {
+	if details := d.lookup.get(repo.FullName); details != nil {
+		return details.Fork
+	}
+	return repo.Fork
+}
+
+func (d *resultDedup) stars(repo github.GitHubRepository) int {
+	if details := d.lookup.get(repo.FullName); details != nil {
+		return details.StargazersCount
+	}
+	return 0
+}
+
+// vendored reports whether path lies in a directory of third-party code.
+func vendored(path string) bool {
+	dirs := strings.Split(path, "/")
+	for _, dir := range dirs[:len(dirs)-1] {
+		if slices.Contains(vendoredDirectories, dir) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,238 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Pratham700/github-search-service/internal/github"
+	pb "github.com/Pratham700/github-search-service/proto/proto"
+)
+
+// dedupItem returns a search hit for path in repo with the given blob SHA.
+func dedupItem(repo, path, sha string, fork bool) github.GitHubSearchItem {
+	item := linkedItem(repo, path)
+	item.SHA = sha
+	item.Repository.Fork = fork
+	return item
+}
+
+// lookupOf returns a repository lookup that already holds repos.
+func lookupOf(repos ...*github.GitHubRepository) *repositoryLookup {
+	lookup := &repositoryLookup{repos: make(map[string]*github.GitHubRepository)}
+	for _, repo := range repos {
+		lookup.repos[repo.FullName] = repo
+	}
+	return lookup
+}
+
+func TestResultDedupGroups(t *testing.T) {
+	files := []github.GitHubSearchItem{
+		dedupItem("upstream/lib", "a.go", "sha1", false),
+		dedupItem("fork1/lib", "a.go", "sha2", true),
+		dedupItem("app/x", "vendor/upstream/a.go", "sha1", false),
+		dedupItem("other/y", "a.go", "sha3", false),
+		dedupItem("fork2/lib", "b.go", "sha4", true),
+		dedupItem("z/z", "c.go", "sha2", false),
+		dedupItem("nosha/n", "a.go", "", false),
+		dedupItem("nosha/m", "a.go", "", false),
+	}
+	upstream := &github.GitHubRepository{FullName: "upstream/lib"}
+	forks := lookupOf(
+		&github.GitHubRepository{FullName: "fork1/lib", Fork: true, Source: upstream},
+		&github.GitHubRepository{FullName: "fork2/lib", Fork: true, Source: upstream},
+	)
+	tests := []struct {
+		name  string
+		dedup *resultDedup
+		want  [][]int
+	}{
+		{"nil keeps every file", nil, [][]int{{0}, {1}, {2}, {3}, {4}, {5}, {6}, {7}}},
+		{
+			"unspecified keeps every file", &resultDedup{lookup: forks},
+			[][]int{{0}, {1}, {2}, {3}, {4}, {5}, {6}, {7}},
+		},
+		{
+			"same blob", &resultDedup{mode: pb.DedupMode_DEDUP_BLOB, lookup: forks},
+			[][]int{{0, 2}, {1, 5}, {3}, {4}, {6}, {7}},
+		},
+		{
+			// fork1/lib joins upstream by path, bringing z/z along by blob
+			"same path in the fork network", &resultDedup{mode: pb.DedupMode_DEDUP_FORK, lookup: forks},
+			[][]int{{0, 1, 2, 5}, {3}, {4}, {6}, {7}},
+		},
+		{
+			"failed fork lookups", &resultDedup{mode: pb.DedupMode_DEDUP_FORK, lookup: lookupOf()},
+			[][]int{{0, 2}, {1, 5}, {3}, {4}, {6}, {7}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.dedup.groups(files); !slices.EqualFunc(got, tt.want, slices.Equal) {
+				t.Errorf("groups() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResultDedupPick(t *testing.T) {
+	tests := []struct {
+		name           string
+		representative pb.Representative
+		lookup         *repositoryLookup
+		files          []github.GitHubSearchItem
+		want           int
+	}{
+		{
+			name: "first without a better one",
+			files: []github.GitHubSearchItem{
+				dedupItem("a/a", "x.go", "s", false), dedupItem("b/b", "y.go", "s", false),
+			},
+			want: 0,
+		},
+		{
+			name: "outside forks, then outside vendored directories",
+			files: []github.GitHubSearchItem{
+				dedupItem("f/f", "x.go", "s", true),
+				dedupItem("a/a", "third_party/f/x.go", "s", false),
+				dedupItem("b/b", "x.go", "s", false),
+			},
+			want: 2,
+		},
+		{
+			name:   "fork as looked up",
+			lookup: lookupOf(&github.GitHubRepository{FullName: "a/a", Fork: true}),
+			files: []github.GitHubSearchItem{
+				dedupItem("a/a", "x.go", "s", false), dedupItem("b/b", "x.go", "s", false),
+			},
+			want: 1,
+		},
+		{
+			name:           "most stars",
+			representative: pb.Representative_REPRESENTATIVE_MOST_STARS,
+			lookup: lookupOf(
+				&github.GitHubRepository{FullName: "a/a", StargazersCount: 10},
+				&github.GitHubRepository{FullName: "f/f", Fork: true, StargazersCount: 100},
+			),
+			files: []github.GitHubSearchItem{
+				dedupItem("a/a", "x.go", "s", false), dedupItem("f/f", "x.go", "s", true),
+			},
+			want: 1,
+		},
+		{
+			name:           "most stars tied",
+			representative: pb.Representative_REPRESENTATIVE_MOST_STARS,
+			lookup: lookupOf(
+				&github.GitHubRepository{FullName: "a/a", StargazersCount: 10},
+				&github.GitHubRepository{FullName: "b/b", StargazersCount: 10},
+			),
+			files: []github.GitHubSearchItem{
+				dedupItem("a/a", "vendor/x.go", "s", false), dedupItem("b/b", "x.go", "s", false),
+			},
+			want: 1,
+		},
+		{
+			name:           "most stars without lookups",
+			representative: pb.Representative_REPRESENTATIVE_MOST_STARS,
+			files: []github.GitHubSearchItem{
+				dedupItem("f/f", "x.go", "s", true), dedupItem("a/a", "x.go", "s", false),
+			},
+			want: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lookup := tt.lookup
+			if lookup == nil {
+				lookup = lookupOf()
+			}
+			d := &resultDedup{mode: pb.DedupMode_DEDUP_BLOB, representative: tt.representative, lookup: lookup}
+			group := make([]int, len(tt.files))
+			for i := range group {
+				group[i] = i
+			}
+			if got := d.pick(tt.files, group); got != tt.want {
+				t.Errorf("pick() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+	if got := (*resultDedup)(nil).pick(nil, []int{3, 1}); got != 3 {
+		t.Errorf("pick() of a nil resultDedup = %d, want 3", got)
+	}
+}
+
+func TestSearchDedup(t *testing.T) {
+	items := []github.GitHubSearchItem{
+		dedupItem("fork/lib", "a.go", "sha2", true),
+		dedupItem("upstream/lib", "a.go", "sha1", false),
+		dedupItem("app/x", "vendor/upstream/a.go", "sha1", false),
+		dedupItem("other/y", "b.go", "sha3", false),
+	}
+	repos := map[string]github.GitHubRepository{
+		"fork/lib":     {FullName: "fork/lib", Fork: true, StargazersCount: 500, Source: &github.GitHubRepository{FullName: "upstream/lib"}},
+		"upstream/lib": {FullName: "upstream/lib", StargazersCount: 50},
+		"app/x":        {FullName: "app/x", StargazersCount: 5},
+	}
+	tests := []struct {
+		name           string
+		mode           pb.DedupMode
+		representative pb.Representative
+		want           []string
+		wantLookups    []string
+	}{
+		{"none", pb.DedupMode_DEDUP_UNSPECIFIED, 0, []string{"fork/lib:0", "upstream/lib:0", "app/x:0", "other/y:0"}, nil},
+		{"blob", pb.DedupMode_DEDUP_BLOB, 0, []string{"fork/lib:0", "upstream/lib:1", "other/y:0"}, nil},
+		{"fork", pb.DedupMode_DEDUP_FORK, 0, []string{"upstream/lib:2", "other/y:0"}, []string{"fork/lib"}},
+		{
+			"fork with most stars", pb.DedupMode_DEDUP_FORK, pb.Representative_REPRESENTATIVE_MOST_STARS,
+			[]string{"fork/lib:2", "other/y:0"}, []string{"app/x", "fork/lib", "upstream/lib"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var lookups []string
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if name, ok := strings.CutPrefix(r.URL.Path, "/repos/"); ok {
+					mu.Lock()
+					lookups = append(lookups, name)
+					mu.Unlock()
+					json.NewEncoder(w).Encode(repos[name])
+					return
+				}
+				json.NewEncoder(w).Encode(github.GithubSearchCodeResponse{TotalCount: len(items), Items: items})
+			}))
+			defer ts.Close()
+			client := github.NewGitHubClient(github.ClientConfig{BaseURL: ts.URL, Timeout: 5 * time.Second, Retry: github.RetryConfig{MaxAttempts: 1}})
+			s, err := NewGithubSearchServer(client, Options{})
+			if err != nil {
+				t.Fatalf("NewGithubSearchServer() error = %v", err)
+			}
+
+			ctx := setAuthTokenInContext(context.Background(), "owner")
+			resp, err := s.Search(ctx, &pb.SearchRequest{SearchTerm: "foo", Dedup: tt.mode, Representative: tt.representative})
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+			var got []string
+			for _, result := range resp.GetResults() {
+				repo := strings.TrimPrefix(result.GetRepo(), "https://github.com/")
+				got = append(got, repo+":"+strconv.Itoa(int(result.GetDuplicateCount())))
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("results = %v, want %v", got, tt.want)
+			}
+			slices.Sort(lookups)
+			if !slices.Equal(lookups, tt.wantLookups) {
+				t.Errorf("looked up %v, want %v", lookups, tt.wantLookups)
+			}
+		})
+	}
+}
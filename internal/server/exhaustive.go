@@ -0,0 +1,340 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Pratham700/github-search-service/internal/github"
+	pb "github.com/Pratham700/github-search-service/proto/proto"
+)
+
+const (
+	// maxQueryLength is the length of the longest query GitHub code search accepts.
+	maxQueryLength = 256
+	// maxIndexedFileSize is the size in bytes of the largest file GitHub
+	// indexes for code search.
+	maxIndexedFileSize = 384 * 1024
+	// maxSplitValues is the number of extensions or owners a shard is split by at a time.
+	maxSplitValues = 10
+)
+
+// ExhaustiveConfig limits exhaustive searches.
+type ExhaustiveConfig struct {
+	// MaxQueries is the number of GitHub searches run per call; 0 disables ExhaustiveSearch.
+	MaxQueries int
+	// MaxResults is the number of distinct hits returned per call.
+	MaxResults int
+}
+
+// ExhaustiveSearch implements the ExhaustiveSearch gRPC method. GitHub
+// returns at most 1000 hits per search, so a search with more is split into
+// shards narrowed by file size, extension and owner until each shard is
+// below the limit, and the hits of all shards are merged. The searches run
+// with the caller's own GitHub token.
+func (s *GithubSearchServer) ExhaustiveSearch(ctx context.Context, req *pb.ExhaustiveSearchRequest) (*pb.ExhaustiveSearchResponse, error) {
+	identity, err := GetCallerIdentityFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if s.exhaustive.MaxQueries == 0 {
+		return nil, status.Errorf(codes.Unimplemented, "exhaustive searches are disabled on this server")
+	}
+	if req.GetRequest() == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "'request' is required")
+	}
+	if req.GetMaxResults() < 0 || req.GetMaxQueries() < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "'max_results' and 'max_queries' must not be negative")
+	}
+	token, err := GetAuthTokenFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := s.validateHitsRequest(req.GetRequest())
+	if err != nil {
+		return nil, err
+	}
+	params["per_page"] = strconv.Itoa(maxPerPage)
+
+	term := req.GetRequest().GetSearchTerm()
+	if user := req.GetRequest().GetUser(); user != "" {
+		term += " user:" + user
+	}
+	lower := strings.ToLower(term)
+	search := &exhaustiveSearch{
+		server:         s,
+		term:           term,
+		params:         params,
+		filter:         newResultFilter(req.GetRequest()),
+		lookup:         s.newRepositoryLookup(token, identity),
+		token:          token,
+		identity:       identity,
+		maxQueries:     s.exhaustive.MaxQueries,
+		maxResults:     s.exhaustive.MaxResults,
+		splitSize:      !strings.Contains(lower, "size:"),
+		splitExtension: !strings.Contains(lower, "extension:"),
+		splitOwner:     !strings.Contains(lower, "user:") && !strings.Contains(lower, "org:") && !strings.Contains(lower, "repo:"),
+		seen:           make(map[[2]string]bool),
+		resp:           &pb.ExhaustiveSearchResponse{},
+	}
+	if req.GetMaxQueries() > 0 {
+		search.maxQueries = min(search.maxQueries, int(req.GetMaxQueries()))
+	}
+	if req.GetMaxResults() > 0 {
+		search.maxResults = min(search.maxResults, int(req.GetMaxResults()))
+	}
+
+	err = search.run(ctx)
+	if err != nil {
+		return nil, searchError(err)
+	}
+
+	resp := search.resp
+	resp.HitCount = int32(len(resp.GetHits()))
+	resp.Complete = len(resp.GetIncompleteShards()) == 0
+	resp.Coverage = 1
+	if !resp.GetComplete() && resp.GetTotalCount() > 0 {
+		resp.Coverage = min(1, float64(resp.GetHitCount())/float64(resp.GetTotalCount()))
+	}
+	slog.DebugContext(ctx, "Exhaustive search completed", "hits", resp.GetHitCount(), "queries", resp.GetQueryCount(),
+		"shards", resp.GetShardCount(), "incomplete_shards", len(resp.GetIncompleteShards()))
+	return resp, nil
+}
+
+// exhaustiveShard is a part of an exhaustive search: the search narrowed by
+// qualifiers.
+type exhaustiveShard struct {
+	qualifiers []string
+	// sized is set if the shard is limited to files of minSize to maxSize bytes.
+	sized            bool
+	minSize, maxSize int
+	// byExtension and byOwner are set if the shard is limited to one
+	// extension or owner, rather than excluding some.
+	byExtension, byOwner bool
+}
+
+// query returns the GitHub query of the shard of the search for term.
+func (sh exhaustiveShard) query(term string) string {
+	parts := append([]string{term}, sh.qualifiers...)
+	if sh.sized {
+		parts = append(parts, fmt.Sprintf("size:%d..%d", sh.minSize, sh.maxSize))
+	}
+	return strings.Join(parts, " ")
+}
+
+// with returns the shard narrowed by another qualifier.
+func (sh exhaustiveShard) with(qualifier string) exhaustiveShard {
+	sh.qualifiers = append(slices.Clip(sh.qualifiers), qualifier)
+	return sh
+}
+
+// exhaustiveSearch holds the state of an ExhaustiveSearch call.
+type exhaustiveSearch struct {
+	server          *GithubSearchServer
+	term            string // The search term, including the user qualifier
+	params          map[string]string
+	filter          *resultFilter
+	lookup          *repositoryLookup
+	token, identity string
+
+	maxQueries, maxResults int
+	// The dimensions the search term leaves free to split by
+	splitSize, splitExtension, splitOwner bool
+
+	seen map[[2]string]bool // Repository and path of the hits
+	resp *pb.ExhaustiveSearchResponse
+	// stopped is set once the caller's quota is exhausted or GitHub fails.
+	stopped bool
+}
+
+// run fetches the shards depth first, splitting those over GitHub's limit.
+// Once the budget is spent or a search fails, the remaining shards are
+// reported as incomplete; only a failure of the first search is returned.
+func (e *exhaustiveSearch) run(ctx context.Context) error {
+	shards := []exhaustiveShard{{}}
+	for len(shards) > 0 {
+		shard := shards[len(shards)-1]
+		shards = shards[:len(shards)-1]
+		if !e.allow() {
+			e.incomplete(shard, 0, 0)
+			continue
+		}
+		first, err := e.search(ctx, shard, 1)
+		if err != nil {
+			if e.resp.GetQueryCount() == 1 {
+				return err
+			}
+			e.fail(ctx, err)
+			e.incomplete(shard, 0, 0)
+			continue
+		}
+		if e.resp.GetQueryCount() == 1 {
+			e.resp.TotalCount = int32(first.TotalCount)
+		}
+		if first.TotalCount > maxGitHubResults {
+			if children := e.split(shard, first.Items); children != nil {
+				shards = append(shards, children...)
+				continue
+			}
+		}
+
+		e.resp.ShardCount++
+		total := min(first.TotalCount, maxGitHubResults)
+		fetched := e.add(ctx, first.Items)
+		incomplete := first.IncompleteResults
+		for page := 2; fetched == (page-1)*maxPerPage && fetched < total; page++ {
+			if !e.allow() {
+				break
+			}
+			result, err := e.search(ctx, shard, page)
+			if err != nil {
+				e.fail(ctx, err)
+				break
+			}
+			fetched += e.add(ctx, result.Items)
+			incomplete = incomplete || result.IncompleteResults
+		}
+		if incomplete || fetched < first.TotalCount {
+			e.incomplete(shard, first.TotalCount, fetched)
+		}
+	}
+	return nil
+}
+
+// allow reports whether another GitHub search may run, charging it to the
+// caller's quota. The first search was charged for the call itself.
+func (e *exhaustiveSearch) allow() bool {
+	if e.stopped || int(e.resp.GetQueryCount()) >= e.maxQueries || len(e.resp.GetHits()) >= e.maxResults {
+		return false
+	}
+	if e.server.quota != nil && e.resp.GetQueryCount() > 0 {
+		if err := e.server.quota.Allow(e.identity); err != nil {
+			e.stopped = true
+			return false
+		}
+	}
+	return true
+}
+
+// fail stops the search after a GitHub search failed.
+func (e *exhaustiveSearch) fail(ctx context.Context, err error) {
+	slog.WarnContext(ctx, "Exhaustive search stopped early", "error", err)
+	e.stopped = true
+}
+
+// search fetches a page of the shard's hits.
+func (e *exhaustiveSearch) search(ctx context.Context, shard exhaustiveShard, page int) (*github.GithubSearchCodeResponse, error) {
+	e.resp.QueryCount++
+	params := make(map[string]string, len(e.params)+1)
+	for key, value := range e.params {
+		params[key] = value
+	}
+	params["page"] = strconv.Itoa(page)
+	result, err := e.server.GitHubClient().SearchCode(ctx, shard.query(e.term), "", e.token, params)
+	if err != nil {
+		return nil, err
+	}
+	if e.server.quota != nil {
+		e.server.quota.RecordResults(e.identity, len(result.Items))
+	}
+	return result, nil
+}
+
+// add records the hits of items that pass the filters and were not seen in
+// other shards, up to the result limit, and returns the number of items it
+// went through.
+func (e *exhaustiveSearch) add(ctx context.Context, items []github.GitHubSearchItem) int {
+	kept := make(map[[2]string]bool)
+	for _, item := range filterItems(ctx, e.lookup, e.filter, items) {
+		kept[[2]string{item.Repository.FullName, item.Path}] = true
+	}
+	for i, item := range items {
+		if len(e.resp.GetHits()) >= e.maxResults {
+			return i
+		}
+		key := [2]string{item.Repository.FullName, item.Path}
+		if kept[key] && !e.seen[key] {
+			e.seen[key] = true
+			e.resp.Hits = append(e.resp.Hits, watchHit(item))
+		}
+	}
+	return len(items)
+}
+
+// incomplete reports a shard whose hits were not all fetched.
+func (e *exhaustiveSearch) incomplete(shard exhaustiveShard, total, fetched int) {
+	e.resp.IncompleteShards = append(e.resp.IncompleteShards, &pb.ExhaustiveShard{
+		Query:        shard.query(e.term),
+		TotalCount:   int32(total),
+		FetchedCount: int32(fetched),
+	})
+}
+
+// split divides a shard with too many hits, halving its size range until it
+// holds a single size, then by the extensions and then the owners found among
+// items, the first page of its hits. It returns nil if the shard cannot be
+// split any further.
+func (e *exhaustiveSearch) split(shard exhaustiveShard, items []github.GitHubSearchItem) []exhaustiveShard {
+	if e.splitSize && (!shard.sized || shard.minSize < shard.maxSize) {
+		low, high := 0, maxIndexedFileSize
+		if shard.sized {
+			low, high = shard.minSize, shard.maxSize
+		}
+		mid := low + (high-low)/2
+		smaller, larger := shard, shard
+		smaller.sized, smaller.minSize, smaller.maxSize = true, low, mid
+		larger.sized, larger.minSize, larger.maxSize = true, mid+1, high
+		return []exhaustiveShard{larger, smaller}
+	}
+	if e.splitExtension && !shard.byExtension {
+		children := e.splitBy(shard, items, "extension", func(item github.GitHubSearchItem) string {
+			return strings.ToLower(strings.TrimPrefix(path.Ext(item.Path), "."))
+		}, func(child *exhaustiveShard) { child.byExtension = true })
+		if children != nil {
+			return children
+		}
+	}
+	if e.splitOwner && !shard.byOwner {
+		return e.splitBy(shard, items, "user", func(item github.GitHubSearchItem) string {
+			owner, _, _ := strings.Cut(item.Repository.FullName, "/")
+			return owner
+		}, func(child *exhaustiveShard) { child.byOwner = true })
+	}
+	return nil
+}
+
+// splitBy divides a shard into one shard per frequent value of qualifier
+// among items and a remaining shard excluding those values, which can be
+// split again by the values found among its own hits.
+func (e *exhaustiveSearch) splitBy(shard exhaustiveShard, items []github.GitHubSearchItem, qualifier string, value func(github.GitHubSearchItem) string, pin func(*exhaustiveShard)) []exhaustiveShard {
+	counts := facetCounter{}
+	for _, item := range items {
+		if v := value(item); v != "" && !strings.ContainsAny(v, " \"") {
+			counts[v]++
+		}
+	}
+
+	var children []exhaustiveShard
+	rest := shard
+	for _, count := range counts.top(maxSplitValues) {
+		child := shard.with(qualifier + ":" + count.GetValue())
+		pin(&child)
+		next := rest.with("-" + qualifier + ":" + count.GetValue())
+		if len(child.query(e.term)) > maxQueryLength || len(next.query(e.term)) > maxQueryLength {
+			break
+		}
+		children = append(children, child)
+		rest = next
+	}
+	if children == nil {
+		return nil
+	}
+	return append([]exhaustiveShard{rest}, children...)
+}
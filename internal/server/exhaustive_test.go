@@ -0,0 +1,161 @@
+package server
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Pratham700/github-search-service/internal/github"
+)
+
+// searchItem returns a search hit for path in the repository with the given full name.
+func searchItem(repo, path string) github.GitHubSearchItem {
+	return github.GitHubSearchItem{Path: path, Repository: github.GitHubRepository{FullName: repo}}
+}
+
+// shardQueries returns the queries of shards for term.
+func shardQueries(term string, shards []exhaustiveShard) []string {
+	var queries []string
+	for _, shard := range shards {
+		queries = append(queries, shard.query(term))
+	}
+	return queries
+}
+
+func TestExhaustiveSplit(t *testing.T) {
+	items := []github.GitHubSearchItem{
+		searchItem("alice/a", "main.go"),
+		searchItem("alice/b", "util.go"),
+		searchItem("bob/c", "README.md"),
+	}
+	sized := func(low, high int) exhaustiveShard {
+		return exhaustiveShard{sized: true, minSize: low, maxSize: high}
+	}
+	tests := []struct {
+		name   string
+		search exhaustiveSearch
+		shard  exhaustiveShard
+		want   []string
+	}{
+		{
+			name:   "halves the indexed size range",
+			search: exhaustiveSearch{splitSize: true},
+			shard:  exhaustiveShard{},
+			want:   []string{"foo size:196609..393216", "foo size:0..196608"},
+		},
+		{
+			name:   "halves a size range",
+			search: exhaustiveSearch{splitSize: true},
+			shard:  sized(100, 200),
+			want:   []string{"foo size:151..200", "foo size:100..150"},
+		},
+		{
+			name:   "splits two sizes into one each",
+			search: exhaustiveSearch{splitSize: true},
+			shard:  sized(7, 8),
+			want:   []string{"foo size:8..8", "foo size:7..7"},
+		},
+		{
+			name:   "splits a single size by extension",
+			search: exhaustiveSearch{splitSize: true, splitExtension: true},
+			shard:  sized(7, 7),
+			want: []string{
+				"foo -extension:go -extension:md size:7..7",
+				"foo extension:go size:7..7",
+				"foo extension:md size:7..7",
+			},
+		},
+		{
+			name:   "splits by owner after extension",
+			search: exhaustiveSearch{splitExtension: true, splitOwner: true},
+			shard:  exhaustiveShard{qualifiers: []string{"extension:go"}, byExtension: true},
+			want: []string{
+				"foo extension:go -user:alice -user:bob",
+				"foo extension:go user:alice",
+				"foo extension:go user:bob",
+			},
+		},
+		{
+			name:   "no size split if the term has a size qualifier",
+			search: exhaustiveSearch{splitExtension: true},
+			shard:  exhaustiveShard{},
+			want:   []string{"foo -extension:go -extension:md", "foo extension:go", "foo extension:md"},
+		},
+		{
+			name:   "cannot split further",
+			search: exhaustiveSearch{splitSize: true, splitExtension: true, splitOwner: true},
+			shard:  exhaustiveShard{sized: true, minSize: 7, maxSize: 7, byExtension: true, byOwner: true},
+			want:   nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.search.term = "foo"
+			got := shardQueries("foo", tt.search.split(tt.shard, items))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("split() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExhaustiveSplitBy(t *testing.T) {
+	extension := func(item github.GitHubSearchItem) string {
+		_, ext, _ := strings.Cut(item.Path, ".")
+		return ext
+	}
+	tests := []struct {
+		name  string
+		term  string
+		items []github.GitHubSearchItem
+		want  []string // The qualifiers of the shards
+	}{
+		{
+			name:  "most frequent first",
+			term:  "foo",
+			items: []github.GitHubSearchItem{searchItem("o/r", "a.md"), searchItem("o/r", "b.go"), searchItem("o/r", "c.go")},
+			want:  []string{"-extension:go -extension:md", "extension:go", "extension:md"},
+		},
+		{
+			name:  "values that cannot be qualified are skipped",
+			term:  "foo",
+			items: []github.GitHubSearchItem{searchItem("o/r", "Makefile"), searchItem("o/r", "a.x y"), searchItem("o/r", "b.go")},
+			want:  []string{"-extension:go", "extension:go"},
+		},
+		{
+			name:  "no values",
+			term:  "foo",
+			items: []github.GitHubSearchItem{searchItem("o/r", "Makefile")},
+			want:  nil,
+		},
+		{
+			// Only go fits: adding md would take the remaining shard past the limit
+			name:  "stops at the query length limit",
+			term:  strings.Repeat("x", maxQueryLength-len(" -extension:go -extension:md")+1),
+			items: []github.GitHubSearchItem{searchItem("o/r", "a.md"), searchItem("o/r", "b.go"), searchItem("o/r", "c.go")},
+			want:  []string{"-extension:go", "extension:go"},
+		},
+		{
+			name:  "nothing fits",
+			term:  strings.Repeat("x", maxQueryLength-len(" extension:go")+1),
+			items: []github.GitHubSearchItem{searchItem("o/r", "b.go")},
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &exhaustiveSearch{term: tt.term}
+			shards := e.splitBy(exhaustiveShard{}, tt.items, "extension", extension, func(*exhaustiveShard) {})
+			var got []string
+			for _, query := range shardQueries(tt.term, shards) {
+				if len(query) > maxQueryLength {
+					t.Errorf("query of %d characters exceeds the limit of %d", len(query), maxQueryLength)
+				}
+				got = append(got, strings.TrimPrefix(query, tt.term+" "))
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("splitBy() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package server
+
+import (
+	"cmp"
+	"context"
+	"path"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/Pratham700/github-search-service/proto/proto"
+)
+
+const defaultFacetSize = 10
+
+// FacetConfig limits SearchFacets.
+type FacetConfig struct {
+	// MaxResults is the number of hits analyzed per call; 0 disables SearchFacets.
+	MaxResults int
+}
+
+// languageByExtension maps lower-case file extensions to the language of the
+// file, for the languages facet. Code search does not report languages.
+var languageByExtension = map[string]string{
+	".c": "C", ".h": "C",
+	".cc": "C++", ".cpp": "C++", ".cxx": "C++", ".hh": "C++", ".hpp": "C++",
+	".cs":   "C#",
+	".css":  "CSS",
+	".dart": "Dart",
+	".ex":   "Elixir", ".exs": "Elixir",
+	".go":     "Go",
+	".groovy": "Groovy", ".gradle": "Groovy",
+	".hs":   "Haskell",
+	".html": "HTML", ".htm": "HTML",
+	".java": "Java",
+	".js":   "JavaScript", ".cjs": "JavaScript", ".mjs": "JavaScript", ".jsx": "JavaScript",
+	".json": "JSON",
+	".kt":   "Kotlin", ".kts": "Kotlin",
+	".lua": "Lua",
+	".md":  "Markdown",
+	".m":   "Objective-C", ".mm": "Objective-C",
+	".php": "PHP",
+	".pl":  "Perl", ".pm": "Perl",
+	".proto": "Protocol Buffers",
+	".py":    "Python", ".pyi": "Python",
+	".r":     "R",
+	".rb":    "Ruby",
+	".rs":    "Rust",
+	".scala": "Scala",
+	".sh":    "Shell", ".bash": "Shell", ".zsh": "Shell",
+	".sql":   "SQL",
+	".swift": "Swift",
+	".tf":    "HCL", ".hcl": "HCL",
+	".toml": "TOML",
+	".ts":   "TypeScript", ".tsx": "TypeScript",
+	".vue":  "Vue",
+	".xml":  "XML",
+	".yaml": "YAML", ".yml": "YAML",
+}
+
+// SearchFacets implements the SearchFacets gRPC method. The search runs with
+// the caller's own GitHub token.
+func (s *GithubSearchServer) SearchFacets(ctx context.Context, req *pb.SearchFacetsRequest) (*pb.SearchFacetsResponse, error) {
+	identity, err := GetCallerIdentityFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if s.facets.MaxResults == 0 {
+		return nil, status.Errorf(codes.Unimplemented, "search facets are disabled on this server")
+	}
+	if req.GetRequest() == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "'request' is required")
+	}
+	if _, err := s.validateHitsRequest(req.GetRequest()); err != nil {
+		return nil, err
+	}
+	if req.GetMaxResults() < 0 || req.GetFacetSize() < 0 || req.GetFacetSize() > maxPerPage {
+		return nil, status.Errorf(codes.InvalidArgument, "'max_results' must not be negative and 'facet_size' must be between 0 and %d", maxPerPage)
+	}
+	maxResults := s.facets.MaxResults
+	if req.GetMaxResults() > 0 {
+		maxResults = min(maxResults, int(req.GetMaxResults()))
+	}
+	facetSize := defaultFacetSize
+	if req.GetFacetSize() > 0 {
+		facetSize = int(req.GetFacetSize())
+	}
+
+	token, err := GetAuthTokenFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	fetched, err := s.fetchHits(ctx, req.GetRequest(), token, identity, maxResults, true)
+	if err != nil {
+		return nil, searchError(err)
+	}
+	hits, total := fetched.hits, fetched.total
+
+	repos, owners, extensions, languages, directories := facetCounter{}, facetCounter{}, facetCounter{}, facetCounter{}, facetCounter{}
+	for _, hit := range hits {
+		repos[hit.GetRepo()]++
+		owner, _, _ := strings.Cut(hit.GetRepo(), "/")
+		owners[owner]++
+		ext := strings.ToLower(path.Ext(hit.GetPath()))
+		extensions[ext]++
+		language, ok := languageByExtension[ext]
+		if !ok {
+			language = "Other"
+		}
+		languages[language]++
+		directory := "/"
+		if dir, _, found := strings.Cut(hit.GetPath(), "/"); found {
+			directory = dir
+		}
+		directories[directory]++
+	}
+
+	resp := &pb.SearchFacetsResponse{
+		Repositories:  repos.top(facetSize),
+		Owners:        owners.top(facetSize),
+		Extensions:    extensions.top(facetSize),
+		Languages:     languages.top(facetSize),
+		Directories:   directories.top(facetSize),
+		TotalCount:    int32(total),
+		AnalyzedCount: int32(len(hits)),
+		Coverage:      1,
+	}
+	if total > 0 {
+		resp.Coverage = float64(len(hits)) / float64(total)
+	}
+	return resp, nil
+}
+
+// facetCounter counts the hits per value of a facet.
+type facetCounter map[string]int
+
+// top returns the n most frequent values, ties broken by value.
+func (c facetCounter) top(n int) []*pb.FacetCount {
+	counts := make([]*pb.FacetCount, 0, len(c))
+	for value, count := range c {
+		counts = append(counts, &pb.FacetCount{Value: value, Count: int32(count)})
+	}
+	slices.SortFunc(counts, func(a, b *pb.FacetCount) int {
+		return cmp.Or(cmp.Compare(b.GetCount(), a.GetCount()), strings.Compare(a.GetValue(), b.GetValue()))
+	})
+	return counts[:min(n, len(counts))]
+}
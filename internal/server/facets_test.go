@@ -0,0 +1,144 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Pratham700/github-search-service/internal/github"
+	pb "github.com/Pratham700/github-search-service/proto/proto"
+)
+
+// facetSpecs formats counts as "value=count" strings.
+func facetSpecs(counts []*pb.FacetCount) []string {
+	var specs []string
+	for _, count := range counts {
+		specs = append(specs, fmt.Sprintf("%s=%d", count.GetValue(), count.GetCount()))
+	}
+	return specs
+}
+
+func TestFacetCounterTop(t *testing.T) {
+	counter := facetCounter{"b": 2, "a": 2, "c": 5, "d": 1}
+	tests := []struct {
+		n    int
+		want []string
+	}{
+		{10, []string{"c=5", "a=2", "b=2", "d=1"}},
+		{2, []string{"c=5", "a=2"}},
+		{0, nil},
+	}
+	for _, tt := range tests {
+		if got := facetSpecs(counter.top(tt.n)); !slices.Equal(got, tt.want) {
+			t.Errorf("top(%d) = %v, want %v", tt.n, got, tt.want)
+		}
+	}
+	if got := (facetCounter{}).top(3); len(got) != 0 {
+		t.Errorf("top() of no hits = %v, want none", got)
+	}
+}
+
+func TestSearchFacets(t *testing.T) {
+	items := []github.GitHubSearchItem{
+		linkedItem("alice/a", "main.go"),
+		linkedItem("alice/a", "cmd/tool/main.GO"),
+		linkedItem("alice/b", "cmd/b.py"),
+		linkedItem("bob/c", "docs/README.md"),
+		linkedItem("bob/c", "Makefile"),
+	}
+	s, _ := newSearchTest(t, Options{Facets: FacetConfig{MaxResults: 100}}, func(string) []github.GitHubSearchItem { return items })
+	ctx := setAuthTokenInContext(context.Background(), "owner")
+
+	tests := []struct {
+		name         string
+		req          *pb.SearchFacetsRequest
+		repositories []string
+		owners       []string
+		extensions   []string
+		languages    []string
+		directories  []string
+		analyzed     int32
+		coverage     float64
+	}{
+		{
+			name:         "every hit",
+			req:          &pb.SearchFacetsRequest{Request: &pb.SearchRequest{SearchTerm: "foo"}},
+			repositories: []string{"alice/a=2", "bob/c=2", "alice/b=1"},
+			owners:       []string{"alice=3", "bob=2"},
+			extensions:   []string{".go=2", "=1", ".md=1", ".py=1"},
+			languages:    []string{"Go=2", "Markdown=1", "Other=1", "Python=1"},
+			directories:  []string{"/=2", "cmd=2", "docs=1"},
+			analyzed:     5,
+			coverage:     1,
+		},
+		{
+			name:         "capped hits and facet size",
+			req:          &pb.SearchFacetsRequest{Request: &pb.SearchRequest{SearchTerm: "foo"}, MaxResults: 2, FacetSize: 1},
+			repositories: []string{"alice/a=2"},
+			owners:       []string{"alice=2"},
+			extensions:   []string{".go=2"},
+			languages:    []string{"Go=2"},
+			directories:  []string{"/=1"},
+			analyzed:     2,
+			coverage:     0.4,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.SearchFacets(ctx, tt.req)
+			if err != nil {
+				t.Fatalf("SearchFacets() error = %v", err)
+			}
+			for name, facet := range map[string]struct {
+				got  []*pb.FacetCount
+				want []string
+			}{
+				"repositories": {resp.GetRepositories(), tt.repositories},
+				"owners":       {resp.GetOwners(), tt.owners},
+				"extensions":   {resp.GetExtensions(), tt.extensions},
+				"languages":    {resp.GetLanguages(), tt.languages},
+				"directories":  {resp.GetDirectories(), tt.directories},
+			} {
+				if got := facetSpecs(facet.got); !slices.Equal(got, facet.want) {
+					t.Errorf("%s = %v, want %v", name, got, facet.want)
+				}
+			}
+			if resp.GetTotalCount() != 5 || resp.GetAnalyzedCount() != tt.analyzed || resp.GetCoverage() != tt.coverage {
+				t.Errorf("total %d, analyzed %d, coverage %v, want 5, %d, %v",
+					resp.GetTotalCount(), resp.GetAnalyzedCount(), resp.GetCoverage(), tt.analyzed, tt.coverage)
+			}
+		})
+	}
+}
+
+func TestSearchFacetsValidation(t *testing.T) {
+	search := &pb.SearchRequest{SearchTerm: "foo"}
+	tests := []struct {
+		name   string
+		config FacetConfig
+		req    *pb.SearchFacetsRequest
+		want   codes.Code
+	}{
+		{"disabled", FacetConfig{}, &pb.SearchFacetsRequest{Request: search}, codes.Unimplemented},
+		{"no search", FacetConfig{MaxResults: 10}, &pb.SearchFacetsRequest{}, codes.InvalidArgument},
+		{"empty search term", FacetConfig{MaxResults: 10}, &pb.SearchFacetsRequest{Request: &pb.SearchRequest{}}, codes.InvalidArgument},
+		{"negative max results", FacetConfig{MaxResults: 10}, &pb.SearchFacetsRequest{Request: search, MaxResults: -1}, codes.InvalidArgument},
+		{"facet size too large", FacetConfig{MaxResults: 10}, &pb.SearchFacetsRequest{Request: search, FacetSize: maxPerPage + 1}, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, searches := newSearchTest(t, Options{Facets: tt.config}, func(string) []github.GitHubSearchItem { return nil })
+			ctx := setAuthTokenInContext(context.Background(), "owner")
+			if _, err := s.SearchFacets(ctx, tt.req); status.Code(err) != tt.want {
+				t.Errorf("SearchFacets() error = %v, want %v", err, tt.want)
+			}
+			if searches.Load() != 0 {
+				t.Errorf("GitHub ran %d searches for a rejected request", searches.Load())
+			}
+		})
+	}
+}
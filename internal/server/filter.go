@@ -0,0 +1,209 @@
+package server
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Pratham700/github-search-service/internal/github"
+	pb "github.com/Pratham700/github-search-service/proto/proto"
+)
+
+const (
+	// defaultPerPage is the page size GitHub uses if per_page is not set.
+	defaultPerPage = 30
+	// maxFilterValues is the number of globs or repositories a filter field may hold.
+	maxFilterValues = 100
+)
+
+// resultFilter holds the result filters of a SearchRequest.
+type resultFilter struct {
+	includePaths, excludePaths []string
+	includeRepos, excludeRepos map[string]bool // Lower-case full names
+	excludeForks               bool
+	excludeArchived            bool
+}
+
+// newResultFilter returns the filter of req, or nil if it sets no filters.
+func newResultFilter(req *pb.SearchRequest) *resultFilter {
+	if len(req.GetIncludePaths()) == 0 && len(req.GetExcludePaths()) == 0 && len(req.GetIncludeRepos()) == 0 &&
+		len(req.GetExcludeRepos()) == 0 && !req.GetExcludeForks() && !req.GetExcludeArchived() {
+		return nil
+	}
+	repoSet := func(names []string) map[string]bool {
+		set := make(map[string]bool, len(names))
+		for _, name := range names {
+			set[strings.ToLower(name)] = true
+		}
+		return set
+	}
+	return &resultFilter{
+		includePaths:    req.GetIncludePaths(),
+		excludePaths:    req.GetExcludePaths(),
+		includeRepos:    repoSet(req.GetIncludeRepos()),
+		excludeRepos:    repoSet(req.GetExcludeRepos()),
+		excludeForks:    req.GetExcludeForks(),
+		excludeArchived: req.GetExcludeArchived(),
+	}
+}
+
+// validateResultFilter checks the filter fields of req.
+func validateResultFilter(req *pb.SearchRequest) error {
+	for name, values := range map[string][]string{
+		"include_paths": req.GetIncludePaths(), "exclude_paths": req.GetExcludePaths(),
+		"include_repos": req.GetIncludeRepos(), "exclude_repos": req.GetExcludeRepos(),
+	} {
+		if len(values) > maxFilterValues {
+			return status.Errorf(codes.InvalidArgument, "'%s' must hold at most %d values", name, maxFilterValues)
+		}
+		for _, value := range values {
+			if value == "" {
+				return status.Errorf(codes.InvalidArgument, "'%s' must not hold empty values", name)
+			}
+		}
+	}
+	for _, pattern := range slices.Concat(req.GetIncludePaths(), req.GetExcludePaths()) {
+		for _, segment := range strings.Split(pattern, "/") {
+			if _, err := path.Match(segment, ""); err != nil {
+				return status.Errorf(codes.InvalidArgument, "invalid path glob %q: %v", pattern, err)
+			}
+		}
+	}
+	return nil
+}
+
+// match reports whether item passes the filters other than excludeArchived,
+// which need no lookups.
+func (f *resultFilter) match(item github.GitHubSearchItem) bool {
+	repo := strings.ToLower(item.Repository.FullName)
+	switch {
+	case len(f.includeRepos) > 0 && !f.includeRepos[repo],
+		f.excludeRepos[repo],
+		f.excludeForks && item.Repository.Fork,
+		len(f.includePaths) > 0 && !matchAnyGlob(f.includePaths, item.Path),
+		matchAnyGlob(f.excludePaths, item.Path):
+		return false
+	default:
+		return true
+	}
+}
+
+// archived reports whether the repository of item was looked up and is archived.
+func (f *resultFilter) archived(lookup *repositoryLookup, item github.GitHubSearchItem) bool {
+	details := lookup.get(item.Repository.FullName)
+	return f.excludeArchived && details != nil && details.Archived
+}
+
+// validateHitsRequest checks a request whose hits are returned or compared
+// as a set, by CreateWatch, SearchFacets, CreateSnapshot, DiffSearch and
+// ExhaustiveSearch, and returns its GitHub parameters. These RPCs apply the
+// filters but keep every hit, so deduplication and ranking are rejected.
+func (s *GithubSearchServer) validateHitsRequest(req *pb.SearchRequest) (map[string]string, error) {
+	if req.GetDedup() != pb.DedupMode_DEDUP_UNSPECIFIED || req.GetRepresentative() != pb.Representative_REPRESENTATIVE_UNSPECIFIED || req.GetRanking() != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "'dedup', 'representative' and 'ranking' are only supported by Search")
+	}
+	return s.buildGitHubParams(req)
+}
+
+// filterItems returns the items that pass filter, looking up their
+// repositories if archived ones are excluded. A nil filter keeps every item.
+func filterItems(ctx context.Context, lookup *repositoryLookup, filter *resultFilter, items []github.GitHubSearchItem) []github.GitHubSearchItem {
+	if filter == nil {
+		return items
+	}
+	var matched []github.GitHubSearchItem
+	var names []string
+	for _, item := range items {
+		if filter.match(item) {
+			matched = append(matched, item)
+			names = append(names, item.Repository.FullName)
+		}
+	}
+	if !filter.excludeArchived {
+		return matched
+	}
+	lookup.lookup(ctx, names)
+	return slices.DeleteFunc(matched, func(item github.GitHubSearchItem) bool {
+		return filter.archived(lookup, item)
+	})
+}
+
+// searchFiltered runs req with GitHub's largest page size, keeping the files
+// that pass filter, until it has the requested page of filtered files or
+// GitHub has no more. Every GitHub search after the first is charged to the
+// caller's quotas.
+func (s *GithubSearchServer) searchFiltered(ctx context.Context, req *pb.SearchRequest, lookup *repositoryLookup, githubParams map[string]string, filter *resultFilter) ([]github.GitHubSearchItem, error) {
+	perPage := int(cmp.Or(req.GetPerPage(), defaultPerPage))
+	skip := (int(max(req.GetPage(), 1)) - 1) * perPage
+	params := maps.Clone(githubParams)
+	params["per_page"] = strconv.Itoa(maxPerPage)
+
+	var files []github.GitHubSearchItem
+	for page := 1; len(files) < perPage && (page-1)*maxPerPage < maxGitHubResults; page++ {
+		if s.quota != nil && page > 1 {
+			if err := s.quota.Allow(lookup.identity); err != nil {
+				return nil, err
+			}
+		}
+		params["page"] = strconv.Itoa(page)
+		result, err := s.searchCode(ctx, req, lookup.token, params)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range filterItems(ctx, lookup, filter, result.Items) {
+			if skip > 0 {
+				skip--
+				continue
+			}
+			if len(files) < perPage {
+				files = append(files, item)
+			}
+		}
+		if len(result.Items) < maxPerPage {
+			break
+		}
+	}
+	return files, nil
+}
+
+// matchAnyGlob reports whether name matches one of patterns.
+func matchAnyGlob(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if matchGlob(strings.Split(pattern, "/"), strings.Split(name, "/")) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchGlob matches the segments of a path against those of a glob. A "**"
+// segment matches any number of segments; the others are matched with
+// path.Match.
+func matchGlob(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchGlob(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
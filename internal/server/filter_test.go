@@ -0,0 +1,63 @@
+package server
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/Pratham700/github-search-service/proto/proto"
+)
+
+func TestMatchAnyGlob(t *testing.T) {
+	tests := []struct {
+		pattern, name string
+		want          bool
+	}{
+		{"*.go", "main.go", true},
+		{"*.go", "cmd/main.go", false},
+		{"cmd/*.go", "cmd/main.go", true},
+		{"**", "a/b/c.go", true},
+		{"**/*.go", "main.go", true},
+		{"**/*.go", "a/b/main.go", true},
+		{"**/*.go", "a/b/main.rs", false},
+		{"**/vendor/**", "vendor/x.go", true},
+		{"**/vendor/**", "a/vendor/b/x.go", true},
+		{"**/vendor/**", "a/vendored/x.go", false},
+		{"src/**/test", "src/test", true},
+		{"src/**/test", "src/a/b/test", true},
+		{"src/**/test", "src/a/b/test/x", false},
+		{"src/**", "src", true},
+		{"src/**", "lib/src/x", false},
+		{"**/testdata/*", "pkg/testdata/in.txt", true},
+		{"**/testdata/*", "pkg/testdata/sub/in.txt", false},
+		{"a/?.go", "a/b.go", true},
+		{"[", "[", false},
+	}
+	for _, tt := range tests {
+		if got := matchAnyGlob([]string{tt.pattern}, tt.name); got != tt.want {
+			t.Errorf("matchAnyGlob(%q, %q) = %v, want %v", tt.pattern, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestValidateResultFilter(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *pb.SearchRequest
+		want codes.Code
+	}{
+		{"no filters", &pb.SearchRequest{}, codes.OK},
+		{"globs", &pb.SearchRequest{IncludePaths: []string{"**/*.go"}, ExcludePaths: []string{"**/vendor/**"}}, codes.OK},
+		{"malformed glob", &pb.SearchRequest{ExcludePaths: []string{"a/[b"}}, codes.InvalidArgument},
+		{"empty value", &pb.SearchRequest{IncludeRepos: []string{""}}, codes.InvalidArgument},
+		{"too many values", &pb.SearchRequest{ExcludeRepos: make([]string, maxFilterValues+1)}, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := status.Code(validateResultFilter(tt.req)); got != tt.want {
+				t.Errorf("validateResultFilter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/Pratham700/github-search-service/internal/github"
+	pb "github.com/Pratham700/github-search-service/proto/proto"
+)
+
+// HealthChecker drives the status reported by the grpc.health.v1 service from
+// the readiness of the server: the listener is up, the configuration is loaded
+// and the GitHub host answers a periodic /rate_limit probe with its circuit
+// breaker closed.
+type HealthChecker struct {
+	health       *health.Server
+	githubServer *GithubSearchServer
+	token        atomic.Pointer[string]
+	timeout      time.Duration
+
+	mu           sync.Mutex
+	listening    bool
+	configLoaded bool
+	probeErr     error
+}
+
+// NewHealthChecker creates a HealthChecker that reports NOT_SERVING until it is ready.
+// The token is optional and only used to authenticate the probe.
+func NewHealthChecker(githubServer *GithubSearchServer, token string, timeout time.Duration) *HealthChecker {
+	h := &HealthChecker{
+		health:       health.NewServer(),
+		githubServer: githubServer,
+		timeout:      timeout,
+		probeErr:     errNotProbed,
+	}
+	h.token.Store(&token)
+	h.update()
+	return h
+}
+
+var errNotProbed = errors.New("GitHub has not been probed yet")
+
+// Server returns the grpc.health.v1 implementation to register on the gRPC server.
+func (h *HealthChecker) Server() healthpb.HealthServer {
+	return h.health
+}
+
+// SetListening records whether the gRPC listener is accepting connections.
+func (h *HealthChecker) SetListening(listening bool) {
+	h.mu.Lock()
+	h.listening = listening
+	h.mu.Unlock()
+	h.update()
+}
+
+// SetConfigLoaded records whether a valid configuration is active.
+func (h *HealthChecker) SetConfigLoaded(loaded bool) {
+	h.mu.Lock()
+	h.configLoaded = loaded
+	h.mu.Unlock()
+	h.update()
+}
+
+// SetToken replaces the token used to authenticate the probe.
+func (h *HealthChecker) SetToken(token string) {
+	h.token.Store(&token)
+}
+
+// Run probes GitHub immediately and then every interval until ctx is done.
+func (h *HealthChecker) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		h.probe(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Shutdown reports NOT_SERVING from now on, so load balancers drain the
+// server before it stops.
+func (h *HealthChecker) Shutdown() {
+	h.health.Shutdown()
+}
+
+func (h *HealthChecker) probe(ctx context.Context) {
+	client := h.githubServer.GitHubClient()
+	probeCtx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	err := client.CheckRateLimit(probeCtx, *h.token.Load())
+	if err == nil {
+		for host, state := range client.BreakerStates() {
+			if state == github.BreakerOpen {
+				err = fmt.Errorf("circuit breaker for %s is open", host)
+			}
+		}
+	}
+
+	h.mu.Lock()
+	if (err == nil) != (h.probeErr == nil) {
+		if err != nil {
+			slog.Warn("GitHub probe failed", "base_url", client.BaseURL(), "error", err)
+		} else {
+			slog.Info("GitHub probe succeeded", "base_url", client.BaseURL())
+		}
+	}
+	h.probeErr = err
+	h.mu.Unlock()
+	h.update()
+}
+
+// update publishes the combined status for the whole server and the search service.
+func (h *HealthChecker) update() {
+	h.mu.Lock()
+	serving := h.listening && h.configLoaded && h.probeErr == nil
+	h.mu.Unlock()
+
+	status := healthpb.HealthCheckResponse_NOT_SERVING
+	if serving {
+		status = healthpb.HealthCheckResponse_SERVING
+	}
+	h.health.SetServingStatus("", status)
+	h.health.SetServingStatus(pb.GithubSearchService_ServiceDesc.ServiceName, status)
+}
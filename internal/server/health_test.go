@@ -0,0 +1,106 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/Pratham700/github-search-service/internal/github"
+	pb "github.com/Pratham700/github-search-service/proto/proto"
+)
+
+// newHealthTest returns a HealthChecker probing a fake GitHub that answers
+// /rate_limit with the status stored in code.
+func newHealthTest(t *testing.T, breaker github.BreakerConfig) (*HealthChecker, *atomic.Int32, *atomic.Pointer[string]) {
+	t.Helper()
+	var code atomic.Int32
+	code.Store(http.StatusOK)
+	var authorization atomic.Pointer[string]
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get("Authorization")
+		authorization.Store(&header)
+		w.WriteHeader(int(code.Load()))
+	}))
+	t.Cleanup(ts.Close)
+	client := github.NewGitHubClient(github.ClientConfig{BaseURL: ts.URL, Timeout: time.Second, Breaker: breaker})
+	s, err := NewGithubSearchServer(client, Options{})
+	if err != nil {
+		t.Fatalf("NewGithubSearchServer() error = %v", err)
+	}
+	return NewHealthChecker(s, "probe-token", time.Second), &code, &authorization
+}
+
+// checkHealth fails t unless the server and the search service both report want.
+func checkHealth(t *testing.T, h *HealthChecker, want healthpb.HealthCheckResponse_ServingStatus) {
+	t.Helper()
+	for _, service := range []string{"", pb.GithubSearchService_ServiceDesc.ServiceName} {
+		resp, err := h.Server().Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		if err != nil {
+			t.Fatalf("Check(%q) error = %v", service, err)
+		}
+		if resp.GetStatus() != want {
+			t.Errorf("Check(%q) = %v, want %v", service, resp.GetStatus(), want)
+		}
+	}
+}
+
+func TestHealthCheckerTransitions(t *testing.T) {
+	h, code, _ := newHealthTest(t, github.BreakerConfig{})
+	const (
+		serving    = healthpb.HealthCheckResponse_SERVING
+		notServing = healthpb.HealthCheckResponse_NOT_SERVING
+	)
+	steps := []struct {
+		name   string
+		change func()
+		want   healthpb.HealthCheckResponse_ServingStatus
+	}{
+		{"initial", func() {}, notServing},
+		{"listening", func() { h.SetListening(true) }, notServing},
+		{"config loaded before a probe", func() { h.SetConfigLoaded(true) }, notServing},
+		{"probe succeeded", func() { h.probe(context.Background()) }, serving},
+		{"probe failed", func() { code.Store(http.StatusBadGateway); h.probe(context.Background()) }, notServing},
+		{"probe recovered", func() { code.Store(http.StatusOK); h.probe(context.Background()) }, serving},
+		{"listener closed", func() { h.SetListening(false) }, notServing},
+		{"listener reopened", func() { h.SetListening(true) }, serving},
+		{"config invalid", func() { h.SetConfigLoaded(false) }, notServing},
+		{"config reloaded", func() { h.SetConfigLoaded(true) }, serving},
+		{"shutdown", func() { h.Shutdown() }, notServing},
+		{"probe after shutdown", func() { h.probe(context.Background()) }, notServing},
+	}
+	for _, step := range steps {
+		step.change()
+		t.Run(step.name, func(t *testing.T) { checkHealth(t, h, step.want) })
+	}
+}
+
+func TestHealthCheckerBreakerOpen(t *testing.T) {
+	h, code, _ := newHealthTest(t, github.BreakerConfig{FailureRatio: 0.5, MinRequests: 1, Window: time.Minute, CoolDown: time.Hour})
+	h.SetListening(true)
+	h.SetConfigLoaded(true)
+
+	code.Store(http.StatusInternalServerError)
+	h.probe(context.Background())
+	// GitHub recovers, but the breaker stays open for its cool-down
+	code.Store(http.StatusOK)
+	h.probe(context.Background())
+	checkHealth(t, h, healthpb.HealthCheckResponse_NOT_SERVING)
+}
+
+func TestHealthCheckerToken(t *testing.T) {
+	h, _, authorization := newHealthTest(t, github.BreakerConfig{})
+	h.probe(context.Background())
+	if got := *authorization.Load(); got != "Bearer probe-token" {
+		t.Errorf("Authorization = %q, want the probe token", got)
+	}
+	h.SetToken("")
+	h.probe(context.Background())
+	if got := *authorization.Load(); got != "" {
+		t.Errorf("Authorization = %q, want none after the token was removed", got)
+	}
+}
@@ -0,0 +1,179 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/Pratham700/github-search-service/internal/store"
+	pb "github.com/Pratham700/github-search-service/proto/proto"
+)
+
+const (
+	defaultHistoryPageSize = 20
+	// historyQueueSize is the number of searches that may wait to be written
+	// to the history; searches beyond it are not recorded.
+	historyQueueSize = 1024
+)
+
+// SearchHistory keeps the recent searches of each caller in the store.
+// Searches are written in the background, so that recording them adds no
+// storage latency to the search.
+type SearchHistory struct {
+	store     *store.Store
+	retention store.HistoryRetention
+
+	queue     chan store.HistoryEntry
+	closeOnce sync.Once
+	done      chan struct{} // Closed by Close
+	flushed   chan struct{} // Closed once the queue is written after Close
+}
+
+// NewSearchHistory creates a SearchHistory keeping searches within retention
+// and starts writing the searches it records. Call Close to stop it.
+func NewSearchHistory(st *store.Store, retention store.HistoryRetention) *SearchHistory {
+	h := &SearchHistory{
+		store:     st,
+		retention: retention,
+		queue:     make(chan store.HistoryEntry, historyQueueSize),
+		done:      make(chan struct{}),
+		flushed:   make(chan struct{}),
+	}
+	go h.write()
+	return h
+}
+
+// Record queues a completed search for the history of identity. It never
+// blocks: if the queue is full the search is dropped and a warning logged.
+func (h *SearchHistory) Record(ctx context.Context, identity string, req *pb.SearchRequest, resultCount int) {
+	entry := store.HistoryEntry{
+		Identity: identity,
+		Search: &pb.RecentSearch{
+			Request:     proto.Clone(req).(*pb.SearchRequest),
+			Time:        timestamppb.Now(),
+			ResultCount: int32(resultCount),
+		},
+	}
+	select {
+	case h.queue <- entry:
+	default:
+		slog.WarnContext(ctx, "Search history queue is full, not recording search")
+	}
+}
+
+// Close writes the searches still queued and stops writing. Searches
+// recorded afterwards are dropped.
+func (h *SearchHistory) Close() {
+	h.closeOnce.Do(func() { close(h.done) })
+	<-h.flushed
+}
+
+// write writes queued searches until Close. The searches queued while a
+// write is in progress are written together in the next transaction, so
+// concurrent searches share a single commit.
+func (h *SearchHistory) write() {
+	defer close(h.flushed)
+	for {
+		var entries []store.HistoryEntry
+		closed := false
+		select {
+		case entry := <-h.queue:
+			entries = append(entries, entry)
+		case <-h.done:
+			closed = true
+		}
+	drain:
+		for len(entries) < historyQueueSize {
+			select {
+			case entry := <-h.queue:
+				entries = append(entries, entry)
+			default:
+				break drain
+			}
+		}
+		if len(entries) > 0 {
+			if err := h.store.AddSearches(entries, h.retention); err != nil {
+				slog.Error("Failed to record search history", "searches", len(entries), "error", err)
+			}
+		}
+		if closed {
+			return
+		}
+	}
+}
+
+// Run drops expired searches of every caller every interval until ctx is
+// done, so that the history of inactive callers expires too.
+func (h *SearchHistory) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := h.store.PruneHistory(h.retention); err != nil {
+			slog.Error("Failed to prune search history", "error", err)
+		}
+	}
+}
+
+// ListRecentSearches implements the ListRecentSearches gRPC method.
+func (s *GithubSearchServer) ListRecentSearches(ctx context.Context, req *pb.ListRecentSearchesRequest) (*pb.ListRecentSearchesResponse, error) {
+	identity, err := GetCallerIdentityFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if s.history == nil {
+		return nil, status.Errorf(codes.Unimplemented, "search history is disabled on this server")
+	}
+
+	pageSize := int(req.GetPageSize())
+	if pageSize < 0 || pageSize > maxPerPage {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid value for 'page_size': must be between 0 and %d", maxPerPage)
+	}
+	if pageSize == 0 {
+		pageSize = defaultHistoryPageSize
+	}
+
+	searches, nextPageToken, err := s.history.store.ListSearches(identity, pageSize, req.GetPageToken())
+	if errors.Is(err, store.ErrInvalidPageToken) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid value for 'page_token'")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list search history: %v", err)
+	}
+	return &pb.ListRecentSearchesResponse{
+		Searches:      searches,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+// DeleteSearchHistory implements the DeleteSearchHistory gRPC method.
+func (s *GithubSearchServer) DeleteSearchHistory(ctx context.Context, req *pb.DeleteSearchHistoryRequest) (*pb.DeleteSearchHistoryResponse, error) {
+	identity, err := GetCallerIdentityFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if s.history == nil {
+		return nil, status.Errorf(codes.Unimplemented, "search history is disabled on this server")
+	}
+
+	var before time.Time
+	if req.GetBefore() != nil {
+		before = req.GetBefore().AsTime()
+	}
+	deleted, err := s.history.store.DeleteSearches(identity, before)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete search history: %v", err)
+	}
+	return &pb.DeleteSearchHistoryResponse{DeletedCount: int32(deleted)}, nil
+}
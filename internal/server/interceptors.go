@@ -0,0 +1,35 @@
+package server
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// ChainUnaryInterceptors combines interceptors into one that runs them in
+// order, the first being the outermost. The result can be installed on the
+// gRPC server and reused by other transports, such as the HTTP gateway, that
+// call the service in process.
+func ChainUnaryInterceptors(interceptors ...grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		chained := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, next := interceptors[i], chained
+			chained = func(ctx context.Context, req interface{}) (interface{}, error) {
+				return interceptor(ctx, req, info, next)
+			}
+		}
+		return chained(ctx, req)
+	}
+}
+
+// contextStream overrides the context of a ServerStream, so that stream
+// interceptors can pass values to the handler like unary interceptors do.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,116 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/Pratham700/github-search-service/internal/logging"
+	"github.com/Pratham700/github-search-service/internal/util"
+)
+
+// LoggingInterceptor assigns every RPC a request ID, taken from the
+// x-request-id metadata or generated, echoes it in the response headers and
+// logs the caller identity, latency and outcome once the call completes.
+// Calls to public services are logged at debug level.
+func LoggingInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	ctx, finish := startRPCLog(ctx, info.FullMethod)
+	resp, err := handler(ctx, req)
+	finish(err)
+	return resp, err
+}
+
+// LoggingStreamInterceptor is the streaming counterpart of LoggingInterceptor;
+// the call is logged when the stream ends.
+func LoggingStreamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	ctx, finish := startRPCLog(ss.Context(), info.FullMethod)
+	err := handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	finish(err)
+	return err
+}
+
+// startRPCLog assigns the call a request ID and returns the context carrying
+// it, along with a function that logs the outcome of the call.
+func startRPCLog(ctx context.Context, fullMethod string) (context.Context, func(error)) {
+	requestID := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(logging.RequestIDHeader); len(values) > 0 && logging.ValidRequestID(values[0]) {
+			requestID = values[0]
+		}
+	}
+	if requestID == "" {
+		requestID = logging.NewRequestID()
+	}
+	// Fails only outside a gRPC transport, e.g. for gateway calls, which echo the ID themselves
+	_ = grpc.SetHeader(ctx, metadata.Pairs(logging.RequestIDHeader, requestID))
+	ctx = logging.WithRequestID(ctx, requestID)
+
+	start := time.Now()
+	return ctx, func(err error) {
+		code := rpcCode(err)
+		level := outcomeLevel(code)
+		if isPublicMethod(fullMethod) && code == codes.OK {
+			level = slog.LevelDebug
+		}
+		attrs := []slog.Attr{
+			slog.String("method", fullMethod),
+			slog.String(logging.IdentityKey, identityFromIncomingContext(ctx)),
+			slog.String("code", code.String()),
+			slog.Duration("latency", time.Since(start)),
+		}
+		if err != nil {
+			attrs = append(attrs, slog.String("error", status.Convert(err).Message()))
+		}
+		slog.LogAttrs(ctx, level, "RPC finished", attrs...)
+	}
+}
+
+// rpcCode returns the code gRPC reports to the client for an error returned
+// by a handler: context errors that are not statuses become Canceled or
+// DeadlineExceeded rather than Unknown.
+func rpcCode(err error) codes.Code {
+	if st, ok := status.FromError(err); ok {
+		return st.Code()
+	}
+	return status.FromContextError(err).Code()
+}
+
+func outcomeLevel(code codes.Code) slog.Level {
+	switch code {
+	case codes.OK:
+		return slog.LevelInfo
+	case codes.Unknown, codes.Internal, codes.Unavailable, codes.DataLoss, codes.DeadlineExceeded, codes.Unimplemented:
+		return slog.LevelError
+	default:
+		return slog.LevelWarn
+	}
+}
+
+// identityFromIncomingContext identifies the caller before authentication has
+// run, so that rejected calls can be attributed too.
+func identityFromIncomingContext(ctx context.Context) string {
+	if identity, ok := GetClientCertIdentityFromContext(ctx); ok {
+		return identity
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if tokens := md.Get("github-token"); len(tokens) > 0 {
+			return "token:" + util.HashToken(tokens[0])
+		}
+	}
+	return "anonymous"
+}
@@ -0,0 +1,96 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/Pratham700/github-search-service/internal/logging"
+	"github.com/Pratham700/github-search-service/internal/util"
+)
+
+// captureLogs routes the default logger to a JSON buffer for the duration of the test.
+func captureLogs(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(logging.NewHandler(&buf, "json", slog.LevelDebug)))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+	return &buf
+}
+
+func TestLoggingInterceptor(t *testing.T) {
+	const method = "/githubsearchservice.GithubSearchService/Search"
+	tests := []struct {
+		name      string
+		method    string
+		md        metadata.MD
+		err       error
+		wantCode  string
+		wantLevel string
+		wantID    string
+		wantUser  string
+	}{
+		{
+			name: "ok", method: method, md: metadata.Pairs("github-token", "t", logging.RequestIDHeader, "req-1"),
+			wantCode: "OK", wantLevel: "INFO", wantID: "req-1", wantUser: "token:" + util.HashToken("t"),
+		},
+		{
+			name: "invalid request id", method: method, md: metadata.Pairs(logging.RequestIDHeader, "bad id"),
+			wantCode: "OK", wantLevel: "INFO", wantUser: "anonymous",
+		},
+		{
+			name: "client error", method: method, err: status.Error(codes.InvalidArgument, "bad"),
+			wantCode: "InvalidArgument", wantLevel: "WARN", wantUser: "anonymous",
+		},
+		{
+			name: "server error", method: method, err: errors.New("broken"),
+			wantCode: "Unknown", wantLevel: "ERROR", wantUser: "anonymous",
+		},
+		{
+			name: "cancelled", method: method, err: context.Canceled,
+			wantCode: "Canceled", wantLevel: "WARN", wantUser: "anonymous",
+		},
+		{
+			name: "public service", method: "/grpc.health.v1.Health/Check",
+			wantCode: "OK", wantLevel: "DEBUG", wantUser: "anonymous",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := captureLogs(t)
+			ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+			var handlerID string
+			_, err := LoggingInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, func(ctx context.Context, _ interface{}) (interface{}, error) {
+				handlerID, _ = logging.RequestIDFromContext(ctx)
+				return nil, tt.err
+			})
+			if err != tt.err {
+				t.Errorf("error = %v, want %v", err, tt.err)
+			}
+
+			var record map[string]any
+			if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+				t.Fatalf("invalid log line %q: %v", buf, err)
+			}
+			if record["code"] != tt.wantCode || record["level"] != tt.wantLevel || record[logging.IdentityKey] != tt.wantUser {
+				t.Errorf("logged code %v, level %v, identity %v, want %s, %s, %s",
+					record["code"], record["level"], record[logging.IdentityKey], tt.wantCode, tt.wantLevel, tt.wantUser)
+			}
+			if !logging.ValidRequestID(handlerID) || record["request_id"] != handlerID {
+				t.Errorf("request ID = %q, logged %v", handlerID, record["request_id"])
+			}
+			if tt.wantID != "" && handlerID != tt.wantID {
+				t.Errorf("request ID = %q, want %q", handlerID, tt.wantID)
+			}
+		})
+	}
+}
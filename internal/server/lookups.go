@@ -0,0 +1,164 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Pratham700/github-search-service/internal/github"
+	"github.com/Pratham700/github-search-service/internal/util"
+)
+
+const (
+	// maxRepositoryLookups is the number of repositories looked up from
+	// GitHub per call; the others are treated as failed lookups.
+	maxRepositoryLookups = 100
+	// repositoryLookupConcurrency is the number of repositories looked up at the same time.
+	repositoryLookupConcurrency = 8
+	// lookupCacheTTL and lookupCacheMaxSize are the defaults of CacheConfig.
+	lookupCacheTTL     = 5 * time.Minute
+	lookupCacheMaxSize = 10000
+)
+
+// CacheConfig bounds the caches of what is looked up from GitHub. Zero
+// values use the defaults.
+type CacheConfig struct {
+	// TTL is how long looked up repositories and callers' organizations are
+	// cached, 5 minutes by default.
+	TTL time.Duration
+	// LoginTTL is how long the Auditor caches the login behind a token, an
+	// hour by default.
+	LoginTTL time.Duration
+	// MaxEntries bounds each cache, 10000 entries by default.
+	MaxEntries int
+}
+
+// repositoryLookup looks up repositories with the caller's token for the
+// filters, deduplication and ranking of one call, each repository once.
+// Repositories are cached per token for CacheConfig.TTL, since what a token
+// can see differs between callers; lookups that miss the cache are charged
+// to the caller's quotas, up to maxRepositoryLookups per call.
+type repositoryLookup struct {
+	server          *GithubSearchServer
+	token, identity string
+
+	mu        sync.Mutex
+	remaining int
+	// repos holds the repositories by full name, nil if the lookup failed or was skipped.
+	repos map[string]*github.GitHubRepository
+}
+
+func (s *GithubSearchServer) newRepositoryLookup(token, identity string) *repositoryLookup {
+	return &repositoryLookup{
+		server:    s,
+		token:     token,
+		identity:  identity,
+		remaining: maxRepositoryLookups,
+		repos:     make(map[string]*github.GitHubRepository),
+	}
+}
+
+// get returns the repository with the given name, or nil if it was not
+// looked up or the lookup failed.
+func (l *repositoryLookup) get(name string) *github.GitHubRepository {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.repos[name]
+}
+
+// lookup fetches the repositories with the given names that were not looked
+// up yet. Failed lookups, and those over the limit or the caller's quotas,
+// are logged and skipped.
+func (l *repositoryLookup) lookup(ctx context.Context, names []string) {
+	var pending []string
+	skipped := 0
+	l.mu.Lock()
+	for _, name := range names {
+		if _, ok := l.repos[name]; ok {
+			continue
+		}
+		if repo, ok := l.server.repositories.get(l.cacheKey(name)); ok {
+			l.repos[name] = repo
+			continue
+		}
+		l.repos[name] = nil
+		if l.remaining == 0 {
+			skipped++
+			continue
+		}
+		if l.server.quota != nil {
+			if err := l.server.quota.Allow(l.identity); err != nil {
+				l.remaining = 0
+				skipped++
+				continue
+			}
+		}
+		l.remaining--
+		pending = append(pending, name)
+	}
+	l.mu.Unlock()
+	if skipped > 0 {
+		slog.WarnContext(ctx, "Skipped repository lookups over the per-call limit or the caller's quota", "skipped", skipped)
+	}
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, repositoryLookupConcurrency)
+	for _, name := range pending {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			repo, err := l.server.GitHubClient().GetRepository(ctx, name, l.token)
+			if err != nil {
+				slog.WarnContext(ctx, "Failed to look up repository", "repo", name, "error", err)
+				return
+			}
+			l.server.repositories.put(l.cacheKey(name), repo)
+			l.mu.Lock()
+			l.repos[name] = repo
+			l.mu.Unlock()
+		}()
+	}
+	wg.Wait()
+}
+
+func (l *repositoryLookup) cacheKey(name string) string {
+	return util.HashToken(l.token) + "\x00" + strings.ToLower(name)
+}
+
+// callerOwners returns the lower-case logins of the owner of token and of
+// its organizations. They are cached per token for CacheConfig.TTL, and the
+// lookups that miss the cache are charged to the quotas of identity. A
+// failed lookup is logged and leaves the owners it could not find out.
+func (s *GithubSearchServer) callerOwners(ctx context.Context, token, identity string) map[string]bool {
+	key := util.HashToken(token)
+	if owners, ok := s.owners.get(key); ok {
+		return owners
+	}
+	owners := make(map[string]bool)
+	if s.quota != nil {
+		if err := s.quota.Allow(identity); err != nil {
+			slog.WarnContext(ctx, "Skipped looking up the caller's login and organizations over the caller's quota")
+			return owners
+		}
+	}
+	login, err := s.GitHubClient().GetLogin(ctx, token)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to look up the caller's login", "error", err)
+		return owners
+	}
+	owners[strings.ToLower(login)] = true
+	orgs, err := s.GitHubClient().GetOrganizations(ctx, token)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to look up the caller's organizations", "error", err)
+		return owners
+	}
+	for _, org := range orgs {
+		owners[strings.ToLower(org)] = true
+	}
+	s.owners.put(key, owners)
+	return owners
+}
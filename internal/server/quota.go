@@ -0,0 +1,287 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/Pratham700/github-search-service/proto/proto"
+)
+
+const (
+	resultsWindow = 24 * time.Hour
+	sweepInterval = time.Minute
+)
+
+// QuotaConfig holds the per-caller limits enforced by QuotaLimiter.
+// A zero value for either limit disables that quota.
+type QuotaConfig struct {
+	RequestsPerMinute int
+	ResultsPerDay     int
+}
+
+// tokenBucket is a classic token bucket refilled continuously at rate tokens per second.
+type tokenBucket struct {
+	capacity float64
+	rate     float64
+	tokens   float64
+	last     time.Time
+}
+
+func newTokenBucket(capacity int, window time.Duration, now time.Time) *tokenBucket {
+	return &tokenBucket{
+		capacity: float64(capacity),
+		rate:     float64(capacity) / window.Seconds(),
+		tokens:   float64(capacity),
+		last:     now,
+	}
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(b.capacity, b.tokens+elapsed*b.rate)
+		b.last = now
+	}
+}
+
+// take removes n tokens, never letting the bucket go below zero.
+func (b *tokenBucket) take(n float64) {
+	b.tokens = math.Max(0, b.tokens-n)
+}
+
+// fullAt returns the time at which the bucket will be full again.
+func (b *tokenBucket) fullAt() time.Time {
+	missing := b.capacity - b.tokens
+	return b.last.Add(time.Duration(missing / b.rate * float64(time.Second)))
+}
+
+// callerQuota tracks the buckets of a single caller. Nil buckets are not enforced.
+type callerQuota struct {
+	requests *tokenBucket
+	results  *tokenBucket
+}
+
+// QuotaLimiter enforces per-caller request and result quotas.
+type QuotaLimiter struct {
+	mu        sync.Mutex
+	config    QuotaConfig
+	callers   map[string]*callerQuota
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// QuotaStatus is a snapshot of a caller's remaining budget.
+type QuotaStatus struct {
+	RequestsPerMinute int
+	RequestsRemaining int
+	ResultsPerDay     int
+	ResultsRemaining  int
+	ResultsResetTime  time.Time
+}
+
+// NewQuotaLimiter creates a new QuotaLimiter with the given limits.
+func NewQuotaLimiter(config QuotaConfig) *QuotaLimiter {
+	return &QuotaLimiter{
+		config:  config,
+		callers: make(map[string]*callerQuota),
+		now:     time.Now,
+	}
+}
+
+// SetConfig replaces the limits. Callers keep their consumed budget, clamped to
+// the new capacity; a quota that becomes enabled starts out full.
+func (l *QuotaLimiter) SetConfig(config QuotaConfig) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	for _, q := range l.callers {
+		q.requests = resizeBucket(q.requests, config.RequestsPerMinute, time.Minute, now)
+		q.results = resizeBucket(q.results, config.ResultsPerDay, resultsWindow, now)
+	}
+	l.config = config
+}
+
+func resizeBucket(b *tokenBucket, capacity int, window time.Duration, now time.Time) *tokenBucket {
+	if capacity <= 0 {
+		return nil
+	}
+	if b == nil {
+		return newTokenBucket(capacity, window, now)
+	}
+	b.refill(now)
+	resized := newTokenBucket(capacity, window, now)
+	resized.tokens = math.Min(resized.capacity, b.tokens)
+	return resized
+}
+
+// caller returns the refilled quota state for the identity, creating it on first use.
+// The caller must hold l.mu.
+func (l *QuotaLimiter) caller(identity string, now time.Time) *callerQuota {
+	l.sweep(now)
+	q, ok := l.callers[identity]
+	if !ok {
+		q = &callerQuota{}
+		if l.config.RequestsPerMinute > 0 {
+			q.requests = newTokenBucket(l.config.RequestsPerMinute, time.Minute, now)
+		}
+		if l.config.ResultsPerDay > 0 {
+			q.results = newTokenBucket(l.config.ResultsPerDay, resultsWindow, now)
+		}
+		l.callers[identity] = q
+	}
+	if q.requests != nil {
+		q.requests.refill(now)
+	}
+	if q.results != nil {
+		q.results.refill(now)
+	}
+	return q
+}
+
+// sweep drops callers whose buckets have fully replenished, since they are
+// indistinguishable from new callers. The caller must hold l.mu.
+func (l *QuotaLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for identity, q := range l.callers {
+		if (q.requests == nil || !now.Before(q.requests.fullAt())) &&
+			(q.results == nil || !now.Before(q.results.fullAt())) {
+			delete(l.callers, identity)
+		}
+	}
+}
+
+// Allow consumes one request from the caller's budget. It returns a
+// ResourceExhausted status carrying QuotaFailure details when a quota is exceeded.
+func (l *QuotaLimiter) Allow(identity string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	q := l.caller(identity, l.now())
+	if q.results != nil && q.results.tokens < 1 {
+		return quotaExceededError(identity, "results_per_day",
+			fmt.Sprintf("daily result quota of %d exhausted", l.config.ResultsPerDay))
+	}
+	if q.requests != nil {
+		if q.requests.tokens < 1 {
+			return quotaExceededError(identity, "requests_per_minute",
+				fmt.Sprintf("request quota of %d per minute exceeded", l.config.RequestsPerMinute))
+		}
+		q.requests.take(1)
+	}
+	return nil
+}
+
+// RecordResults charges n returned results against the caller's daily budget.
+func (l *QuotaLimiter) RecordResults(identity string, n int) {
+	if n <= 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if q := l.caller(identity, l.now()); q.results != nil {
+		q.results.take(float64(n))
+	}
+}
+
+// Status returns the caller's remaining budget without consuming any of it.
+func (l *QuotaLimiter) Status(identity string) QuotaStatus {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	q := l.caller(identity, now)
+	st := QuotaStatus{
+		RequestsPerMinute: l.config.RequestsPerMinute,
+		ResultsPerDay:     l.config.ResultsPerDay,
+		ResultsResetTime:  now,
+	}
+	if q.requests != nil {
+		st.RequestsRemaining = int(q.requests.tokens)
+	}
+	if q.results != nil {
+		st.ResultsRemaining = int(q.results.tokens)
+		st.ResultsResetTime = q.results.fullAt()
+	}
+	return st
+}
+
+// resultCounter is implemented by responses that carry search results.
+type resultCounter interface {
+	GetResults() []*pb.Result
+}
+
+// UnaryInterceptor enforces the caller's quotas. It must run after AuthInterceptor
+// so that the caller identity is available in the context.
+func (l *QuotaLimiter) UnaryInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	// Inspecting the quota must not consume it
+	if info.FullMethod == pb.GithubSearchService_GetQuota_FullMethodName || isPublicMethod(info.FullMethod) {
+		return handler(ctx, req)
+	}
+
+	identity, err := GetCallerIdentityFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := l.Allow(identity); err != nil {
+		return nil, err
+	}
+
+	resp, err := handler(ctx, req)
+	if rc, ok := resp.(resultCounter); ok && err == nil {
+		l.RecordResults(identity, len(rc.GetResults()))
+	}
+	return resp, err
+}
+
+// StreamInterceptor is the streaming counterpart of UnaryInterceptor. Opening
+// a stream counts as one request; the results it sends are not counted.
+func (l *QuotaLimiter) StreamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	if isPublicMethod(info.FullMethod) {
+		return handler(srv, ss)
+	}
+
+	identity, err := GetCallerIdentityFromContext(ss.Context())
+	if err != nil {
+		return err
+	}
+	if err := l.Allow(identity); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+func quotaExceededError(identity, quota, description string) error {
+	st := status.New(codes.ResourceExhausted, description)
+	detailed, err := st.WithDetails(&errdetails.QuotaFailure{
+		Violations: []*errdetails.QuotaFailure_Violation{{
+			Subject:     fmt.Sprintf("caller:%s/%s", identity, quota),
+			Description: description,
+		}},
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
@@ -0,0 +1,77 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestTokenBucketRefill(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	tests := []struct {
+		name    string
+		take    float64
+		elapsed time.Duration
+		want    float64
+	}{
+		{"full stays full", 0, time.Minute, 60},
+		{"refills at rate", 30, 10 * time.Second, 40},
+		{"refills to capacity", 30, 45 * time.Second, 60},
+		{"empty refills", 60, 30 * time.Second, 30},
+		{"take stops at zero", 100, 0, 0},
+		{"clock going back is ignored", 30, -time.Minute, 30},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newTokenBucket(60, time.Minute, start)
+			b.take(tt.take)
+			b.refill(start.Add(tt.elapsed))
+			if b.tokens != tt.want {
+				t.Errorf("tokens = %v, want %v", b.tokens, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenBucketFullAt(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	b := newTokenBucket(60, time.Minute, start)
+	b.take(15)
+	if got, want := b.fullAt(), start.Add(15*time.Second); !got.Equal(want) {
+		t.Errorf("fullAt() = %v, want %v", got, want)
+	}
+}
+
+func TestQuotaLimiterAllow(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	l := NewQuotaLimiter(QuotaConfig{RequestsPerMinute: 2, ResultsPerDay: 10})
+	l.now = func() time.Time { return now }
+
+	steps := []struct {
+		name    string
+		advance time.Duration
+		results int
+		want    codes.Code
+	}{
+		{"first request", 0, 0, codes.OK},
+		{"second request", 0, 0, codes.OK},
+		{"third request within the minute", 0, 0, codes.ResourceExhausted},
+		{"one token back after half a minute", 30 * time.Second, 0, codes.OK},
+		{"bucket empty again", 0, 0, codes.ResourceExhausted},
+		{"results charged", time.Minute, 10, codes.OK},
+		{"daily results exhausted", time.Minute, 0, codes.ResourceExhausted},
+		{"results refilled", 12 * time.Hour, 0, codes.OK},
+	}
+	for _, s := range steps {
+		now = now.Add(s.advance)
+		if got := status.Code(l.Allow("caller")); got != s.want {
+			t.Fatalf("%s: Allow() = %v, want %v", s.name, got, s.want)
+		}
+		l.RecordResults("caller", s.results)
+	}
+	if got := status.Code(l.Allow("other caller")); got != codes.OK {
+		t.Errorf("Allow() of another caller = %v, want OK", got)
+	}
+}
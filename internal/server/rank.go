@@ -0,0 +1,154 @@
+package server
+
+import (
+	"cmp"
+	"context"
+	"math"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Pratham700/github-search-service/internal/github"
+	pb "github.com/Pratham700/github-search-service/proto/proto"
+)
+
+const (
+	// recencyHalfLife is the time after which the recency score of a repository halves.
+	recencyHalfLife = 90 * 24 * time.Hour
+	// maxScoredStars and maxScoredTextMatches are the numbers from which the
+	// stars and text match scorers rate a result 1.
+	maxScoredStars       = 10000
+	maxScoredTextMatches = 10
+)
+
+// testDirectories are directory names that usually hold tests or test data.
+var testDirectories = []string{"test", "tests", "__tests__", "testdata", "spec", "specs", "fixtures"}
+
+// resultScorer rates a file between 0 and 1.
+type resultScorer struct {
+	weight float64
+	score  func(github.GitHubSearchItem) float64
+}
+
+// resultRanker scores search results by the weighted sum of its scorers.
+type resultRanker struct {
+	scorers []resultScorer
+}
+
+// validateRanking checks the ranking weights of req.
+func validateRanking(req *pb.SearchRequest) error {
+	weights := req.GetRanking()
+	for _, weight := range []float64{weights.GetPathDepth(), weights.GetNonTest(), weights.GetStars(),
+		weights.GetRecency(), weights.GetCallerOwned(), weights.GetTextMatches()} {
+		if weight < 0 || math.IsNaN(weight) || math.IsInf(weight, 0) {
+			return status.Errorf(codes.InvalidArgument, "ranking weights must be finite and not negative")
+		}
+	}
+	return nil
+}
+
+// newResultRanker creates the ranker of req, looking up what its scorers
+// need with the caller's token: the repositories of files for the stars and
+// recency scorers, and the caller's login and organizations for the
+// ownership scorer. A failed lookup scores 0.
+func (s *GithubSearchServer) newResultRanker(ctx context.Context, req *pb.SearchRequest, lookup *repositoryLookup, files []github.GitHubSearchItem) *resultRanker {
+	weights := req.GetRanking()
+	r := &resultRanker{}
+	add := func(weight float64, score func(github.GitHubSearchItem) float64) {
+		if weight > 0 {
+			r.scorers = append(r.scorers, resultScorer{weight: weight, score: score})
+		}
+	}
+	add(weights.GetPathDepth(), pathDepthScore)
+	add(weights.GetNonTest(), nonTestScore)
+	add(weights.GetTextMatches(), textMatchScore)
+
+	if weights.GetStars() > 0 || weights.GetRecency() > 0 {
+		var names []string
+		for _, file := range files {
+			names = append(names, file.Repository.FullName)
+		}
+		lookup.lookup(ctx, names)
+		now := time.Now()
+		add(weights.GetStars(), func(item github.GitHubSearchItem) float64 {
+			repo := lookup.get(item.Repository.FullName)
+			if repo == nil {
+				return 0
+			}
+			return min(1, math.Log10(1+float64(repo.StargazersCount))/math.Log10(maxScoredStars))
+		})
+		add(weights.GetRecency(), func(item github.GitHubSearchItem) float64 {
+			repo := lookup.get(item.Repository.FullName)
+			if repo == nil || repo.PushedAt.IsZero() {
+				return 0
+			}
+			return math.Pow(0.5, max(0, float64(now.Sub(repo.PushedAt))/float64(recencyHalfLife)))
+		})
+	}
+
+	if weights.GetCallerOwned() > 0 {
+		owners := s.callerOwners(ctx, lookup.token, lookup.identity)
+		add(weights.GetCallerOwned(), func(item github.GitHubSearchItem) float64 {
+			owner, _, _ := strings.Cut(item.Repository.FullName, "/")
+			if owners[strings.ToLower(owner)] {
+				return 1
+			}
+			return 0
+		})
+	}
+	return r
+}
+
+// score returns the weighted sum of the scores of item. A nil ranker scores 0.
+func (r *resultRanker) score(item github.GitHubSearchItem) float64 {
+	if r == nil {
+		return 0
+	}
+	var score float64
+	for _, scorer := range r.scorers {
+		score += scorer.weight * scorer.score(item)
+	}
+	return score
+}
+
+// rankResults orders results by descending score, keeping GitHub's order
+// among equal scores.
+func rankResults(results []*pb.Result) {
+	slices.SortStableFunc(results, func(a, b *pb.Result) int {
+		return cmp.Compare(b.GetScore(), a.GetScore())
+	})
+}
+
+func pathDepthScore(item github.GitHubSearchItem) float64 {
+	return 1 / float64(1+strings.Count(item.Path, "/"))
+}
+
+func nonTestScore(item github.GitHubSearchItem) float64 {
+	dirs := strings.Split(item.Path, "/")
+	for _, dir := range dirs[:len(dirs)-1] {
+		if slices.Contains(testDirectories, strings.ToLower(dir)) {
+			return 0
+		}
+	}
+	// e.g. foo_test.go, test_foo.py, foo.test.ts, foo.spec.js, FooTest.java
+	name := dirs[len(dirs)-1]
+	base := strings.TrimSuffix(name, path.Ext(name))
+	lower := strings.ToLower(base)
+	if strings.HasPrefix(lower, "test_") || strings.HasSuffix(lower, "_test") || strings.HasSuffix(lower, ".test") ||
+		strings.HasSuffix(lower, ".spec") || len(base) > len("Test") && (strings.HasSuffix(base, "Test") || strings.HasSuffix(base, "Tests")) {
+		return 0
+	}
+	return 1
+}
+
+func textMatchScore(item github.GitHubSearchItem) float64 {
+	matches := 0
+	for _, textMatch := range item.TextMatches {
+		matches += len(textMatch.Matches)
+	}
+	return min(1, float64(matches)/maxScoredTextMatches)
+}
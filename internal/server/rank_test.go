@@ -0,0 +1,85 @@
+package server
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/Pratham700/github-search-service/internal/github"
+	pb "github.com/Pratham700/github-search-service/proto/proto"
+)
+
+func TestNonTestScore(t *testing.T) {
+	tests := []struct {
+		path string
+		want float64
+	}{
+		{"main.go", 1},
+		{"internal/server/server.go", 1},
+		{"server_test.go", 0},
+		{"test_server.py", 0},
+		{"src/app.test.ts", 0},
+		{"src/app.spec.js", 0},
+		{"src/FooTest.java", 0},
+		{"src/FooTests.cs", 0},
+		{"tests/helpers.py", 0},
+		{"pkg/testdata/input.go", 0},
+		{"web/__tests__/app.js", 0},
+		{"spec/models/user.rb", 0},
+		{"Test/Fixtures.cs", 0},
+		{"Test.java", 1},
+		{"src/Contest.java", 1},
+		{"latest.go", 1},
+		{"testing/helpers.go", 1},
+		{"test", 1},
+	}
+	for _, tt := range tests {
+		if got := nonTestScore(github.GitHubSearchItem{Path: tt.path}); got != tt.want {
+			t.Errorf("nonTestScore(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestRankResultsStable(t *testing.T) {
+	// Enough results that an unstable sort would reorder equal scores
+	var results []*pb.Result
+	for i := range 50 {
+		results = append(results, &pb.Result{FileUrl: strconv.Itoa(i), Score: float64(i % 3)})
+	}
+	rankResults(results)
+
+	for i := 1; i < len(results); i++ {
+		prev, cur := results[i-1], results[i]
+		if prev.GetScore() < cur.GetScore() {
+			t.Fatalf("result %d scores %v after a result scoring %v", i, cur.GetScore(), prev.GetScore())
+		}
+		prevIndex, _ := strconv.Atoi(prev.GetFileUrl())
+		curIndex, _ := strconv.Atoi(cur.GetFileUrl())
+		if prev.GetScore() == cur.GetScore() && prevIndex > curIndex {
+			t.Fatalf("results %d and %d with equal scores are out of GitHub's order", prevIndex, curIndex)
+		}
+	}
+}
+
+func TestResultRankerScore(t *testing.T) {
+	req := &pb.SearchRequest{Ranking: &pb.RankingWeights{PathDepth: 2, NonTest: 1}}
+	r := (&GithubSearchServer{}).newResultRanker(context.Background(), req, nil, nil)
+	tests := []struct {
+		path string
+		want float64
+	}{
+		{"main.go", 3},
+		{"cmd/main.go", 2},
+		{"main_test.go", 2},
+		{"a/b/c/main_test.go", 0.5},
+	}
+	for _, tt := range tests {
+		if got := r.score(github.GitHubSearchItem{Path: tt.path}); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("score(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+	if got := (*resultRanker)(nil).score(github.GitHubSearchItem{Path: "main.go"}); got != 0 {
+		t.Errorf("score() of a nil ranker = %v, want 0", got)
+	}
+}
@@ -0,0 +1,242 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/Pratham700/github-search-service/internal/store"
+	pb "github.com/Pratham700/github-search-service/proto/proto"
+)
+
+const (
+	defaultSavedSearchPageSize = 20
+	maxSavedSearchNameLen      = 100
+	maxSavedSearchDescLen      = 1000
+	maxSavedSearchTags         = 20
+	maxSavedSearchTagLen       = 50
+)
+
+// CreateSavedSearch implements the CreateSavedSearch gRPC method.
+func (s *GithubSearchServer) CreateSavedSearch(ctx context.Context, req *pb.CreateSavedSearchRequest) (*pb.SavedSearch, error) {
+	identity, err := s.savedSearchCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	search := proto.Clone(req.GetSavedSearch()).(*pb.SavedSearch)
+	if err := s.validateSavedSearch(search); err != nil {
+		return nil, err
+	}
+	now := timestamppb.Now()
+	search.Owner = identity
+	search.CreateTime = now
+	search.UpdateTime = now
+
+	if err := s.store.CreateSavedSearch(search); err != nil {
+		return nil, savedSearchError(err)
+	}
+	return search, nil
+}
+
+// GetSavedSearch implements the GetSavedSearch gRPC method.
+func (s *GithubSearchServer) GetSavedSearch(ctx context.Context, req *pb.GetSavedSearchRequest) (*pb.SavedSearch, error) {
+	identity, err := s.savedSearchCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.visibleSavedSearch(identity, req.GetId())
+}
+
+// ListSavedSearches implements the ListSavedSearches gRPC method.
+func (s *GithubSearchServer) ListSavedSearches(ctx context.Context, req *pb.ListSavedSearchesRequest) (*pb.ListSavedSearchesResponse, error) {
+	identity, err := s.savedSearchCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := int(req.GetPageSize())
+	if pageSize < 0 || pageSize > maxPerPage {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid value for 'page_size': must be between 0 and %d", maxPerPage)
+	}
+	if pageSize == 0 {
+		pageSize = defaultSavedSearchPageSize
+	}
+
+	filter := func(search *pb.SavedSearch) bool {
+		if search.GetOwner() != identity && !search.GetShared() {
+			return false
+		}
+		return req.GetTag() == "" || slices.Contains(search.GetTags(), req.GetTag())
+	}
+	searches, nextPageToken, err := s.store.ListSavedSearches(filter, pageSize, req.GetPageToken())
+	if err != nil {
+		return nil, savedSearchError(err)
+	}
+	return &pb.ListSavedSearchesResponse{
+		SavedSearches: searches,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+// UpdateSavedSearch implements the UpdateSavedSearch gRPC method.
+func (s *GithubSearchServer) UpdateSavedSearch(ctx context.Context, req *pb.UpdateSavedSearchRequest) (*pb.SavedSearch, error) {
+	identity, err := s.savedSearchCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := req.GetSavedSearch()
+	paths := req.GetUpdateMask().GetPaths()
+	if len(paths) == 0 {
+		paths = []string{"name", "description", "tags", "request", "shared"}
+	}
+
+	search, err := s.store.UpdateSavedSearch(changes.GetId(), func(search *pb.SavedSearch) error {
+		if search.GetOwner() != identity {
+			return ownershipError(search)
+		}
+		for _, path := range paths {
+			switch path {
+			case "name":
+				search.Name = changes.GetName()
+			case "description":
+				search.Description = changes.GetDescription()
+			case "tags":
+				search.Tags = changes.GetTags()
+			case "request":
+				search.Request = changes.GetRequest()
+			case "shared":
+				search.Shared = changes.GetShared()
+			default:
+				return status.Errorf(codes.InvalidArgument, "invalid update_mask path %q: must be one of name, description, tags, request, shared", path)
+			}
+		}
+		if err := s.validateSavedSearch(search); err != nil {
+			return err
+		}
+		search.UpdateTime = timestamppb.Now()
+		return nil
+	})
+	if err != nil {
+		return nil, savedSearchError(err)
+	}
+	return search, nil
+}
+
+// DeleteSavedSearch implements the DeleteSavedSearch gRPC method.
+func (s *GithubSearchServer) DeleteSavedSearch(ctx context.Context, req *pb.DeleteSavedSearchRequest) (*pb.DeleteSavedSearchResponse, error) {
+	identity, err := s.savedSearchCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	search, err := s.store.GetSavedSearch(req.GetId())
+	if err != nil {
+		return nil, savedSearchError(err)
+	}
+	if search.GetOwner() != identity {
+		return nil, ownershipError(search)
+	}
+	if err := s.store.DeleteSavedSearch(req.GetId()); err != nil {
+		return nil, savedSearchError(err)
+	}
+	return &pb.DeleteSavedSearchResponse{}, nil
+}
+
+// RunSavedSearch implements the RunSavedSearch gRPC method. The search runs
+// with the caller's own GitHub token.
+func (s *GithubSearchServer) RunSavedSearch(ctx context.Context, req *pb.RunSavedSearchRequest) (*pb.SearchResponse, error) {
+	identity, err := s.savedSearchCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	search, err := s.visibleSavedSearch(identity, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	searchReq := proto.Clone(search.GetRequest()).(*pb.SearchRequest)
+	if req.Page != nil {
+		searchReq.Page = req.Page
+	}
+	return s.Search(ctx, searchReq)
+}
+
+// savedSearchCaller returns the caller identity, or an error if saved
+// searches are disabled.
+func (s *GithubSearchServer) savedSearchCaller(ctx context.Context) (string, error) {
+	identity, err := GetCallerIdentityFromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+	if s.store == nil {
+		return "", status.Errorf(codes.Unimplemented, "saved searches are disabled on this server")
+	}
+	return identity, nil
+}
+
+// visibleSavedSearch returns the saved search if identity owns it or it is
+// shared. Other callers' private saved searches are reported as not found.
+func (s *GithubSearchServer) visibleSavedSearch(identity, id string) (*pb.SavedSearch, error) {
+	search, err := s.store.GetSavedSearch(id)
+	if err != nil {
+		return nil, savedSearchError(err)
+	}
+	if search.GetOwner() != identity && !search.GetShared() {
+		return nil, savedSearchError(store.ErrNotFound)
+	}
+	return search, nil
+}
+
+// ownershipError rejects changes to a saved search by a caller other than its owner,
+// without revealing private saved searches of other callers.
+func ownershipError(search *pb.SavedSearch) error {
+	if search.GetShared() {
+		return status.Errorf(codes.PermissionDenied, "only the owner can change saved search %s", search.GetId())
+	}
+	return savedSearchError(store.ErrNotFound)
+}
+
+// validateSavedSearch checks the fields a caller can set, including that the
+// stored SearchRequest would be accepted by Search.
+func (s *GithubSearchServer) validateSavedSearch(search *pb.SavedSearch) error {
+	switch {
+	case search.GetName() == "":
+		return status.Errorf(codes.InvalidArgument, "'name' is required")
+	case len(search.GetName()) > maxSavedSearchNameLen:
+		return status.Errorf(codes.InvalidArgument, "'name' must be at most %d characters", maxSavedSearchNameLen)
+	case len(search.GetDescription()) > maxSavedSearchDescLen:
+		return status.Errorf(codes.InvalidArgument, "'description' must be at most %d characters", maxSavedSearchDescLen)
+	case len(search.GetTags()) > maxSavedSearchTags:
+		return status.Errorf(codes.InvalidArgument, "at most %d tags are allowed", maxSavedSearchTags)
+	case search.GetRequest() == nil:
+		return status.Errorf(codes.InvalidArgument, "'request' is required")
+	}
+	for _, tag := range search.GetTags() {
+		if tag == "" || len(tag) > maxSavedSearchTagLen {
+			return status.Errorf(codes.InvalidArgument, "tags must be between 1 and %d characters", maxSavedSearchTagLen)
+		}
+	}
+	_, err := s.buildGitHubParams(search.GetRequest())
+	return err
+}
+
+// savedSearchError maps store errors to gRPC status errors.
+func savedSearchError(err error) error {
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		return status.Errorf(codes.NotFound, "saved search not found")
+	case errors.Is(err, store.ErrAlreadyExists):
+		return status.Errorf(codes.AlreadyExists, "a saved search with this name already exists")
+	case status.Code(err) != codes.Unknown:
+		return err
+	default:
+		return status.Errorf(codes.Internal, "failed to access saved searches: %v", err)
+	}
+}
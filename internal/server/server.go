@@ -1,68 +1,205 @@
 package server
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
-	"log"
-	"os"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"log/slog"
 	"strconv"
+	"sync/atomic"
 
 	"github.com/Pratham700/github-search-service/internal/github"
+	"github.com/Pratham700/github-search-service/internal/store"
+	"github.com/Pratham700/github-search-service/internal/tracing"
 	pb "github.com/Pratham700/github-search-service/proto/proto"
 )
 
 const (
 	minPerPage = 1
 	maxPerPage = 100
+	// maxGitHubResults is the number of hits GitHub returns at most for a search.
+	maxGitHubResults = 1000
 )
 
 type GithubSearchServer struct {
 	pb.UnimplementedGithubSearchServiceServer
-	gitHubClient *github.GitHubClient
+	gitHubClient atomic.Pointer[github.GitHubClient]
+	quota        *QuotaLimiter
+	history      *SearchHistory
+	store        *store.Store
+	watches      *Watches
+	webhooks     *Webhooks
+	snapshots    *Snapshots
+	batch        BatchConfig
+	facets       FacetConfig
+	exhaustive   ExhaustiveConfig
+	cache        CacheConfig
+	// repositories and owners cache lookups for filters, dedup and ranking.
+	repositories *ttlCache[*github.GitHubRepository]
+	owners       *ttlCache[map[string]bool]
+	// auditor, if set, records the runs of watches, which no call audits.
+	auditor *Auditor
+}
+
+// Options holds the optional dependencies of GithubSearchServer. The RPCs of
+// a feature whose dependency is nil return UNIMPLEMENTED.
+type Options struct {
+	// Quota answers GetQuota.
+	Quota *QuotaLimiter
+	// History records searches and answers the search history RPCs.
+	History *SearchHistory
+	// SavedSearches holds saved searches.
+	SavedSearches *store.Store
+	// Watches runs search watches.
+	Watches *Watches
+	// Webhooks delivers the new hits of watches to their webhooks; it
+	// requires Watches.
+	Webhooks *Webhooks
+	// Snapshots holds search snapshots and answers DiffSearch.
+	Snapshots *Snapshots
+	// Batch limits batch searches, which are disabled if Batch.MaxQueries is 0.
+	Batch BatchConfig
+	// Facets limits SearchFacets, which is disabled if Facets.MaxResults is 0.
+	Facets FacetConfig
+	// Exhaustive limits ExhaustiveSearch, which is disabled if Exhaustive.MaxQueries is 0.
+	Exhaustive ExhaustiveConfig
+	// Cache bounds the caches of GitHub lookups.
+	Cache CacheConfig
 }
 
 // NewGithubSearchServer creates a new GithubSearchServer.
-func NewGithubSearchServer() (*GithubSearchServer, error) {
-	// Read the GitHub API base URL from an environment variable (optional)
-	baseURL := os.Getenv("GITHUB_BASE_URL")
-	if baseURL == "" {
-		baseURL = "https://api.github.com" // Default base URL
+func NewGithubSearchServer(gitHubClient *github.GitHubClient, options Options) (*GithubSearchServer, error) {
+	if gitHubClient == nil {
+		return nil, fmt.Errorf("a GitHub client is required")
 	}
 
-	return &GithubSearchServer{
-		gitHubClient: github.NewGitHubClient(baseURL),
-	}, nil
+	s := &GithubSearchServer{
+		quota:      options.Quota,
+		history:    options.History,
+		store:      options.SavedSearches,
+		watches:    options.Watches,
+		webhooks:   options.Webhooks,
+		snapshots:  options.Snapshots,
+		batch:      options.Batch,
+		facets:     options.Facets,
+		exhaustive: options.Exhaustive,
+
+		cache: CacheConfig{
+			TTL:        cmp.Or(options.Cache.TTL, lookupCacheTTL),
+			LoginTTL:   cmp.Or(options.Cache.LoginTTL, loginCacheTTL),
+			MaxEntries: cmp.Or(options.Cache.MaxEntries, lookupCacheMaxSize),
+		},
+	}
+	s.repositories = newTTLCache[*github.GitHubRepository](s.cache.TTL, s.cache.MaxEntries)
+	s.owners = newTTLCache[map[string]bool](s.cache.TTL, s.cache.MaxEntries)
+	s.gitHubClient.Store(gitHubClient)
+	return s, nil
+}
+
+// SetAuditor makes the server record watch runs with auditor. It must be
+// called before RunWatches.
+func (s *GithubSearchServer) SetAuditor(auditor *Auditor) {
+	s.auditor = auditor
+}
+
+// GitHubClient returns the client currently used for new requests.
+func (s *GithubSearchServer) GitHubClient() *github.GitHubClient {
+	return s.gitHubClient.Load()
+}
+
+// SetGitHubClient replaces the client used for new requests. Requests already
+// in flight finish with the client they started with.
+func (s *GithubSearchServer) SetGitHubClient(gitHubClient *github.GitHubClient) {
+	s.gitHubClient.Store(gitHubClient)
 }
 
 // Search implements the Search gRPC method.
 func (s *GithubSearchServer) Search(ctx context.Context, req *pb.SearchRequest) (*pb.SearchResponse, error) {
-	log.Printf("Received Search request: SearchTerm=%s, User=%s", req.SearchTerm, req.User)
+	// The search term may contain secrets; the log handler masks anything that looks like one
+	slog.DebugContext(ctx, "Received Search request", "search_term", req.SearchTerm, "user", req.User)
 
 	authToken, err := GetAuthTokenFromContext(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get github token from context: %w", err)
 	}
 
+	_, span := tracing.StartSpan(ctx, "validate parameters")
 	githubParams, err := s.buildGitHubParams(req)
+	tracing.EndSpan(span, err)
 	if err != nil {
 		return nil, err
 	}
 
-	files, err := s.gitHubClient.SearchFiles(ctx, req.SearchTerm, req.User, authToken, githubParams)
+	identity, _ := GetCallerIdentityFromContext(ctx)
+	lookup := s.newRepositoryLookup(authToken, identity)
+	var files []github.GitHubSearchItem
+	if filter := newResultFilter(req); filter != nil {
+		files, err = s.searchFiltered(ctx, req, lookup, githubParams, filter)
+	} else {
+		var result *github.GithubSearchCodeResponse
+		if result, err = s.searchCode(ctx, req, authToken, githubParams); err == nil {
+			files = result.Items
+		}
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to search files on GitHub: %w", err)
+		return nil, searchError(err)
 	}
 
-	results := transformGitHubResults(files)
-	log.Printf("Found %d results", len(results))
+	var dedup *resultDedup
+	if req.GetDedup() != pb.DedupMode_DEDUP_UNSPECIFIED {
+		dedup = newResultDedup(ctx, req, lookup, files)
+	}
+	var ranker *resultRanker
+	if req.GetRanking() != nil {
+		ranker = s.newResultRanker(ctx, req, lookup, files)
+	}
+	results := transformGitHubResults(files, dedup, ranker)
+	if ranker != nil {
+		rankResults(results)
+	}
+	slog.DebugContext(ctx, "Search completed", "results", len(results))
+	if s.history != nil && identity != "" {
+		s.history.Record(ctx, identity, req, len(results))
+	}
 
 	return &pb.SearchResponse{
 		Results: results,
 	}, nil
 }
 
+// searchError converts the error of a search on GitHub into the error
+// returned to the caller, reporting an open circuit breaker as Unavailable.
+func searchError(err error) error {
+	if errors.Is(err, github.ErrCircuitOpen) {
+		return status.Errorf(codes.Unavailable, "GitHub is currently unavailable: %v", err)
+	}
+	return fmt.Errorf("failed to search files on GitHub: %w", err)
+}
+
+// GetQuota implements the GetQuota gRPC method.
+func (s *GithubSearchServer) GetQuota(ctx context.Context, _ *pb.GetQuotaRequest) (*pb.GetQuotaResponse, error) {
+	identity, err := GetCallerIdentityFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if s.quota == nil {
+		return &pb.GetQuotaResponse{}, nil
+	}
+
+	st := s.quota.Status(identity)
+	return &pb.GetQuotaResponse{
+		RequestsPerMinute: int32(st.RequestsPerMinute),
+		RequestsRemaining: int32(st.RequestsRemaining),
+		ResultsPerDay:     int32(st.ResultsPerDay),
+		ResultsRemaining:  int32(st.ResultsRemaining),
+		ResultsResetTime:  timestamppb.New(st.ResultsResetTime),
+	}, nil
+}
+
 func (s *GithubSearchServer) buildGitHubParams(req *pb.SearchRequest) (map[string]string, error) {
 	githubParams := make(map[string]string)
 	if err := s.processSearchParameters(req, githubParams); err != nil {
@@ -71,30 +208,53 @@ func (s *GithubSearchServer) buildGitHubParams(req *pb.SearchRequest) (map[strin
 	return githubParams, nil
 }
 
-func transformGitHubResults(files []github.GitHubSearchItem) []*pb.Result {
-	var results []*pb.Result
+// searchCode runs req on GitHub, asking for text matches if they are ranked.
+func (s *GithubSearchServer) searchCode(ctx context.Context, req *pb.SearchRequest, token string, githubParams map[string]string) (*github.GithubSearchCodeResponse, error) {
+	if req.GetRanking().GetTextMatches() > 0 {
+		return s.GitHubClient().SearchCodeWithTextMatches(ctx, req.GetSearchTerm(), req.GetUser(), token, githubParams)
+	}
+	return s.GitHubClient().SearchCode(ctx, req.GetSearchTerm(), req.GetUser(), token, githubParams)
+}
+
+// transformGitHubResults converts the files into results, collapsing
+// duplicates if dedup is set and scoring them if ranker is.
+func transformGitHubResults(files []github.GitHubSearchItem, dedup *resultDedup, ranker *resultRanker) []*pb.Result {
+	var valid []github.GitHubSearchItem
 	for _, file := range files {
-		fileURL := github.ExtractFileURL(file)
-		repoName := github.ExtractRepoUrl(file)
-		if fileURL != "" && repoName != "" {
-			results = append(results, &pb.Result{
-				FileUrl: fileURL,
-				Repo:    repoName,
-			})
+		if github.ExtractFileURL(file) != "" && github.ExtractRepoUrl(file) != "" {
+			valid = append(valid, file)
 		}
 	}
+
+	var results []*pb.Result
+	for _, group := range dedup.groups(valid) {
+		file := valid[dedup.pick(valid, group)]
+		results = append(results, &pb.Result{
+			FileUrl:        github.ExtractFileURL(file),
+			Repo:           github.ExtractRepoUrl(file),
+			DuplicateCount: int32(len(group) - 1),
+			Score:          ranker.score(file),
+		})
+	}
 	return results
 }
 
-// processSearchParameters extracts and validates enum parameters.
+// processSearchParameters extracts and validates the request parameters. The
+// constraints are also declared as annotations in the proto file.
 func (s *GithubSearchServer) processSearchParameters(req *pb.SearchRequest, githubParams map[string]string) error {
+	// GitHub's code search rejects a query without search terms, so an empty
+	// search_term would only fail there after being charged to the quotas
+	if req.GetSearchTerm() == "" {
+		return status.Errorf(codes.InvalidArgument, "'search_term' is required")
+	}
+
 	// Helper function to handle enum to string mapping
 	mapEnumToString := func(enumValue int32, mapping map[int32]string, paramName string) error {
 		if stringValue, ok := mapping[enumValue]; ok {
 			githubParams[paramName] = stringValue
 			return nil
 		} else if enumValue != 0 {
-			log.Printf("Received invalid %s option: %v", paramName, enumValue)
+			slog.Debug("Received invalid option", "parameter", paramName, "value", enumValue)
 			return status.Errorf(codes.InvalidArgument, "invalid %s option: %v", paramName, enumValue)
 		}
 		return nil // No error for UNSPECIFIED
@@ -120,18 +280,30 @@ func (s *GithubSearchServer) processSearchParameters(req *pb.SearchRequest, gith
 		return err
 	}
 
-	// Handle per_page
-	perPage := req.GetPerPage()
-	if perPage > 0 {
+	// Dedup options and filters are applied to the results, not passed to GitHub
+	if err := validateResultFilter(req); err != nil {
+		return err
+	}
+	if err := validateRanking(req); err != nil {
+		return err
+	}
+	if _, ok := pb.DedupMode_name[int32(req.GetDedup())]; !ok {
+		return status.Errorf(codes.InvalidArgument, "invalid dedup option: %v", req.GetDedup())
+	}
+	if _, ok := pb.Representative_name[int32(req.GetRepresentative())]; !ok {
+		return status.Errorf(codes.InvalidArgument, "invalid representative option: %v", req.GetRepresentative())
+	}
+
+	// Handle per_page and page; GitHub's defaults apply if they are not set
+	if req.PerPage != nil {
+		perPage := req.GetPerPage()
 		if perPage < minPerPage || perPage > maxPerPage {
 			return status.Errorf(codes.InvalidArgument, "invalid value for 'per_page': must be an integer between 1 and 100")
 		}
 		githubParams["per_page"] = strconv.Itoa(int(perPage))
 	}
-
-	// Handle page
-	page := req.GetPage()
-	if page > 0 {
+	if req.Page != nil {
+		page := req.GetPage()
 		if page < 1 {
 			return status.Errorf(codes.InvalidArgument, "invalid value for 'page': must be an integer greater than or equal to 1")
 		}
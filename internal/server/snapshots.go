@@ -0,0 +1,285 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/Pratham700/github-search-service/internal/store"
+	pb "github.com/Pratham700/github-search-service/proto/proto"
+)
+
+// SnapshotConfig configures search snapshots.
+type SnapshotConfig struct {
+	// MaxResults is the number of hits recorded per snapshot, and per run of
+	// a search compared by DiffSearch.
+	MaxResults int
+	// MaxPerCaller is the number of snapshots a caller may keep; 0 allows any number.
+	MaxPerCaller int
+}
+
+// Snapshots holds the state of search snapshots.
+type Snapshots struct {
+	store  *store.Store
+	config SnapshotConfig
+}
+
+// NewSnapshots creates Snapshots stored in st.
+func NewSnapshots(st *store.Store, config SnapshotConfig) *Snapshots {
+	return &Snapshots{store: st, config: config}
+}
+
+// CreateSnapshot implements the CreateSnapshot gRPC method. The search runs
+// with the caller's own GitHub token.
+func (s *GithubSearchServer) CreateSnapshot(ctx context.Context, req *pb.CreateSnapshotRequest) (*pb.Snapshot, error) {
+	identity, err := s.snapshotCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.GetRequest() == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "'request' is required")
+	}
+	return s.takeSnapshot(ctx, identity, req.GetRequest(), true)
+}
+
+// GetSnapshot implements the GetSnapshot gRPC method.
+func (s *GithubSearchServer) GetSnapshot(ctx context.Context, req *pb.GetSnapshotRequest) (*pb.Snapshot, error) {
+	identity, err := s.snapshotCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.ownedSnapshot(identity, req.GetId())
+}
+
+// ListSnapshots implements the ListSnapshots gRPC method.
+func (s *GithubSearchServer) ListSnapshots(ctx context.Context, req *pb.ListSnapshotsRequest) (*pb.ListSnapshotsResponse, error) {
+	identity, err := s.snapshotCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pageSize, err := watchPageSize(req.GetPageSize())
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots, nextPageToken, err := s.snapshots.store.ListSnapshots(func(snapshot *pb.Snapshot) bool {
+		return snapshot.GetOwner() == identity
+	}, pageSize, req.GetPageToken())
+	if err != nil {
+		return nil, snapshotError(err)
+	}
+	return &pb.ListSnapshotsResponse{Snapshots: snapshots, NextPageToken: nextPageToken}, nil
+}
+
+// DeleteSnapshot implements the DeleteSnapshot gRPC method.
+func (s *GithubSearchServer) DeleteSnapshot(ctx context.Context, req *pb.DeleteSnapshotRequest) (*pb.DeleteSnapshotResponse, error) {
+	identity, err := s.snapshotCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.ownedSnapshot(identity, req.GetId()); err != nil {
+		return nil, err
+	}
+	if err := s.snapshots.store.DeleteSnapshot(req.GetId()); err != nil {
+		return nil, snapshotError(err)
+	}
+	return &pb.DeleteSnapshotResponse{}, nil
+}
+
+// DiffSearch implements the DiffSearch gRPC method. It compares the base
+// snapshot with the target snapshot or, without one, with a run of the
+// search now, matching hits by repository and path.
+func (s *GithubSearchServer) DiffSearch(ctx context.Context, req *pb.DiffSearchRequest) (*pb.DiffSearchResponse, error) {
+	identity, err := s.snapshotCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.GetRequest() == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "'request' is required")
+	}
+	if req.GetSaveTarget() && req.GetTargetSnapshotId() != "" {
+		return nil, status.Errorf(codes.InvalidArgument, "'save_target' cannot be combined with 'target_snapshot_id'")
+	}
+
+	base, err := s.diffSnapshot(identity, req.GetBaseSnapshotId(), req.GetRequest())
+	if err != nil {
+		return nil, err
+	}
+	var target *pb.Snapshot
+	if req.GetTargetSnapshotId() != "" {
+		target, err = s.diffSnapshot(identity, req.GetTargetSnapshotId(), req.GetRequest())
+	} else {
+		target, err = s.takeSnapshot(ctx, identity, req.GetRequest(), req.GetSaveTarget())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	resp := diffHits(base.GetHits(), target.GetHits())
+	resp.BaseTime = base.GetCreateTime()
+	resp.TargetTime = target.GetCreateTime()
+	resp.TargetSnapshotId = target.GetId()
+	resp.Truncated = base.GetTruncated() || target.GetTruncated()
+	return resp, nil
+}
+
+// takeSnapshot runs req with the caller's token and returns its hits as a
+// snapshot, which is stored if save is set.
+func (s *GithubSearchServer) takeSnapshot(ctx context.Context, identity string, req *pb.SearchRequest, save bool) (*pb.Snapshot, error) {
+	token, err := GetAuthTokenFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.validateHitsRequest(req); err != nil {
+		return nil, err
+	}
+	fetched, err := s.fetchHits(ctx, req, token, identity, s.snapshots.config.MaxResults, true)
+	if err != nil {
+		return nil, searchError(err)
+	}
+
+	snapshot := &pb.Snapshot{
+		Request:    snapshotRequest(req),
+		Owner:      identity,
+		CreateTime: timestamppb.Now(),
+		HitCount:   int32(len(fetched.hits)),
+		Truncated:  fetched.truncated || fetched.incomplete,
+		Hits:       fetched.hits,
+	}
+	if !save {
+		return snapshot, nil
+	}
+	if err := s.snapshots.store.CreateSnapshot(snapshot, s.snapshots.config.MaxPerCaller); err != nil {
+		return nil, snapshotError(err)
+	}
+	return snapshot, nil
+}
+
+// diffSnapshot returns the snapshot with the given ID if identity owns it
+// and it was taken of the same search as req.
+func (s *GithubSearchServer) diffSnapshot(identity, id string, req *pb.SearchRequest) (*pb.Snapshot, error) {
+	snapshot, err := s.ownedSnapshot(identity, id)
+	if err != nil {
+		return nil, err
+	}
+	if !proto.Equal(snapshot.GetRequest(), snapshotRequest(req)) {
+		return nil, status.Errorf(codes.InvalidArgument, "snapshot %s was taken of a different search", id)
+	}
+	return snapshot, nil
+}
+
+// snapshotRequest returns the search of a snapshot of req. The hits do not
+// depend on paging, so it is not part of the snapshot.
+func snapshotRequest(req *pb.SearchRequest) *pb.SearchRequest {
+	request := proto.Clone(req).(*pb.SearchRequest)
+	request.Page, request.PerPage = nil, nil
+	return request
+}
+
+// diffHits compares two sets of hits by repository and path, grouping the
+// differences by repository.
+func diffHits(base, target []*pb.WatchHit) *pb.DiffSearchResponse {
+	type fileKey struct{ repo, path string }
+	baseHits := make(map[fileKey]*pb.WatchHit, len(base))
+	for _, hit := range base {
+		baseHits[fileKey{hit.GetRepo(), hit.GetPath()}] = hit
+	}
+
+	resp := &pb.DiffSearchResponse{}
+	repos := make(map[string]*pb.RepositoryDiff)
+	repoDiff := func(repo string) *pb.RepositoryDiff {
+		if repos[repo] == nil {
+			repos[repo] = &pb.RepositoryDiff{Repo: repo}
+		}
+		return repos[repo]
+	}
+
+	seen := make(map[fileKey]bool, len(target))
+	for _, hit := range target {
+		key := fileKey{hit.GetRepo(), hit.GetPath()}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		previous, ok := baseHits[key]
+		switch {
+		case !ok:
+			diff := repoDiff(hit.GetRepo())
+			diff.Added = append(diff.Added, hit)
+			diff.AddedCount++
+			resp.AddedCount++
+		case previous.GetSha() != hit.GetSha():
+			diff := repoDiff(hit.GetRepo())
+			diff.Changed = append(diff.Changed, &pb.ChangedHit{
+				Path:      hit.GetPath(),
+				BaseSha:   previous.GetSha(),
+				TargetSha: hit.GetSha(),
+				FileUrl:   hit.GetFileUrl(),
+			})
+			diff.ChangedCount++
+			resp.ChangedCount++
+		}
+	}
+	for key, hit := range baseHits {
+		if !seen[key] {
+			diff := repoDiff(hit.GetRepo())
+			diff.Removed = append(diff.Removed, hit)
+			diff.RemovedCount++
+			resp.RemovedCount++
+		}
+	}
+
+	for _, diff := range repos {
+		slices.SortFunc(diff.Added, func(a, b *pb.WatchHit) int { return strings.Compare(a.GetPath(), b.GetPath()) })
+		slices.SortFunc(diff.Removed, func(a, b *pb.WatchHit) int { return strings.Compare(a.GetPath(), b.GetPath()) })
+		slices.SortFunc(diff.Changed, func(a, b *pb.ChangedHit) int { return strings.Compare(a.GetPath(), b.GetPath()) })
+		resp.Repositories = append(resp.Repositories, diff)
+	}
+	slices.SortFunc(resp.Repositories, func(a, b *pb.RepositoryDiff) int { return strings.Compare(a.GetRepo(), b.GetRepo()) })
+	return resp
+}
+
+// snapshotCaller returns the caller identity, or an error if snapshots are disabled.
+func (s *GithubSearchServer) snapshotCaller(ctx context.Context) (string, error) {
+	identity, err := GetCallerIdentityFromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+	if s.snapshots == nil {
+		return "", status.Errorf(codes.Unimplemented, "snapshots are disabled on this server")
+	}
+	return identity, nil
+}
+
+// ownedSnapshot returns the snapshot if identity owns it. Other callers'
+// snapshots are reported as not found.
+func (s *GithubSearchServer) ownedSnapshot(identity, id string) (*pb.Snapshot, error) {
+	snapshot, err := s.snapshots.store.GetSnapshot(id)
+	if err != nil {
+		return nil, snapshotError(err)
+	}
+	if snapshot.GetOwner() != identity {
+		return nil, snapshotError(store.ErrNotFound)
+	}
+	return snapshot, nil
+}
+
+// snapshotError maps store errors to gRPC status errors.
+func snapshotError(err error) error {
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		return status.Errorf(codes.NotFound, "snapshot not found")
+	case errors.Is(err, store.ErrLimitReached):
+		return status.Errorf(codes.ResourceExhausted, "snapshot limit reached; delete snapshots that are no longer needed")
+	case errors.Is(err, store.ErrInvalidPageToken):
+		return status.Errorf(codes.InvalidArgument, "invalid value for 'page_token'")
+	default:
+		return status.Errorf(codes.Internal, "failed to access snapshots: %v", err)
+	}
+}
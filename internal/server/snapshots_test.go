@@ -0,0 +1,125 @@
+package server
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/Pratham700/github-search-service/internal/store"
+	pb "github.com/Pratham700/github-search-service/proto/proto"
+)
+
+// diffSpecs formats the differences of resp as "repo +path", "repo -path"
+// and "repo ~path" strings, in the order they are returned.
+func diffSpecs(resp *pb.DiffSearchResponse) []string {
+	var specs []string
+	for _, diff := range resp.GetRepositories() {
+		for _, hit := range diff.GetAdded() {
+			specs = append(specs, diff.GetRepo()+" +"+hit.GetPath())
+		}
+		for _, hit := range diff.GetRemoved() {
+			specs = append(specs, diff.GetRepo()+" -"+hit.GetPath())
+		}
+		for _, hit := range diff.GetChanged() {
+			specs = append(specs, diff.GetRepo()+" ~"+hit.GetPath())
+		}
+	}
+	return specs
+}
+
+func TestDiffHits(t *testing.T) {
+	hit := func(repo, path, sha string) *pb.WatchHit {
+		return &pb.WatchHit{Repo: repo, Path: path, Sha: sha}
+	}
+	tests := []struct {
+		name                    string
+		base, target            []*pb.WatchHit
+		want                    []string
+		added, removed, changed int32
+	}{
+		{"identical", []*pb.WatchHit{hit("o/r", "a.go", "1")}, []*pb.WatchHit{hit("o/r", "a.go", "1")}, nil, 0, 0, 0},
+		{"empty", nil, nil, nil, 0, 0, 0},
+		{
+			name:   "added, removed and changed",
+			base:   []*pb.WatchHit{hit("o/r", "a.go", "1"), hit("o/r", "b.go", "1")},
+			target: []*pb.WatchHit{hit("o/r", "a.go", "2"), hit("o/r", "c.go", "1")},
+			want:   []string{"o/r +c.go", "o/r -b.go", "o/r ~a.go"},
+			added:  1, removed: 1, changed: 1,
+		},
+		{
+			name:   "grouped and sorted by repository and path",
+			base:   []*pb.WatchHit{hit("z/r", "b.go", "1")},
+			target: []*pb.WatchHit{hit("z/r", "c.go", "1"), hit("a/r", "y.go", "1"), hit("a/r", "x.go", "1")},
+			want:   []string{"a/r +x.go", "a/r +y.go", "z/r +c.go", "z/r -b.go"},
+			added:  3, removed: 1,
+		},
+		{
+			name:   "same path in another repository",
+			base:   []*pb.WatchHit{hit("o/r", "a.go", "1")},
+			target: []*pb.WatchHit{hit("o/fork", "a.go", "1"), hit("o/r", "a.go", "1")},
+			want:   []string{"o/fork +a.go"},
+			added:  1,
+		},
+		{
+			name:   "duplicate target hits count once",
+			target: []*pb.WatchHit{hit("o/r", "a.go", "1"), hit("o/r", "a.go", "2")},
+			want:   []string{"o/r +a.go"},
+			added:  1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := diffHits(tt.base, tt.target)
+			if got := diffSpecs(resp); !slices.Equal(got, tt.want) {
+				t.Errorf("diffHits() = %q, want %q", got, tt.want)
+			}
+			if resp.GetAddedCount() != tt.added || resp.GetRemovedCount() != tt.removed || resp.GetChangedCount() != tt.changed {
+				t.Errorf("counts = %d, %d, %d, want %d, %d, %d", resp.GetAddedCount(), resp.GetRemovedCount(),
+					resp.GetChangedCount(), tt.added, tt.removed, tt.changed)
+			}
+		})
+	}
+}
+
+func TestDiffSnapshotRequest(t *testing.T) {
+	st, err := store.Open(filepath.Join(t.TempDir(), "store.db"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	t.Cleanup(func() { st.Close() })
+	s := &GithubSearchServer{snapshots: NewSnapshots(st, SnapshotConfig{})}
+
+	taken := &pb.SearchRequest{SearchTerm: "foo", User: "o", ExcludePaths: []string{"**/vendor/**"}, Page: proto.Int32(2)}
+	snapshot := &pb.Snapshot{Request: snapshotRequest(taken), Owner: "alice"}
+	if err := st.CreateSnapshot(snapshot, 0); err != nil {
+		t.Fatalf("CreateSnapshot() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*pb.SearchRequest)
+		want   codes.Code
+	}{
+		{"same search", func(*pb.SearchRequest) {}, codes.OK},
+		{"other page", func(req *pb.SearchRequest) { req.Page, req.PerPage = proto.Int32(3), proto.Int32(10) }, codes.OK},
+		{"other term", func(req *pb.SearchRequest) { req.SearchTerm = "bar" }, codes.InvalidArgument},
+		{"other filter", func(req *pb.SearchRequest) { req.ExcludePaths = nil }, codes.InvalidArgument},
+		{"other repository filter", func(req *pb.SearchRequest) { req.ExcludeForks = true }, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := proto.Clone(taken).(*pb.SearchRequest)
+			tt.modify(req)
+			if _, err := s.diffSnapshot("alice", snapshot.GetId(), req); status.Code(err) != tt.want {
+				t.Errorf("diffSnapshot() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+	if _, err := s.diffSnapshot("bob", snapshot.GetId(), taken); status.Code(err) != codes.NotFound {
+		t.Errorf("diffSnapshot() of another caller's snapshot error = %v, want NotFound", err)
+	}
+}
@@ -0,0 +1,134 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+// TLSOptions holds the resolved TLS settings of the gRPC listener.
+// A non-empty ClientCAFile enables mutual TLS.
+type TLSOptions struct {
+	CertFile     string
+	KeyFile      string
+	ClientCAFile string
+	MinVersion   uint16
+	CipherSuites []uint16
+	ClientAuth   tls.ClientAuthType
+}
+
+// CertReloader serves the listener certificate and the client CA pool and
+// reloads them when the underlying files change, so certificates can be
+// rotated without restarting the server.
+type CertReloader struct {
+	options   TLSOptions
+	cert      atomic.Pointer[tls.Certificate]
+	clientCAs atomic.Pointer[x509.CertPool]
+	modTimes  map[string]time.Time
+}
+
+// NewCertReloader loads the certificate, key and client CA files.
+func NewCertReloader(options TLSOptions) (*CertReloader, error) {
+	r := &CertReloader{options: options, modTimes: make(map[string]time.Time)}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+	r.modTimes = r.currentModTimes()
+	return r, nil
+}
+
+// TLSConfig returns a tls.Config that always uses the most recently loaded files
+// and negotiates the given ALPN protocols ("h2" for gRPC).
+func (r *CertReloader) TLSConfig(nextProtos ...string) *tls.Config {
+	return &tls.Config{
+		MinVersion: r.options.MinVersion,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return &tls.Config{
+				MinVersion:   r.options.MinVersion,
+				CipherSuites: r.options.CipherSuites,
+				Certificates: []tls.Certificate{*r.cert.Load()},
+				ClientAuth:   r.options.ClientAuth,
+				ClientCAs:    r.clientCAs.Load(),
+				NextProtos:   nextProtos,
+			}, nil
+		},
+	}
+}
+
+// Watch checks the files every interval until ctx is done and reloads them
+// when one has changed. A failed reload keeps the previous certificates.
+func (r *CertReloader) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		modTimes := r.currentModTimes()
+		if mapsEqual(modTimes, r.modTimes) {
+			continue
+		}
+		if err := r.reload(); err != nil {
+			slog.Error("Failed to reload TLS certificates, keeping the previous ones", "error", err)
+			continue
+		}
+		r.modTimes = modTimes
+		slog.Info("Reloaded TLS certificates")
+	}
+}
+
+func (r *CertReloader) reload() error {
+	cert, err := tls.LoadX509KeyPair(r.options.CertFile, r.options.KeyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load TLS key pair: %w", err)
+	}
+
+	var pool *x509.CertPool
+	if r.options.ClientCAFile != "" {
+		pem, err := os.ReadFile(r.options.ClientCAFile)
+		if err != nil {
+			return fmt.Errorf("failed to read client CA file: %w", err)
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no certificates found in client CA file %s", r.options.ClientCAFile)
+		}
+	}
+
+	r.cert.Store(&cert)
+	r.clientCAs.Store(pool)
+	return nil
+}
+
+func (r *CertReloader) currentModTimes() map[string]time.Time {
+	modTimes := make(map[string]time.Time)
+	for _, path := range []string{r.options.CertFile, r.options.KeyFile, r.options.ClientCAFile} {
+		if path == "" {
+			continue
+		}
+		if info, err := os.Stat(path); err == nil {
+			modTimes[path] = info.ModTime()
+		}
+	}
+	return modTimes
+}
+
+func mapsEqual(a, b map[string]time.Time) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if !b[k].Equal(v) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,56 @@
+package server
+
+import (
+	"sync"
+	"time"
+)
+
+// ttlCache maps keys to values that expire ttl after they are stored. It
+// holds at most maxSize entries and is safe for concurrent use.
+type ttlCache[V any] struct {
+	ttl     time.Duration
+	maxSize int
+
+	mu      sync.Mutex
+	entries map[string]ttlEntry[V]
+}
+
+type ttlEntry[V any] struct {
+	value   V
+	expires time.Time
+}
+
+func newTTLCache[V any](ttl time.Duration, maxSize int) *ttlCache[V] {
+	return &ttlCache[V]{ttl: ttl, maxSize: maxSize, entries: make(map[string]ttlEntry[V])}
+}
+
+// get returns the value stored under key, if it has not expired.
+func (c *ttlCache[V]) get(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok || time.Now().After(entry.expires) {
+		var zero V
+		return zero, false
+	}
+	return entry.value, true
+}
+
+// put stores value under key. Expired entries are dropped once the cache is
+// full, and value is not stored if that frees no room.
+func (c *ttlCache[V]) put(key string, value V) {
+	now := time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= c.maxSize {
+		for k, entry := range c.entries {
+			if now.After(entry.expires) {
+				delete(c.entries, k)
+			}
+		}
+		if len(c.entries) >= c.maxSize {
+			return
+		}
+	}
+	c.entries[key] = ttlEntry[V]{value: value, expires: now.Add(c.ttl)}
+}
@@ -0,0 +1,559 @@
+package server
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/Pratham700/github-search-service/internal/github"
+	"github.com/Pratham700/github-search-service/internal/store"
+	pb "github.com/Pratham700/github-search-service/proto/proto"
+)
+
+const (
+	defaultWatchPageSize = 20
+	// subscribeBatchSize is the number of events SubscribeWatch reads from the store at a time.
+	subscribeBatchSize = 100
+)
+
+// WatchConfig configures search watches.
+type WatchConfig struct {
+	// MinInterval is the shortest schedule a watch may have.
+	MinInterval time.Duration
+	// MaxResults is the number of hits fetched, and compared, per run.
+	MaxResults int
+	// MaxEvents is the number of events kept per watch; 0 keeps all.
+	MaxEvents int
+}
+
+// Watches holds the state of search watches. Watches run with the GitHub
+// token their owner created them with, so they see only what the owner can
+// see, and are charged to the quotas of their owner. The token and the
+// webhook secret are stored encrypted with AES-GCM, bound to the watch ID
+// and owner.
+type Watches struct {
+	store  *store.Store
+	config WatchConfig
+	tokens cipher.AEAD
+
+	mu sync.Mutex
+	// subscribers holds, per watch ID, the channels of SubscribeWatch streams,
+	// signalled when the watch records events or is deleted.
+	subscribers map[string]map[chan struct{}]struct{}
+	// done is closed by Shutdown to end the SubscribeWatch streams.
+	done     chan struct{}
+	shutdown sync.Once
+}
+
+// NewWatches creates Watches stored in st whose owners' tokens and webhook
+// secrets are encrypted with key, an AES-256 key.
+func NewWatches(st *store.Store, config WatchConfig, key []byte) (*Watches, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid token key: %w", err)
+	}
+	tokens, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &Watches{
+		store:       st,
+		config:      config,
+		tokens:      tokens,
+		subscribers: make(map[string]map[chan struct{}]struct{}),
+		done:        make(chan struct{}),
+	}, nil
+}
+
+// sealToken encrypts the token of the owner of the watch with the given ID.
+func (w *Watches) sealToken(id, owner, token string) ([]byte, error) {
+	return w.seal(token, id+"\x00"+owner)
+}
+
+// openToken decrypts the token sealed for the watch.
+func (w *Watches) openToken(watch *pb.Watch, sealed []byte) (string, error) {
+	return w.open(sealed, watch.GetId()+"\x00"+watch.GetOwner())
+}
+
+// sealWebhookSecret encrypts the webhook secret of the watch with the given
+// ID. It is bound to a different context than the token, so neither can be
+// passed off as the other.
+func (w *Watches) sealWebhookSecret(id, owner, secret string) ([]byte, error) {
+	return w.seal(secret, id+"\x00"+owner+"\x00webhook_secret")
+}
+
+// webhookSecret loads and decrypts the webhook secret of watch. Watches
+// created before secrets were sealed keep theirs in the watch itself.
+func (w *Watches) webhookSecret(watch *pb.Watch) (string, error) {
+	sealed, err := w.store.WatchWebhookSecret(watch.GetId())
+	if errors.Is(err, store.ErrNotFound) && watch.GetWebhookSecret() != "" {
+		return watch.GetWebhookSecret(), nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return w.open(sealed, watch.GetId()+"\x00"+watch.GetOwner()+"\x00webhook_secret")
+}
+
+// seal encrypts plaintext bound to aad, prefixing the random nonce.
+func (w *Watches) seal(plaintext, aad string) ([]byte, error) {
+	nonce := make([]byte, w.tokens.NonceSize(), w.tokens.NonceSize()+len(plaintext)+w.tokens.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return w.tokens.Seal(nonce, nonce, []byte(plaintext), []byte(aad)), nil
+}
+
+// open decrypts a value sealed by seal with the same aad.
+func (w *Watches) open(sealed []byte, aad string) (string, error) {
+	if len(sealed) < w.tokens.NonceSize() {
+		return "", errors.New("sealed value is too short")
+	}
+	nonce, ciphertext := sealed[:w.tokens.NonceSize()], sealed[w.tokens.NonceSize():]
+	plaintext, err := w.tokens.Open(nil, nonce, ciphertext, []byte(aad))
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// Shutdown ends the SubscribeWatch streams, which would otherwise hold up a
+// graceful stop of the gRPC server until the clients disconnect.
+func (w *Watches) Shutdown() {
+	w.shutdown.Do(func() { close(w.done) })
+}
+
+// subscribe returns a channel that is signalled when the watch with the
+// given ID changes, and a function that releases it.
+func (w *Watches) subscribe(id string) (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.subscribers[id] == nil {
+		w.subscribers[id] = make(map[chan struct{}]struct{})
+	}
+	w.subscribers[id][ch] = struct{}{}
+
+	return ch, func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		delete(w.subscribers[id], ch)
+		if len(w.subscribers[id]) == 0 {
+			delete(w.subscribers, id)
+		}
+	}
+}
+
+// notify signals the subscribers of the watch with the given ID. A signal
+// that is already pending covers the new one.
+func (w *Watches) notify(id string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for ch := range w.subscribers[id] {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// CreateWatch implements the CreateWatch gRPC method. The watch runs with
+// the caller's GitHub token, which is stored encrypted until the watch is
+// deleted.
+func (s *GithubSearchServer) CreateWatch(ctx context.Context, req *pb.CreateWatchRequest) (*pb.Watch, error) {
+	identity, err := s.watchCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	token, err := GetAuthTokenFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	watch := req.GetWatch()
+	if watch.GetRequest() == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "'request' is required")
+	}
+	if _, err := s.validateHitsRequest(watch.GetRequest()); err != nil {
+		return nil, err
+	}
+	if err := watch.GetInterval().CheckValid(); err != nil || watch.GetInterval().AsDuration() < s.watches.config.MinInterval {
+		return nil, status.Errorf(codes.InvalidArgument, "'interval' must be at least %s", s.watches.config.MinInterval)
+	}
+	if err := s.validateWebhook(watch); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	created := &pb.Watch{
+		Request:     proto.Clone(watch.GetRequest()).(*pb.SearchRequest),
+		Interval:    watch.GetInterval(),
+		Owner:       identity,
+		CreateTime:  timestamppb.New(now),
+		NextRunTime: timestamppb.New(now),
+		WebhookUrl:  watch.GetWebhookUrl(),
+	}
+	err = s.watches.store.CreateWatch(created, func(id string) ([]byte, []byte, error) {
+		sealedToken, err := s.watches.sealToken(id, identity, token)
+		if err != nil || watch.GetWebhookSecret() == "" {
+			return sealedToken, nil, err
+		}
+		sealedSecret, err := s.watches.sealWebhookSecret(id, identity, watch.GetWebhookSecret())
+		return sealedToken, sealedSecret, err
+	})
+	if err != nil {
+		return nil, watchError(err)
+	}
+	return withoutSecret(created), nil
+}
+
+// GetWatch implements the GetWatch gRPC method.
+func (s *GithubSearchServer) GetWatch(ctx context.Context, req *pb.GetWatchRequest) (*pb.Watch, error) {
+	identity, err := s.watchCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	watch, err := s.ownedWatch(identity, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return withoutSecret(watch), nil
+}
+
+// ListWatches implements the ListWatches gRPC method.
+func (s *GithubSearchServer) ListWatches(ctx context.Context, req *pb.ListWatchesRequest) (*pb.ListWatchesResponse, error) {
+	identity, err := s.watchCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pageSize, err := watchPageSize(req.GetPageSize())
+	if err != nil {
+		return nil, err
+	}
+
+	watches, nextPageToken, err := s.watches.store.ListWatches(func(watch *pb.Watch) bool {
+		return watch.GetOwner() == identity
+	}, pageSize, req.GetPageToken())
+	if err != nil {
+		return nil, watchError(err)
+	}
+	for i, watch := range watches {
+		watches[i] = withoutSecret(watch)
+	}
+	return &pb.ListWatchesResponse{Watches: watches, NextPageToken: nextPageToken}, nil
+}
+
+// DeleteWatch implements the DeleteWatch gRPC method.
+func (s *GithubSearchServer) DeleteWatch(ctx context.Context, req *pb.DeleteWatchRequest) (*pb.DeleteWatchResponse, error) {
+	identity, err := s.watchCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.ownedWatch(identity, req.GetId()); err != nil {
+		return nil, err
+	}
+	if err := s.watches.store.DeleteWatch(req.GetId()); err != nil {
+		return nil, watchError(err)
+	}
+	s.watches.notify(req.GetId())
+	return &pb.DeleteWatchResponse{}, nil
+}
+
+// ListWatchEvents implements the ListWatchEvents gRPC method.
+func (s *GithubSearchServer) ListWatchEvents(ctx context.Context, req *pb.ListWatchEventsRequest) (*pb.ListWatchEventsResponse, error) {
+	identity, err := s.watchCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.ownedWatch(identity, req.GetWatchId()); err != nil {
+		return nil, err
+	}
+	pageSize, err := watchPageSize(req.GetPageSize())
+	if err != nil {
+		return nil, err
+	}
+
+	events, nextPageToken, err := s.watches.store.ListWatchEvents(req.GetWatchId(), pageSize, req.GetPageToken())
+	if err != nil {
+		return nil, watchError(err)
+	}
+	return &pb.ListWatchEventsResponse{Events: events, NextPageToken: nextPageToken}, nil
+}
+
+// SubscribeWatch implements the SubscribeWatch gRPC method. Events are read
+// back from the store whenever the scheduler records new ones, so a slow
+// client only delays its own stream, and a client that reconnects with the
+// cursor of the last event it received misses none that are still kept.
+func (s *GithubSearchServer) SubscribeWatch(req *pb.SubscribeWatchRequest, stream grpc.ServerStreamingServer[pb.WatchEvent]) error {
+	ctx := stream.Context()
+	identity, err := s.watchCaller(ctx)
+	if err != nil {
+		return err
+	}
+	id := req.GetWatchId()
+	if _, err := s.ownedWatch(identity, id); err != nil {
+		return err
+	}
+
+	// Subscribe before looking up the newest event so that none recorded in
+	// between is missed
+	updates, unsubscribe := s.watches.subscribe(id)
+	defer unsubscribe()
+	cursor := req.GetCursor()
+	if cursor == "" {
+		if cursor, err = s.watches.store.LatestWatchEventCursor(id); err != nil {
+			return watchError(err)
+		}
+	}
+
+	for {
+		events, err := s.watches.store.WatchEventsAfter(id, cursor, subscribeBatchSize)
+		if err != nil {
+			return watchError(err)
+		}
+		for _, event := range events {
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+			cursor = event.GetCursor()
+		}
+		if len(events) == subscribeBatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-s.watches.done:
+			return status.Errorf(codes.Unavailable, "server is shutting down")
+		case <-updates:
+		}
+		if _, err := s.ownedWatch(identity, id); err != nil {
+			// Deleted
+			return err
+		}
+	}
+}
+
+// RunWatches runs the watches that are due every interval until ctx is done.
+func (s *GithubSearchServer) RunWatches(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		due, err := s.watches.store.DueWatches(time.Now())
+		if err != nil {
+			slog.Error("Failed to load due watches", "error", err)
+		}
+		for _, watch := range due {
+			if ctx.Err() != nil {
+				return
+			}
+			events := s.runWatch(ctx, watch)
+			if s.webhooks != nil {
+				if err := s.webhooks.enqueue(watch, events); err != nil {
+					slog.Error("Failed to store webhook delivery", "watch", watch.GetId(), "error", err)
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runWatch runs watch once and records the outcome.
+func (s *GithubSearchServer) runWatch(ctx context.Context, watch *pb.Watch) []*pb.WatchEvent {
+	now := time.Now()
+	run := store.WatchRun{
+		Time:    now,
+		NextRun: now.Add(watch.GetInterval().AsDuration()),
+	}
+	fetched, err := s.fetchWatchHits(ctx, watch)
+	run.Hits, run.Truncated, run.Incomplete, run.Err = fetched.hits, fetched.truncated, fetched.incomplete, err
+	if ctx.Err() != nil {
+		// Shutting down; the watch stays due and runs again after the restart
+		return nil
+	}
+	if s.auditor != nil {
+		s.auditor.recordWatchRun(watch, now, len(run.Hits), run.Err)
+	}
+
+	_, events, err := s.watches.store.RecordWatchRun(watch.GetId(), run, s.watches.config.MaxEvents)
+	if len(events) > 0 {
+		s.watches.notify(watch.GetId())
+	}
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		// Deleted while it was running
+	case err != nil:
+		slog.Error("Failed to record watch run", "watch", watch.GetId(), "error", err)
+	case run.Err != nil:
+		slog.Warn("Watch run failed", "watch", watch.GetId(), "error", run.Err)
+	default:
+		slog.Debug("Watch run completed", "watch", watch.GetId(), "hits", len(run.Hits), "events", len(events))
+	}
+	return events
+}
+
+// fetchWatchHits fetches up to MaxResults hits of the watched search with
+// the owner's token, charging each page to the owner's quotas.
+func (s *GithubSearchServer) fetchWatchHits(ctx context.Context, watch *pb.Watch) (fetchedHits, error) {
+	sealed, err := s.watches.store.WatchToken(watch.GetId())
+	if errors.Is(err, store.ErrNotFound) {
+		return fetchedHits{}, errors.New("no GitHub token is stored for the watch; recreate it")
+	}
+	if err != nil {
+		return fetchedHits{}, fmt.Errorf("failed to load the owner's token: %w", err)
+	}
+	token, err := s.watches.openToken(watch, sealed)
+	if err != nil {
+		return fetchedHits{}, fmt.Errorf("failed to decrypt the owner's token; recreate the watch if watches.token_key changed: %w", err)
+	}
+	return s.fetchHits(ctx, watch.GetRequest(), token, watch.GetOwner(), s.watches.config.MaxResults, false)
+}
+
+// fetchedHits are the hits found by fetchHits.
+type fetchedHits struct {
+	hits []*pb.WatchHit
+	// total is the number of hits GitHub reported before filtering, which
+	// may exceed the hits it can return.
+	total int
+	// truncated is set if GitHub reported more hits than were fetched, and
+	// incomplete if it reported incomplete results; hits may be missing
+	// either way.
+	truncated, incomplete bool
+}
+
+// fetchHits fetches up to maxResults hits of req that pass its filters with
+// token, one page at a time, charging each page to the quotas of identity,
+// except the first one if prepaid, i.e. already charged for the RPC.
+func (s *GithubSearchServer) fetchHits(ctx context.Context, req *pb.SearchRequest, token, identity string, maxResults int, prepaid bool) (fetchedHits, error) {
+	params, err := s.validateHitsRequest(req)
+	if err != nil {
+		return fetchedHits{}, err
+	}
+	filter := newResultFilter(req)
+	lookup := s.newRepositoryLookup(token, identity)
+	perPage := min(maxResults, maxPerPage)
+	params["per_page"] = strconv.Itoa(perPage)
+
+	var fetched fetchedHits
+	items := 0
+	for page := 1; len(fetched.hits) < maxResults; page++ {
+		if s.quota != nil && (page > 1 || !prepaid) {
+			if err := s.quota.Allow(identity); err != nil {
+				return fetchedHits{}, fmt.Errorf("quota exhausted: %w", err)
+			}
+		}
+		params["page"] = strconv.Itoa(page)
+		result, err := s.GitHubClient().SearchCode(ctx, req.GetSearchTerm(), req.GetUser(), token, params)
+		if err != nil {
+			return fetchedHits{}, err
+		}
+		items += len(result.Items)
+		fetched.total = result.TotalCount
+		fetched.incomplete = fetched.incomplete || result.IncompleteResults
+		if s.quota != nil {
+			s.quota.RecordResults(identity, len(result.Items))
+		}
+
+		for _, item := range filterItems(ctx, lookup, filter, result.Items) {
+			fetched.hits = append(fetched.hits, watchHit(item))
+		}
+		if len(result.Items) < perPage || page*perPage >= maxGitHubResults {
+			break
+		}
+	}
+	fetched.truncated = items < fetched.total || len(fetched.hits) > maxResults
+	if len(fetched.hits) > maxResults {
+		fetched.hits = fetched.hits[:maxResults]
+	}
+	fetched.total = max(fetched.total, len(fetched.hits))
+	return fetched, nil
+}
+
+// watchHit converts a GitHub search item into a hit.
+func watchHit(item github.GitHubSearchItem) *pb.WatchHit {
+	return &pb.WatchHit{
+		Repo:    item.Repository.FullName,
+		Path:    item.Path,
+		Sha:     item.SHA,
+		FileUrl: item.HTMLURL,
+	}
+}
+
+// watchCaller returns the caller identity, or an error if watches are disabled.
+func (s *GithubSearchServer) watchCaller(ctx context.Context) (string, error) {
+	identity, err := GetCallerIdentityFromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+	if s.watches == nil {
+		return "", status.Errorf(codes.Unimplemented, "watches are disabled on this server")
+	}
+	return identity, nil
+}
+
+// ownedWatch returns the watch if identity owns it. Other callers' watches
+// are reported as not found.
+func (s *GithubSearchServer) ownedWatch(identity, id string) (*pb.Watch, error) {
+	watch, err := s.watches.store.GetWatch(id)
+	if err != nil {
+		return nil, watchError(err)
+	}
+	if watch.GetOwner() != identity {
+		return nil, watchError(store.ErrNotFound)
+	}
+	return watch, nil
+}
+
+// withoutSecret returns watch without its webhook secret, which is never
+// returned to callers.
+func withoutSecret(watch *pb.Watch) *pb.Watch {
+	if watch.GetWebhookSecret() == "" {
+		return watch
+	}
+	watch = proto.Clone(watch).(*pb.Watch)
+	watch.WebhookSecret = ""
+	return watch
+}
+
+func watchPageSize(pageSize int32) (int, error) {
+	if pageSize < 0 || pageSize > maxPerPage {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid value for 'page_size': must be between 0 and %d", maxPerPage)
+	}
+	if pageSize == 0 {
+		return defaultWatchPageSize, nil
+	}
+	return int(pageSize), nil
+}
+
+// watchError maps store errors to gRPC status errors.
+func watchError(err error) error {
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		return status.Errorf(codes.NotFound, "watch not found")
+	case errors.Is(err, store.ErrInvalidPageToken):
+		return status.Errorf(codes.InvalidArgument, "invalid value for 'page_token'")
+	case errors.Is(err, store.ErrInvalidCursor):
+		return status.Errorf(codes.InvalidArgument, "invalid value for 'cursor'")
+	default:
+		return status.Errorf(codes.Internal, "failed to access watches: %v", err)
+	}
+}
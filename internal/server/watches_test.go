@@ -0,0 +1,122 @@
+package server
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/Pratham700/github-search-service/internal/github"
+	"github.com/Pratham700/github-search-service/internal/store"
+	pb "github.com/Pratham700/github-search-service/proto/proto"
+)
+
+// eventStream is a SubscribeWatch stream that collects the events sent and
+// ends the call once it has want of them.
+type eventStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	cancel context.CancelFunc
+	want   int
+	events []*pb.WatchEvent
+}
+
+func (s *eventStream) Context() context.Context { return s.ctx }
+
+func (s *eventStream) Send(event *pb.WatchEvent) error {
+	s.events = append(s.events, event)
+	if len(s.events) == s.want {
+		s.cancel()
+	}
+	return nil
+}
+
+// subscribe runs SubscribeWatch from cursor as the owner until want events
+// were sent, and returns them formatted as "TYPE path" strings.
+func subscribe(t *testing.T, s *GithubSearchServer, id, cursor string, want int) []string {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(setAuthTokenInContext(context.Background(), "owner"), 5*time.Second)
+	defer cancel()
+	stream := &eventStream{ctx: ctx, cancel: cancel, want: want}
+	err := s.SubscribeWatch(&pb.SubscribeWatchRequest{WatchId: id, Cursor: cursor}, stream)
+	if status.Code(err) != codes.Canceled {
+		t.Fatalf("SubscribeWatch() error = %v, want Canceled", err)
+	}
+	var got []string
+	for _, event := range stream.events {
+		got = append(got, event.GetType().String()+" "+event.GetHit().GetPath())
+	}
+	return got
+}
+
+func TestSubscribeWatchResume(t *testing.T) {
+	st, err := store.Open(filepath.Join(t.TempDir(), "store.db"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	t.Cleanup(func() { st.Close() })
+	watches, err := NewWatches(st, WatchConfig{}, make([]byte, 32))
+	if err != nil {
+		t.Fatalf("NewWatches() error = %v", err)
+	}
+	s, err := NewGithubSearchServer(github.NewGitHubClient(github.ClientConfig{}), Options{Watches: watches})
+	if err != nil {
+		t.Fatalf("NewGithubSearchServer() error = %v", err)
+	}
+	ctx := setAuthTokenInContext(context.Background(), "owner")
+	watch, err := s.CreateWatch(ctx, &pb.CreateWatchRequest{Watch: &pb.Watch{
+		Request:  &pb.SearchRequest{SearchTerm: "foo"},
+		Interval: durationpb.New(time.Hour),
+	}})
+	if err != nil {
+		t.Fatalf("CreateWatch() error = %v", err)
+	}
+
+	// The clock steps back between runs, which must not reorder the events
+	start := time.Unix(1700000000, 0)
+	runs := [][]*pb.WatchHit{
+		{{Repo: "o/r", Path: "a.go"}},
+		{{Repo: "o/r", Path: "a.go"}, {Repo: "o/r", Path: "b.go"}},
+		{{Repo: "o/r", Path: "a.go"}, {Repo: "o/r", Path: "c.go"}},
+	}
+	for i, hits := range runs {
+		run := store.WatchRun{Time: start.Add(-time.Duration(i) * time.Hour), Hits: hits}
+		if _, _, err := st.RecordWatchRun(watch.GetId(), run, 0); err != nil {
+			t.Fatalf("RecordWatchRun() error = %v", err)
+		}
+	}
+
+	all, err := st.WatchEventsAfter(watch.GetId(), "", 10)
+	if err != nil {
+		t.Fatalf("WatchEventsAfter() error = %v", err)
+	}
+	if len(all) != 3 {
+		t.Fatalf("got %d events, want 3", len(all))
+	}
+	tests := []struct {
+		name   string
+		cursor string
+		want   []string
+	}{
+		{"after the first event", all[0].GetCursor(), []string{"TYPE_REMOVED b.go", "TYPE_ADDED c.go"}},
+		{"after the second event", all[1].GetCursor(), []string{"TYPE_ADDED c.go"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := subscribe(t, s, watch.GetId(), tt.cursor, len(tt.want)); !slices.Equal(got, tt.want) {
+				t.Errorf("events = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	err = s.SubscribeWatch(&pb.SubscribeWatchRequest{WatchId: watch.GetId(), Cursor: "AAAA"}, &eventStream{ctx: ctx})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("SubscribeWatch() with an invalid cursor error = %v, want InvalidArgument", err)
+	}
+}
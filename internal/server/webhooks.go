@@ -0,0 +1,371 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/Pratham700/github-search-service/internal/store"
+	pb "github.com/Pratham700/github-search-service/proto/proto"
+)
+
+const (
+	// WebhookSignatureHeader carries the HMAC-SHA256 signature of a webhook
+	// payload, in the same format as GitHub's webhooks: "sha256=<hex digest>".
+	WebhookSignatureHeader = "X-Hub-Signature-256"
+	// WebhookDeliveryHeader carries the ID of a webhook delivery, which stays
+	// the same across retries.
+	WebhookDeliveryHeader = "X-Delivery-Id"
+
+	minWebhookSecretLen = 16
+)
+
+// WebhookConfig configures the delivery of new watch hits to webhooks.
+type WebhookConfig struct {
+	// MaxAttempts is the total number of attempts per delivery, including the
+	// first one, before it is moved to the dead letters.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry; it doubles on each subsequent retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between retries.
+	MaxBackoff time.Duration
+	// Timeout bounds each attempt.
+	Timeout time.Duration
+	// MaxDeliveries is the number of deliveries kept per watch; 0 keeps all.
+	MaxDeliveries int
+	// AllowedHosts, if set, lists the only hosts webhook URLs may point to.
+	AllowedHosts []string
+	// AllowPrivateNetworks lets webhooks reach non-public addresses, which
+	// are refused by default so that watches cannot probe the server's
+	// network or cloud metadata endpoints.
+	AllowPrivateNetworks bool
+}
+
+// errWebhookAddress is returned for webhooks that resolve to a refused address.
+var errWebhookAddress = errors.New("webhook address is not public")
+
+// sharedAddressSpace is the carrier-grade NAT range, which is not public
+// either but which netip does not classify as private.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// Webhooks posts the hits that watches find to the watches' webhook URLs.
+// Deliveries are stored before they are attempted, so that pending retries
+// survive restarts.
+type Webhooks struct {
+	store   *store.Store
+	watches *Watches // Holds the sealed webhook secrets
+	config  WebhookConfig
+	client  *http.Client
+	// wake is signalled when a delivery is created, so that it is attempted
+	// without waiting for the next poll.
+	wake chan struct{}
+}
+
+// NewWebhooks creates Webhooks for watches, stored along with them.
+// Redirects are never followed, and unless config.AllowPrivateNetworks is
+// set every address a webhook resolves to is checked when it is dialed, and
+// proxies are bypassed.
+func NewWebhooks(watches *Watches, config WebhookConfig) *Webhooks {
+	dialer := &net.Dialer{Timeout: config.Timeout}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if !config.AllowPrivateNetworks {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil || !publicAddress(addrPort.Addr()) {
+				return fmt.Errorf("%w: %s", errWebhookAddress, address)
+			}
+			return nil
+		}
+		transport.Proxy = nil
+	}
+	transport.DialContext = dialer.DialContext
+	return &Webhooks{
+		store:   watches.store,
+		watches: watches,
+		config:  config,
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   config.Timeout,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		wake: make(chan struct{}, 1),
+	}
+}
+
+// publicAddress reports whether addr is a public unicast address, i.e. not
+// loopback, private, link-local (such as 169.254.169.254), multicast or
+// unspecified.
+func publicAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsGlobalUnicast() && !addr.IsPrivate() && !sharedAddressSpace.Contains(addr)
+}
+
+// SignWebhookPayload returns the value of WebhookSignatureHeader for body
+// signed with secret. Receivers verify a delivery by computing it themselves
+// and comparing the two with hmac.Equal.
+func SignWebhookPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// ListDeliveries implements the ListDeliveries gRPC method.
+func (s *GithubSearchServer) ListDeliveries(ctx context.Context, req *pb.ListDeliveriesRequest) (*pb.ListDeliveriesResponse, error) {
+	identity, err := s.watchCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if s.webhooks == nil {
+		return nil, status.Errorf(codes.Unimplemented, "webhooks are disabled on this server")
+	}
+	if _, err := s.ownedWatch(identity, req.GetWatchId()); err != nil {
+		return nil, err
+	}
+	if _, ok := pb.WebhookDelivery_State_name[int32(req.GetState())]; !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid state option: %v", req.GetState())
+	}
+	pageSize, err := watchPageSize(req.GetPageSize())
+	if err != nil {
+		return nil, err
+	}
+
+	filter := func(delivery *pb.WebhookDelivery) bool {
+		return req.GetState() == pb.WebhookDelivery_STATE_UNSPECIFIED || delivery.GetState() == req.GetState()
+	}
+	deliveries, nextPageToken, err := s.webhooks.store.ListDeliveries(req.GetWatchId(), filter, pageSize, req.GetPageToken())
+	if err != nil {
+		return nil, watchError(err)
+	}
+	return &pb.ListDeliveriesResponse{Deliveries: deliveries, NextPageToken: nextPageToken}, nil
+}
+
+// validateWebhook checks the webhook settings of a new watch.
+func (s *GithubSearchServer) validateWebhook(watch *pb.Watch) error {
+	if watch.GetWebhookUrl() == "" {
+		if watch.GetWebhookSecret() != "" {
+			return status.Errorf(codes.InvalidArgument, "'webhook_secret' requires 'webhook_url'")
+		}
+		return nil
+	}
+	if s.webhooks == nil {
+		return status.Errorf(codes.FailedPrecondition, "webhooks are disabled on this server")
+	}
+	u, err := url.Parse(watch.GetWebhookUrl())
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return status.Errorf(codes.InvalidArgument, "'webhook_url' must be an absolute http or https URL")
+	}
+	config := s.webhooks.config
+	if len(config.AllowedHosts) > 0 && !slices.ContainsFunc(config.AllowedHosts, func(host string) bool {
+		return strings.EqualFold(host, u.Hostname())
+	}) {
+		return status.Errorf(codes.InvalidArgument, "'webhook_url' host %q is not allowed on this server", u.Hostname())
+	}
+	if addr, err := netip.ParseAddr(u.Hostname()); err == nil && !config.AllowPrivateNetworks && !publicAddress(addr) {
+		return status.Errorf(codes.InvalidArgument, "'webhook_url' must point to a public address")
+	}
+	if len(watch.GetWebhookSecret()) < minWebhookSecretLen {
+		return status.Errorf(codes.InvalidArgument, "'webhook_secret' must be at least %d characters", minWebhookSecretLen)
+	}
+	return nil
+}
+
+// enqueue stores a delivery of the hits added by a run of watch, if the
+// watch has a webhook and the run found any.
+func (w *Webhooks) enqueue(watch *pb.Watch, events []*pb.WatchEvent) error {
+	if watch.GetWebhookUrl() == "" {
+		return nil
+	}
+	var results []*pb.Result
+	var found *timestamppb.Timestamp
+	for _, event := range events {
+		if event.GetType() == pb.WatchEvent_TYPE_ADDED {
+			results = append(results, &pb.Result{FileUrl: event.GetHit().GetFileUrl(), Repo: event.GetHit().GetRepo()})
+			found = event.GetTime()
+		}
+	}
+	if len(results) == 0 {
+		return nil
+	}
+
+	now := timestamppb.Now()
+	delivery := &pb.WebhookDelivery{
+		WatchId:         watch.GetId(),
+		Url:             watch.GetWebhookUrl(),
+		State:           pb.WebhookDelivery_STATE_PENDING,
+		CreateTime:      now,
+		NextAttemptTime: now,
+	}
+	err := w.store.CreateDelivery(delivery, w.config.MaxDeliveries, func(id string) (string, error) {
+		payload, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(&pb.WebhookPayload{
+			DeliveryId: id,
+			WatchId:    watch.GetId(),
+			SearchTerm: watch.GetRequest().GetSearchTerm(),
+			Time:       found,
+			Results:    results,
+		})
+		return string(payload), err
+	})
+	if err != nil {
+		return err
+	}
+
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Run attempts the deliveries that are due every interval, and as soon as
+// new ones are created, until ctx is done.
+func (w *Webhooks) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		due, err := w.store.DueDeliveries(time.Now())
+		if err != nil {
+			slog.Error("Failed to load due webhook deliveries", "error", err)
+		}
+		for _, delivery := range due {
+			if ctx.Err() != nil {
+				return
+			}
+			w.deliver(ctx, delivery)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-w.wake:
+		}
+	}
+}
+
+// deliver makes one attempt at delivery and records the outcome: success,
+// a retry after a backoff, or a dead letter once the attempts are exhausted,
+// the receiver rejected the payload or its address was refused. Redirects
+// count as rejections.
+func (w *Webhooks) deliver(ctx context.Context, delivery *pb.WebhookDelivery) {
+	watch, err := w.store.GetWatch(delivery.GetWatchId())
+	if errors.Is(err, store.ErrNotFound) {
+		// The watch was deleted before its deliveries were; stop retrying
+		if err := w.store.DropPendingDelivery(delivery.GetId()); err != nil {
+			slog.Error("Failed to drop webhook delivery of deleted watch", "delivery", delivery.GetId(), "error", err)
+		}
+		return
+	}
+	if err != nil {
+		slog.Error("Failed to load watch of webhook delivery", "delivery", delivery.GetId(), "error", err)
+		return
+	}
+
+	secret, err := w.watches.webhookSecret(watch)
+	if err != nil {
+		slog.Error("Failed to load webhook secret; recreate the watch if watches.token_key changed",
+			"delivery", delivery.GetId(), "watch", watch.GetId(), "error", err)
+		return
+	}
+
+	statusCode, err := w.post(ctx, delivery, secret)
+	if ctx.Err() != nil {
+		// Shutting down; the delivery stays due and is retried after the restart
+		return
+	}
+
+	now := time.Now()
+	updated, updateErr := w.store.UpdateDelivery(delivery.GetWatchId(), delivery.GetId(), func(d *pb.WebhookDelivery) {
+		d.Attempts++
+		d.LastStatusCode = int32(statusCode)
+		d.LastAttemptTime = timestamppb.New(now)
+		d.NextAttemptTime = nil
+		d.LastError = ""
+		switch {
+		case err == nil:
+			d.State = pb.WebhookDelivery_STATE_SUCCEEDED
+		case int(d.Attempts) >= w.config.MaxAttempts || errors.Is(err, errWebhookAddress) || !retryableWebhookStatus(statusCode):
+			d.State = pb.WebhookDelivery_STATE_DEAD_LETTER
+			d.LastError = err.Error()
+		default:
+			d.LastError = err.Error()
+			d.NextAttemptTime = timestamppb.New(now.Add(w.backoff(int(d.Attempts))))
+		}
+	})
+	switch {
+	case errors.Is(updateErr, store.ErrNotFound):
+		// Deleted while it was being delivered
+	case updateErr != nil:
+		slog.Error("Failed to record webhook delivery", "delivery", delivery.GetId(), "error", updateErr)
+	case updated.GetState() == pb.WebhookDelivery_STATE_DEAD_LETTER:
+		slog.Warn("Webhook delivery failed permanently", "watch", updated.GetWatchId(), "delivery", updated.GetId(), "attempts", updated.GetAttempts(), "error", updated.GetLastError())
+	case updated.GetState() == pb.WebhookDelivery_STATE_PENDING:
+		slog.Info("Webhook delivery failed, will retry", "watch", updated.GetWatchId(), "delivery", updated.GetId(), "attempts", updated.GetAttempts(), "error", updated.GetLastError())
+	default:
+		slog.Debug("Webhook delivered", "watch", updated.GetWatchId(), "delivery", updated.GetId(), "attempts", updated.GetAttempts())
+	}
+}
+
+// post sends the payload of delivery, signed with secret. It returns the
+// HTTP status of the response, or 0 if none was received, and an error
+// unless the status is 2xx.
+func (w *Webhooks) post(ctx context.Context, delivery *pb.WebhookDelivery, secret string) (int, error) {
+	body := []byte(delivery.GetPayload())
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.GetUrl(), bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "github-search-service")
+	req.Header.Set(WebhookDeliveryHeader, delivery.GetId())
+	req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(secret, body))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	// Drain a bounded amount so that the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// backoff returns the delay before the retry that follows the given number of attempts.
+func (w *Webhooks) backoff(attempts int) time.Duration {
+	backoff := w.config.InitialBackoff
+	for i := 1; i < attempts && backoff < w.config.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, w.config.MaxBackoff)
+}
+
+// retryableWebhookStatus reports whether a failed attempt is worth retrying:
+// transport errors, timeouts, throttling and server errors are, other client
+// errors are not.
+func retryableWebhookStatus(statusCode int) bool {
+	return statusCode == 0 || statusCode == http.StatusRequestTimeout ||
+		statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError
+}
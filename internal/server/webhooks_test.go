@@ -0,0 +1,306 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/Pratham700/github-search-service/internal/github"
+	"github.com/Pratham700/github-search-service/internal/store"
+	"github.com/Pratham700/github-search-service/internal/util"
+	pb "github.com/Pratham700/github-search-service/proto/proto"
+)
+
+const testWebhookSecret = "0123456789abcdef"
+
+// webhookReceiver is an httptest server answering with a scripted sequence
+// of statuses, repeating the last one, and recording the requests it gets.
+type webhookReceiver struct {
+	*httptest.Server
+	mu       sync.Mutex
+	statuses []int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func newWebhookReceiver(t *testing.T, statuses ...int) *webhookReceiver {
+	r := &webhookReceiver{statuses: statuses}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		code := r.statuses[min(len(r.requests), len(r.statuses)-1)]
+		r.requests = append(r.requests, req)
+		r.bodies = append(r.bodies, body)
+		if code >= 300 && code < 400 {
+			w.Header().Set("Location", "/elsewhere")
+		}
+		w.WriteHeader(code)
+	}))
+	t.Cleanup(r.Close)
+	return r
+}
+
+func (r *webhookReceiver) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.requests)
+}
+
+// newWebhookTest creates Webhooks in a new store along with a watch posting
+// to url, and enqueues one delivery for it.
+func newWebhookTest(t *testing.T, config WebhookConfig, url string) (*store.Store, *Webhooks, *pb.Watch) {
+	t.Helper()
+	st, err := store.Open(filepath.Join(t.TempDir(), "store.db"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	t.Cleanup(func() { st.Close() })
+
+	config = WebhookConfig{
+		MaxAttempts:          max(config.MaxAttempts, 1),
+		InitialBackoff:       time.Millisecond,
+		MaxBackoff:           time.Millisecond,
+		Timeout:              5 * time.Second,
+		AllowPrivateNetworks: config.AllowPrivateNetworks,
+	}
+	watches, err := NewWatches(st, WatchConfig{}, make([]byte, 32))
+	if err != nil {
+		t.Fatalf("NewWatches() error = %v", err)
+	}
+	w := NewWebhooks(watches, config)
+	watch := &pb.Watch{
+		Request:    &pb.SearchRequest{SearchTerm: "foo"},
+		Owner:      "token:" + util.HashToken("owner"),
+		WebhookUrl: url,
+	}
+	err = st.CreateWatch(watch, func(id string) ([]byte, []byte, error) {
+		secret, err := watches.sealWebhookSecret(id, watch.GetOwner(), testWebhookSecret)
+		return nil, secret, err
+	})
+	if err != nil {
+		t.Fatalf("CreateWatch() error = %v", err)
+	}
+	events := []*pb.WatchEvent{{
+		Type: pb.WatchEvent_TYPE_ADDED,
+		Time: timestamppb.Now(),
+		Hit:  &pb.WatchHit{Repo: "o/r", Path: "a.go", FileUrl: "https://github.com/o/r/blob/x/a.go"},
+	}}
+	if err := w.enqueue(watch, events); err != nil {
+		t.Fatalf("enqueue() error = %v", err)
+	}
+	return st, w, watch
+}
+
+// deliverAll attempts the pending deliveries until none is left or attempts
+// rounds were made, ignoring the backoff.
+func deliverAll(t *testing.T, st *store.Store, w *Webhooks, attempts int) {
+	t.Helper()
+	for i := 0; i < attempts; i++ {
+		due, err := st.DueDeliveries(time.Now().Add(time.Hour))
+		if err != nil {
+			t.Fatalf("DueDeliveries() error = %v", err)
+		}
+		if len(due) == 0 {
+			return
+		}
+		for _, delivery := range due {
+			w.deliver(context.Background(), delivery)
+		}
+	}
+}
+
+func onlyDelivery(t *testing.T, st *store.Store, watchID string) *pb.WebhookDelivery {
+	t.Helper()
+	deliveries, _, err := st.ListDeliveries(watchID, func(*pb.WebhookDelivery) bool { return true }, 10, "")
+	if err != nil {
+		t.Fatalf("ListDeliveries() error = %v", err)
+	}
+	if len(deliveries) != 1 {
+		t.Fatalf("got %d deliveries, want 1", len(deliveries))
+	}
+	return deliveries[0]
+}
+
+func TestWebhookSignature(t *testing.T) {
+	receiver := newWebhookReceiver(t, http.StatusOK)
+	st, w, watch := newWebhookTest(t, WebhookConfig{AllowPrivateNetworks: true}, receiver.URL)
+	deliverAll(t, st, w, 1)
+
+	if receiver.count() != 1 {
+		t.Fatalf("receiver got %d requests, want 1", receiver.count())
+	}
+	req, body := receiver.requests[0], receiver.bodies[0]
+	if got, want := req.Header.Get(WebhookSignatureHeader), SignWebhookPayload(testWebhookSecret, body); got != want {
+		t.Errorf("%s = %q, want %q", WebhookSignatureHeader, got, want)
+	}
+	if got := req.Header.Get(WebhookSignatureHeader); got == SignWebhookPayload("another secret!!", body) {
+		t.Errorf("signature does not depend on the secret")
+	}
+	delivery := onlyDelivery(t, st, watch.GetId())
+	if got := req.Header.Get(WebhookDeliveryHeader); got != delivery.GetId() {
+		t.Errorf("%s = %q, want %q", WebhookDeliveryHeader, got, delivery.GetId())
+	}
+	if !strings.Contains(string(body), `"search_term":"foo"`) {
+		t.Errorf("payload %s lacks the search term", body)
+	}
+	if delivery.GetState() != pb.WebhookDelivery_STATE_SUCCEEDED {
+		t.Errorf("state = %v, want STATE_SUCCEEDED", delivery.GetState())
+	}
+}
+
+func TestWebhookDeliveryOutcomes(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int
+		maxAttempts  int
+		wantState    pb.WebhookDelivery_State
+		wantAttempts int32
+		wantStatus   int32
+	}{
+		{"success", []int{200}, 3, pb.WebhookDelivery_STATE_SUCCEEDED, 1, 200},
+		{"retried after 5xx", []int{503, 500, 204}, 3, pb.WebhookDelivery_STATE_SUCCEEDED, 3, 204},
+		{"retried after 429", []int{429, 200}, 3, pb.WebhookDelivery_STATE_SUCCEEDED, 2, 200},
+		{"dead letter after 4xx", []int{400}, 3, pb.WebhookDelivery_STATE_DEAD_LETTER, 1, 400},
+		{"dead letter after exhausted attempts", []int{500}, 3, pb.WebhookDelivery_STATE_DEAD_LETTER, 3, 500},
+		{"redirect not followed", []int{302}, 3, pb.WebhookDelivery_STATE_DEAD_LETTER, 1, 302},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receiver := newWebhookReceiver(t, tt.statuses...)
+			st, w, watch := newWebhookTest(t, WebhookConfig{MaxAttempts: tt.maxAttempts, AllowPrivateNetworks: true}, receiver.URL)
+			deliverAll(t, st, w, tt.maxAttempts+1)
+
+			delivery := onlyDelivery(t, st, watch.GetId())
+			if delivery.GetState() != tt.wantState || delivery.GetAttempts() != tt.wantAttempts || delivery.GetLastStatusCode() != tt.wantStatus {
+				t.Errorf("delivery = %v after %d attempts with status %d, want %v after %d with %d", delivery.GetState(),
+					delivery.GetAttempts(), delivery.GetLastStatusCode(), tt.wantState, tt.wantAttempts, tt.wantStatus)
+			}
+			if receiver.count() != int(tt.wantAttempts) {
+				t.Errorf("receiver got %d requests, want %d", receiver.count(), tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestWebhookPrivateAddressRefused(t *testing.T) {
+	receiver := newWebhookReceiver(t, http.StatusOK)
+	st, w, watch := newWebhookTest(t, WebhookConfig{MaxAttempts: 3}, receiver.URL)
+	deliverAll(t, st, w, 3)
+
+	delivery := onlyDelivery(t, st, watch.GetId())
+	if delivery.GetState() != pb.WebhookDelivery_STATE_DEAD_LETTER || delivery.GetAttempts() != 1 {
+		t.Errorf("delivery = %v after %d attempts, want STATE_DEAD_LETTER after 1", delivery.GetState(), delivery.GetAttempts())
+	}
+	if receiver.count() != 0 {
+		t.Errorf("receiver got %d requests, want 0", receiver.count())
+	}
+}
+
+func TestValidateWebhook(t *testing.T) {
+	tests := []struct {
+		name   string
+		config WebhookConfig
+		url    string
+		want   codes.Code
+	}{
+		{"public", WebhookConfig{}, "https://hooks.example.com/x", codes.OK},
+		{"metadata endpoint", WebhookConfig{}, "http://169.254.169.254/latest/meta-data", codes.InvalidArgument},
+		{"loopback", WebhookConfig{}, "http://127.0.0.1:8080/", codes.InvalidArgument},
+		{"mapped loopback", WebhookConfig{}, "http://[::ffff:127.0.0.1]/", codes.InvalidArgument},
+		{"private", WebhookConfig{}, "http://10.1.2.3/", codes.InvalidArgument},
+		{"private allowed", WebhookConfig{AllowPrivateNetworks: true}, "http://10.1.2.3/", codes.OK},
+		{"allowed host", WebhookConfig{AllowedHosts: []string{"hooks.example.com"}}, "https://HOOKS.example.com/x", codes.OK},
+		{"other host", WebhookConfig{AllowedHosts: []string{"hooks.example.com"}}, "https://evil.example.com/x", codes.InvalidArgument},
+		{"not http", WebhookConfig{}, "file:///etc/passwd", codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &GithubSearchServer{webhooks: &Webhooks{config: tt.config}}
+			err := s.validateWebhook(&pb.Watch{WebhookUrl: tt.url, WebhookSecret: testWebhookSecret})
+			if got := status.Code(err); got != tt.want {
+				t.Errorf("validateWebhook(%q) = %v, want %v", tt.url, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestListDeliveriesFilter(t *testing.T) {
+	receiver := newWebhookReceiver(t, http.StatusBadRequest)
+	st, w, watch := newWebhookTest(t, WebhookConfig{MaxAttempts: 3, AllowPrivateNetworks: true}, receiver.URL)
+	deliverAll(t, st, w, 1) // The first delivery becomes a dead letter
+	events := []*pb.WatchEvent{{Type: pb.WatchEvent_TYPE_ADDED, Time: timestamppb.Now(), Hit: &pb.WatchHit{Repo: "o/r", Path: "b.go"}}}
+	if err := w.enqueue(watch, events); err != nil {
+		t.Fatalf("enqueue() error = %v", err)
+	}
+
+	s, err := NewGithubSearchServer(github.NewGitHubClient(github.ClientConfig{}), Options{Watches: w.watches, Webhooks: w})
+	if err != nil {
+		t.Fatalf("NewGithubSearchServer() error = %v", err)
+	}
+	other := setAuthTokenInContext(context.Background(), "someone else")
+	if _, err := s.ListDeliveries(other, &pb.ListDeliveriesRequest{WatchId: watch.GetId()}); status.Code(err) != codes.NotFound {
+		t.Fatalf("ListDeliveries() of another caller's watch error = %v, want NotFound", err)
+	}
+
+	ctx := setAuthTokenInContext(context.Background(), "owner")
+	tests := []struct {
+		state pb.WebhookDelivery_State
+		want  int
+	}{
+		{pb.WebhookDelivery_STATE_UNSPECIFIED, 2},
+		{pb.WebhookDelivery_STATE_PENDING, 1},
+		{pb.WebhookDelivery_STATE_DEAD_LETTER, 1},
+		{pb.WebhookDelivery_STATE_SUCCEEDED, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.state.String(), func(t *testing.T) {
+			resp, err := s.ListDeliveries(ctx, &pb.ListDeliveriesRequest{WatchId: watch.GetId(), State: tt.state})
+			if err != nil {
+				t.Fatalf("ListDeliveries() error = %v", err)
+			}
+			if len(resp.GetDeliveries()) != tt.want {
+				t.Errorf("got %d deliveries, want %d", len(resp.GetDeliveries()), tt.want)
+			}
+			for _, delivery := range resp.GetDeliveries() {
+				if tt.state != pb.WebhookDelivery_STATE_UNSPECIFIED && delivery.GetState() != tt.state {
+					t.Errorf("got a delivery in %v", delivery.GetState())
+				}
+			}
+		})
+	}
+}
+
+func TestWebhookSecretSealed(t *testing.T) {
+	st, w, watch := newWebhookTest(t, WebhookConfig{}, "https://example.com/hook")
+	sealed, err := st.WatchWebhookSecret(watch.GetId())
+	if err != nil {
+		t.Fatalf("WatchWebhookSecret() error = %v", err)
+	}
+	if bytes.Contains(sealed, []byte(testWebhookSecret)) {
+		t.Errorf("the stored webhook secret is not encrypted")
+	}
+	if got, err := w.watches.webhookSecret(watch); err != nil || got != testWebhookSecret {
+		t.Errorf("webhookSecret() = %q, %v, want %q", got, err, testWebhookSecret)
+	}
+
+	// Watches created before secrets were sealed keep them in the watch
+	legacy := &pb.Watch{Request: &pb.SearchRequest{SearchTerm: "foo"}, Owner: watch.GetOwner(), WebhookSecret: "legacy"}
+	if err := st.CreateWatch(legacy, func(string) ([]byte, []byte, error) { return nil, nil, nil }); err != nil {
+		t.Fatalf("CreateWatch() error = %v", err)
+	}
+	if got, err := w.watches.webhookSecret(legacy); err != nil || got != "legacy" {
+		t.Errorf("webhookSecret() of a legacy watch = %q, %v, want %q", got, err, "legacy")
+	}
+}
@@ -0,0 +1,205 @@
+package store
+
+import (
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/Pratham700/github-search-service/proto/proto"
+)
+
+var (
+	// deliveryBucket holds one nested bucket per watch with its webhook
+	// deliveries, keyed by delivery ID.
+	deliveryBucket = []byte("webhook_deliveries")
+	// pendingDeliveryBucket maps the IDs of pending deliveries to the ID of
+	// their watch, so that due deliveries are found without a full scan.
+	pendingDeliveryBucket = []byte("webhook_pending")
+)
+
+// CreateDelivery stores delivery under a new ID, which it sets on delivery,
+// and keeps at most maxDeliveries per watch (0 keeps all), oldest dropped
+// first. The ID is also passed to payload, which returns the body to store.
+func (s *Store) CreateDelivery(delivery *pb.WebhookDelivery, maxDeliveries int, payload func(id string) (string, error)) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		root, err := tx.CreateBucketIfNotExists(deliveryBucket)
+		if err != nil {
+			return err
+		}
+		b, err := root.CreateBucketIfNotExists([]byte(delivery.GetWatchId()))
+		if err != nil {
+			return err
+		}
+		pending, err := tx.CreateBucketIfNotExists(pendingDeliveryBucket)
+		if err != nil {
+			return err
+		}
+
+		delivery.Id = NewID()
+		if delivery.Payload, err = payload(delivery.GetId()); err != nil {
+			return err
+		}
+		if err := putDelivery(b, delivery); err != nil {
+			return err
+		}
+		if delivery.GetState() == pb.WebhookDelivery_STATE_PENDING {
+			if err := pending.Put([]byte(delivery.GetId()), []byte(delivery.GetWatchId())); err != nil {
+				return err
+			}
+		}
+		if maxDeliveries <= 0 {
+			return nil
+		}
+
+		// Drop the oldest deliveries, pending or not, so that a receiver that
+		// is down for long cannot grow the store without bound
+		var keys [][]byte
+		excess := countKeys(b) - maxDeliveries
+		c := b.Cursor()
+		for k, _ := c.First(); k != nil && len(keys) < excess; k, _ = c.Next() {
+			keys = append(keys, k)
+		}
+		if err := deleteKeys(pending, keys); err != nil {
+			return err
+		}
+		return deleteKeys(b, keys)
+	})
+}
+
+// DueDeliveries returns the pending deliveries whose next attempt time is not
+// after now.
+func (s *Store) DueDeliveries(now time.Time) ([]*pb.WebhookDelivery, error) {
+	var deliveries []*pb.WebhookDelivery
+	err := s.db.View(func(tx *bolt.Tx) error {
+		pending := tx.Bucket(pendingDeliveryBucket)
+		if pending == nil {
+			return nil
+		}
+		return pending.ForEach(func(id, watchID []byte) error {
+			delivery, err := getDelivery(tx, string(watchID), string(id))
+			if err != nil {
+				return err
+			}
+			if !delivery.GetNextAttemptTime().AsTime().After(now) {
+				deliveries = append(deliveries, delivery)
+			}
+			return nil
+		})
+	})
+	return deliveries, err
+}
+
+// UpdateDelivery loads the delivery with the given ID, applies update to it
+// and stores the result. A delivery that is no longer pending stops being
+// returned by DueDeliveries.
+func (s *Store) UpdateDelivery(watchID, id string, update func(*pb.WebhookDelivery)) (*pb.WebhookDelivery, error) {
+	var delivery *pb.WebhookDelivery
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		var err error
+		delivery, err = getDelivery(tx, watchID, id)
+		if err != nil {
+			return err
+		}
+
+		update(delivery)
+		if delivery.GetState() != pb.WebhookDelivery_STATE_PENDING {
+			if err := tx.Bucket(pendingDeliveryBucket).Delete([]byte(id)); err != nil {
+				return err
+			}
+		}
+		return putDelivery(tx.Bucket(deliveryBucket).Bucket([]byte(watchID)), delivery)
+	})
+	return delivery, err
+}
+
+// DropPendingDelivery stops returning the delivery with the given ID from
+// DueDeliveries, such as one whose watch no longer exists.
+func (s *Store) DropPendingDelivery(id string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		if pending := tx.Bucket(pendingDeliveryBucket); pending != nil {
+			return pending.Delete([]byte(id))
+		}
+		return nil
+	})
+}
+
+// ListDeliveries returns up to limit deliveries of the watch with the given
+// ID that are accepted by filter, newest first, starting after pageToken, and
+// the token of the next page, which is empty on the last page.
+func (s *Store) ListDeliveries(watchID string, filter func(*pb.WebhookDelivery) bool, limit int, pageToken string) ([]*pb.WebhookDelivery, string, error) {
+	var deliveries []*pb.WebhookDelivery
+	var nextPageToken string
+	err := s.db.View(func(tx *bolt.Tx) error {
+		var b *bolt.Bucket
+		if root := tx.Bucket(deliveryBucket); root != nil {
+			b = root.Bucket([]byte(watchID))
+		}
+		var err error
+		nextPageToken, err = listNewestFirst(b, limit, pageToken, func(v []byte) (bool, error) {
+			delivery := &pb.WebhookDelivery{}
+			if err := proto.Unmarshal(v, delivery); err != nil {
+				return false, fmt.Errorf("failed to decode webhook delivery: %w", err)
+			}
+			if !filter(delivery) {
+				return false, nil
+			}
+			deliveries = append(deliveries, delivery)
+			return true, nil
+		})
+		return err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return deliveries, nextPageToken, nil
+}
+
+// deleteDeliveries deletes the deliveries of the watch with the given ID.
+func deleteDeliveries(tx *bolt.Tx, watchID string) error {
+	root := tx.Bucket(deliveryBucket)
+	if root == nil || root.Bucket([]byte(watchID)) == nil {
+		return nil
+	}
+	if pending := tx.Bucket(pendingDeliveryBucket); pending != nil {
+		var keys [][]byte
+		err := root.Bucket([]byte(watchID)).ForEach(func(k, _ []byte) error {
+			if pending.Get(k) != nil {
+				keys = append(keys, k)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if err := deleteKeys(pending, keys); err != nil {
+			return err
+		}
+	}
+	return root.DeleteBucket([]byte(watchID))
+}
+
+func getDelivery(tx *bolt.Tx, watchID, id string) (*pb.WebhookDelivery, error) {
+	root := tx.Bucket(deliveryBucket)
+	if root == nil || root.Bucket([]byte(watchID)) == nil {
+		return nil, ErrNotFound
+	}
+	value := root.Bucket([]byte(watchID)).Get([]byte(id))
+	if value == nil {
+		return nil, ErrNotFound
+	}
+	delivery := &pb.WebhookDelivery{}
+	if err := proto.Unmarshal(value, delivery); err != nil {
+		return nil, fmt.Errorf("failed to decode webhook delivery: %w", err)
+	}
+	return delivery, nil
+}
+
+func putDelivery(b *bolt.Bucket, delivery *pb.WebhookDelivery) error {
+	value, err := proto.Marshal(delivery)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook delivery: %w", err)
+	}
+	return b.Put([]byte(delivery.GetId()), value)
+}
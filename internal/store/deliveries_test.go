@@ -0,0 +1,67 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/Pratham700/github-search-service/proto/proto"
+)
+
+// createTestDelivery stores a delivery of the watch with the given ID that is due now.
+func createTestDelivery(t *testing.T, s *Store, watchID string, state pb.WebhookDelivery_State) *pb.WebhookDelivery {
+	t.Helper()
+	delivery := &pb.WebhookDelivery{WatchId: watchID, State: state, NextAttemptTime: timestamppb.Now()}
+	if err := s.CreateDelivery(delivery, 0, func(string) (string, error) { return "{}", nil }); err != nil {
+		t.Fatalf("CreateDelivery() error = %v", err)
+	}
+	return delivery
+}
+
+func dueDeliveryIDs(t *testing.T, s *Store) map[string]bool {
+	t.Helper()
+	due, err := s.DueDeliveries(time.Now().Add(time.Minute))
+	if err != nil {
+		t.Fatalf("DueDeliveries() error = %v", err)
+	}
+	ids := make(map[string]bool)
+	for _, delivery := range due {
+		ids[delivery.GetId()] = true
+	}
+	return ids
+}
+
+func TestDeleteWatchDeletesDeliveries(t *testing.T) {
+	s := openTestStore(t)
+	deleted, kept := createTestWatch(t, s), createTestWatch(t, s)
+	first := createTestDelivery(t, s, deleted, pb.WebhookDelivery_STATE_PENDING)
+	second := createTestDelivery(t, s, deleted, pb.WebhookDelivery_STATE_PENDING)
+	createTestDelivery(t, s, deleted, pb.WebhookDelivery_STATE_SUCCEEDED)
+	other := createTestDelivery(t, s, kept, pb.WebhookDelivery_STATE_PENDING)
+	if due := dueDeliveryIDs(t, s); !due[first.GetId()] || !due[second.GetId()] || !due[other.GetId()] {
+		t.Fatalf("DueDeliveries() = %v, want the three pending deliveries", due)
+	}
+
+	if err := s.DeleteWatch(deleted); err != nil {
+		t.Fatalf("DeleteWatch() error = %v", err)
+	}
+	if due := dueDeliveryIDs(t, s); len(due) != 1 || !due[other.GetId()] {
+		t.Errorf("DueDeliveries() after DeleteWatch = %v, want only %s", due, other.GetId())
+	}
+	deliveries, _, err := s.ListDeliveries(deleted, func(*pb.WebhookDelivery) bool { return true }, 10, "")
+	if err != nil || len(deliveries) != 0 {
+		t.Errorf("ListDeliveries() of the deleted watch = %v, %v, want none", deliveries, err)
+	}
+}
+
+func TestDropPendingDelivery(t *testing.T) {
+	s := openTestStore(t)
+	delivery := createTestDelivery(t, s, createTestWatch(t, s), pb.WebhookDelivery_STATE_PENDING)
+	if err := s.DropPendingDelivery(delivery.GetId()); err != nil {
+		t.Fatalf("DropPendingDelivery() error = %v", err)
+	}
+	if due := dueDeliveryIDs(t, s); len(due) != 0 {
+		t.Errorf("DueDeliveries() after DropPendingDelivery = %v, want none", due)
+	}
+}
@@ -0,0 +1,190 @@
+package store
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/Pratham700/github-search-service/proto/proto"
+)
+
+// historyBucket holds one nested bucket per caller identity, keyed by
+// timeKey; values are encoded RecentSearch messages.
+var historyBucket = []byte("history")
+
+// HistoryRetention bounds the history kept per caller. A zero field disables that limit.
+type HistoryRetention struct {
+	MaxEntries int
+	MaxAge     time.Duration
+}
+
+// HistoryEntry is a search to add to the history of Identity.
+type HistoryEntry struct {
+	Identity string
+	Search   *pb.RecentSearch
+}
+
+// AddSearches appends entries to the histories of their callers in a single
+// transaction and drops the entries of those histories that fall outside
+// retention.
+func (s *Store) AddSearches(entries []HistoryEntry, retention HistoryRetention) error {
+	values := make([][]byte, len(entries))
+	for i, entry := range entries {
+		var err error
+		if values[i], err = proto.Marshal(entry.Search); err != nil {
+			return fmt.Errorf("failed to encode search: %w", err)
+		}
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		root, err := tx.CreateBucketIfNotExists(historyBucket)
+		if err != nil {
+			return err
+		}
+		touched := make(map[string]*bolt.Bucket)
+		for i, entry := range entries {
+			b, err := root.CreateBucketIfNotExists([]byte(entry.Identity))
+			if err != nil {
+				return err
+			}
+			seq, err := b.NextSequence()
+			if err != nil {
+				return err
+			}
+			if err := b.Put(timeKey(entry.Search.GetTime().AsTime(), seq), values[i]); err != nil {
+				return err
+			}
+			touched[entry.Identity] = b
+		}
+		now := time.Now()
+		for _, b := range touched {
+			if err := trimHistory(b, retention, now); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ListSearches returns up to limit searches of identity, newest first,
+// starting after pageToken, and the token of the next page, which is empty
+// on the last page.
+func (s *Store) ListSearches(identity string, limit int, pageToken string) ([]*pb.RecentSearch, string, error) {
+	var searches []*pb.RecentSearch
+	var nextPageToken string
+	err := s.db.View(func(tx *bolt.Tx) error {
+		var err error
+		nextPageToken, err = listNewestFirst(historyBucketFor(tx, identity), limit, pageToken, func(v []byte) (bool, error) {
+			search := &pb.RecentSearch{}
+			if err := proto.Unmarshal(v, search); err != nil {
+				return false, fmt.Errorf("failed to decode search: %w", err)
+			}
+			searches = append(searches, search)
+			return true, nil
+		})
+		return err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return searches, nextPageToken, nil
+}
+
+// DeleteSearches deletes the searches of identity made before the given time,
+// or all of them when before is zero, and returns how many were deleted.
+func (s *Store) DeleteSearches(identity string, before time.Time) (int, error) {
+	deleted := 0
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		b := historyBucketFor(tx, identity)
+		if b == nil {
+			return nil
+		}
+		if before.IsZero() {
+			deleted = countKeys(b)
+			return tx.Bucket(historyBucket).DeleteBucket([]byte(identity))
+		}
+		var err error
+		deleted, err = deleteBefore(b, timeKey(before, 0))
+		return err
+	})
+	return deleted, err
+}
+
+// PruneHistory drops the searches of every caller that fall outside retention.
+func (s *Store) PruneHistory(retention HistoryRetention) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		root := tx.Bucket(historyBucket)
+		if root == nil {
+			return nil
+		}
+		now := time.Now()
+		return root.ForEachBucket(func(identity []byte) error {
+			return trimHistory(root.Bucket(identity), retention, now)
+		})
+	})
+}
+
+func historyBucketFor(tx *bolt.Tx, identity string) *bolt.Bucket {
+	root := tx.Bucket(historyBucket)
+	if root == nil {
+		return nil
+	}
+	return root.Bucket([]byte(identity))
+}
+
+// trimHistory deletes the entries older than MaxAge and then the oldest
+// entries beyond MaxEntries.
+func trimHistory(b *bolt.Bucket, retention HistoryRetention, now time.Time) error {
+	if retention.MaxAge > 0 {
+		if _, err := deleteBefore(b, timeKey(now.Add(-retention.MaxAge), 0)); err != nil {
+			return err
+		}
+	}
+	if retention.MaxEntries > 0 {
+		var keys [][]byte
+		excess := countKeys(b) - retention.MaxEntries
+		c := b.Cursor()
+		for k, _ := c.First(); k != nil && len(keys) < excess; k, _ = c.Next() {
+			keys = append(keys, k)
+		}
+		if err := deleteKeys(b, keys); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// countKeys counts the entries of b. Bucket.Stats is not used as it misses
+// writes of the current transaction.
+func countKeys(b *bolt.Bucket) int {
+	n := 0
+	c := b.Cursor()
+	for k, _ := c.First(); k != nil; k, _ = c.Next() {
+		n++
+	}
+	return n
+}
+
+// deleteBefore deletes the entries whose key sorts before limit.
+func deleteBefore(b *bolt.Bucket, limit []byte) (int, error) {
+	var keys [][]byte
+	c := b.Cursor()
+	for k, _ := c.First(); k != nil && bytes.Compare(k, limit) < 0; k, _ = c.Next() {
+		keys = append(keys, k)
+	}
+	return len(keys), deleteKeys(b, keys)
+}
+
+// deleteKeys deletes keys collected beforehand, as deleting through a cursor
+// while iterating can skip entries.
+func deleteKeys(b *bolt.Bucket, keys [][]byte) error {
+	for _, k := range keys {
+		if err := b.Delete(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
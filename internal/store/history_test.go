@@ -0,0 +1,70 @@
+package store
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/Pratham700/github-search-service/proto/proto"
+)
+
+func TestTimeKeyOrder(t *testing.T) {
+	epoch := time.Unix(0, 0)
+	tests := []struct {
+		name           string
+		earlier, later time.Time
+	}{
+		{"after the epoch", time.Unix(1700000000, 0), time.Unix(1700000001, 0)},
+		{"before the epoch", time.Date(1969, 1, 1, 0, 0, 0, 0, time.UTC), time.Unix(1700000000, 0)},
+		{"zero time", time.Time{}, epoch.Add(time.Nanosecond)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if bytes.Compare(timeKey(tt.earlier, 0), timeKey(tt.later, 0)) >= 0 {
+				t.Errorf("timeKey(%v) does not sort before timeKey(%v)", tt.earlier, tt.later)
+			}
+		})
+	}
+	if !bytes.Equal(timeKey(time.Time{}, 1), timeKey(epoch, 1)) {
+		t.Errorf("timeKey() of a time before the epoch is not clamped to the epoch")
+	}
+}
+
+func TestAddSearches(t *testing.T) {
+	s := openTestStore(t)
+	now := time.Now()
+	search := func(term string, age time.Duration) *pb.RecentSearch {
+		return &pb.RecentSearch{Request: &pb.SearchRequest{SearchTerm: term}, Time: timestamppb.New(now.Add(-age))}
+	}
+	entries := []HistoryEntry{
+		{Identity: "alice", Search: search("a1", 3*time.Minute)},
+		{Identity: "bob", Search: search("b1", 2*time.Minute)},
+		{Identity: "alice", Search: search("a2", 2*time.Minute)},
+		{Identity: "alice", Search: search("a3", time.Minute)},
+	}
+	if err := s.AddSearches(entries, HistoryRetention{MaxEntries: 2}); err != nil {
+		t.Fatalf("AddSearches() error = %v", err)
+	}
+
+	for identity, want := range map[string][]string{"alice": {"a3", "a2"}, "bob": {"b1"}} {
+		searches, _, err := s.ListSearches(identity, 10, "")
+		if err != nil {
+			t.Fatalf("ListSearches(%q) error = %v", identity, err)
+		}
+		var got []string
+		for _, search := range searches {
+			got = append(got, search.GetRequest().GetSearchTerm())
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("ListSearches(%q) = %v, want %v", identity, got, want)
+		}
+	}
+
+	deleted, err := s.DeleteSearches("alice", time.Date(1969, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil || deleted != 0 {
+		t.Errorf("DeleteSearches() before the epoch = %d, %v, want nothing deleted", deleted, err)
+	}
+}
@@ -0,0 +1,239 @@
+package store
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/Pratham700/github-search-service/proto/proto"
+)
+
+var (
+	// savedSearchBucket maps saved search IDs to encoded SavedSearch messages.
+	// IDs start with the creation time, so key order is creation order.
+	savedSearchBucket = []byte("saved_searches")
+	// savedSearchNameBucket maps the owner and name of each saved search,
+	// see nameKey, to its ID, so that names are kept unique without a scan.
+	savedSearchNameBucket = []byte("saved_search_names")
+)
+
+var (
+	// ErrNotFound is returned when the requested item does not exist.
+	ErrNotFound = errors.New("not found")
+	// ErrAlreadyExists is returned when an owner already has a saved search with the same name.
+	ErrAlreadyExists = errors.New("already exists")
+)
+
+// NewID returns a unique ID that sorts by creation time.
+func NewID() string {
+	b := make([]byte, 12)
+	binary.BigEndian.PutUint64(b, uint64(time.Now().UnixNano()))
+	_, _ = rand.Read(b[8:])
+	return hex.EncodeToString(b)
+}
+
+// CreateSavedSearch stores search under a new ID, which it sets on search.
+func (s *Store) CreateSavedSearch(search *pb.SavedSearch) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(savedSearchBucket)
+		if err != nil {
+			return err
+		}
+		names, err := savedSearchNames(tx)
+		if err != nil {
+			return err
+		}
+		search.Id = NewID()
+		if err := putName(names, search); err != nil {
+			return err
+		}
+		return putSavedSearch(b, search)
+	})
+}
+
+// GetSavedSearch returns the saved search with the given ID.
+func (s *Store) GetSavedSearch(id string) (*pb.SavedSearch, error) {
+	var search *pb.SavedSearch
+	err := s.db.View(func(tx *bolt.Tx) error {
+		var err error
+		search, err = getSavedSearch(tx.Bucket(savedSearchBucket), id)
+		return err
+	})
+	return search, err
+}
+
+// ListSavedSearches returns up to limit saved searches accepted by filter,
+// in creation order, starting after pageToken, and the token of the next
+// page, which is empty on the last page.
+func (s *Store) ListSavedSearches(filter func(*pb.SavedSearch) bool, limit int, pageToken string) ([]*pb.SavedSearch, string, error) {
+	var searches []*pb.SavedSearch
+	var nextPageToken string
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(savedSearchBucket)
+		if b == nil {
+			return nil
+		}
+
+		c := b.Cursor()
+		k, v := c.First()
+		if pageToken != "" {
+			k, v = c.Seek([]byte(pageToken))
+			if k != nil && bytes.Equal(k, []byte(pageToken)) {
+				k, v = c.Next()
+			}
+		}
+		for ; k != nil; k, v = c.Next() {
+			search := &pb.SavedSearch{}
+			if err := proto.Unmarshal(v, search); err != nil {
+				return fmt.Errorf("failed to decode saved search: %w", err)
+			}
+			if !filter(search) {
+				continue
+			}
+			if len(searches) == limit {
+				nextPageToken = searches[len(searches)-1].GetId()
+				break
+			}
+			searches = append(searches, search)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return searches, nextPageToken, nil
+}
+
+// UpdateSavedSearch loads the saved search with the given ID, applies update
+// to it and stores the result. Returning an error from update aborts the change.
+func (s *Store) UpdateSavedSearch(id string, update func(*pb.SavedSearch) error) (*pb.SavedSearch, error) {
+	var search *pb.SavedSearch
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(savedSearchBucket)
+		var err error
+		search, err = getSavedSearch(b, id)
+		if err != nil {
+			return err
+		}
+		previous := proto.Clone(search).(*pb.SavedSearch)
+		if err := update(search); err != nil {
+			return err
+		}
+		search.Id = id
+		names, err := savedSearchNames(tx)
+		if err != nil {
+			return err
+		}
+		if err := deleteName(names, previous); err != nil {
+			return err
+		}
+		if err := putName(names, search); err != nil {
+			return err
+		}
+		return putSavedSearch(b, search)
+	})
+	return search, err
+}
+
+// DeleteSavedSearch deletes the saved search with the given ID.
+func (s *Store) DeleteSavedSearch(id string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(savedSearchBucket)
+		search, err := getSavedSearch(b, id)
+		if err != nil {
+			return err
+		}
+		names, err := savedSearchNames(tx)
+		if err != nil {
+			return err
+		}
+		if err := deleteName(names, search); err != nil {
+			return err
+		}
+		return b.Delete([]byte(id))
+	})
+}
+
+func getSavedSearch(b *bolt.Bucket, id string) (*pb.SavedSearch, error) {
+	if b == nil {
+		return nil, ErrNotFound
+	}
+	value := b.Get([]byte(id))
+	if value == nil {
+		return nil, ErrNotFound
+	}
+	search := &pb.SavedSearch{}
+	if err := proto.Unmarshal(value, search); err != nil {
+		return nil, fmt.Errorf("failed to decode saved search: %w", err)
+	}
+	return search, nil
+}
+
+func putSavedSearch(b *bolt.Bucket, search *pb.SavedSearch) error {
+	value, err := proto.Marshal(search)
+	if err != nil {
+		return fmt.Errorf("failed to encode saved search: %w", err)
+	}
+	return b.Put([]byte(search.GetId()), value)
+}
+
+// nameKey returns the key of search in savedSearchNameBucket: the length of
+// its owner, as a uvarint, followed by the owner and the name, so that no two
+// owner and name pairs share a key.
+func nameKey(search *pb.SavedSearch) []byte {
+	key := binary.AppendUvarint(nil, uint64(len(search.GetOwner())))
+	key = append(key, search.GetOwner()...)
+	return append(key, search.GetName()...)
+}
+
+// savedSearchNames returns the name index of the saved searches. Databases
+// written before the index existed have none, so it is built from the saved
+// searches the first time it is needed.
+func savedSearchNames(tx *bolt.Tx) (*bolt.Bucket, error) {
+	if names := tx.Bucket(savedSearchNameBucket); names != nil {
+		return names, nil
+	}
+	names, err := tx.CreateBucket(savedSearchNameBucket)
+	if err != nil {
+		return nil, err
+	}
+	b := tx.Bucket(savedSearchBucket)
+	if b == nil {
+		return names, nil
+	}
+	err = b.ForEach(func(k, v []byte) error {
+		search := &pb.SavedSearch{}
+		if err := proto.Unmarshal(v, search); err != nil {
+			return fmt.Errorf("failed to decode saved search: %w", err)
+		}
+		return names.Put(nameKey(search), k)
+	})
+	return names, err
+}
+
+// putName indexes the name of search, failing with ErrAlreadyExists if
+// another saved search of the same owner has the same name.
+func putName(names *bolt.Bucket, search *pb.SavedSearch) error {
+	key := nameKey(search)
+	if id := names.Get(key); id != nil && string(id) != search.GetId() {
+		return ErrAlreadyExists
+	}
+	return names.Put(key, []byte(search.GetId()))
+}
+
+// deleteName removes the name of search from the index, unless it belongs to
+// another saved search.
+func deleteName(names *bolt.Bucket, search *pb.SavedSearch) error {
+	key := nameKey(search)
+	if string(names.Get(key)) != search.GetId() {
+		return nil
+	}
+	return names.Delete(key)
+}
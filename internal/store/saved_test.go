@@ -0,0 +1,123 @@
+package store
+
+import (
+	"errors"
+	"testing"
+
+	bolt "go.etcd.io/bbolt"
+
+	pb "github.com/Pratham700/github-search-service/proto/proto"
+)
+
+func createTestSavedSearch(t *testing.T, s *Store, owner, name string) string {
+	t.Helper()
+	search := &pb.SavedSearch{Owner: owner, Name: name, Request: &pb.SearchRequest{SearchTerm: "foo"}}
+	if err := s.CreateSavedSearch(search); err != nil {
+		t.Fatalf("CreateSavedSearch(%s, %s) error = %v", owner, name, err)
+	}
+	return search.GetId()
+}
+
+// rename returns an update that sets the name of a saved search.
+func rename(name string) func(*pb.SavedSearch) error {
+	return func(search *pb.SavedSearch) error {
+		search.Name = name
+		return nil
+	}
+}
+
+func TestSavedSearchCRUD(t *testing.T) {
+	s := openTestStore(t)
+	id := createTestSavedSearch(t, s, "alice", "foo")
+
+	search, err := s.GetSavedSearch(id)
+	if err != nil || search.GetName() != "foo" || search.GetOwner() != "alice" {
+		t.Fatalf("GetSavedSearch() = %v, %v", search, err)
+	}
+	search, err = s.UpdateSavedSearch(id, func(search *pb.SavedSearch) error {
+		search.Id = "other"
+		search.Description = "bar"
+		return nil
+	})
+	if err != nil || search.GetId() != id || search.GetDescription() != "bar" {
+		t.Fatalf("UpdateSavedSearch() = %v, %v", search, err)
+	}
+	errAbort := errors.New("abort")
+	if _, err := s.UpdateSavedSearch(id, func(*pb.SavedSearch) error { return errAbort }); !errors.Is(err, errAbort) {
+		t.Fatalf("UpdateSavedSearch() error = %v, want %v", err, errAbort)
+	}
+	if search, _ := s.GetSavedSearch(id); search.GetDescription() != "bar" {
+		t.Errorf("aborted update changed the description to %q", search.GetDescription())
+	}
+
+	if err := s.DeleteSavedSearch(id); err != nil {
+		t.Fatalf("DeleteSavedSearch() error = %v", err)
+	}
+	if _, err := s.GetSavedSearch(id); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetSavedSearch() after delete error = %v, want ErrNotFound", err)
+	}
+	if err := s.DeleteSavedSearch(id); !errors.Is(err, ErrNotFound) {
+		t.Errorf("DeleteSavedSearch() twice error = %v, want ErrNotFound", err)
+	}
+	if _, err := s.UpdateSavedSearch(id, rename("foo")); !errors.Is(err, ErrNotFound) {
+		t.Errorf("UpdateSavedSearch() after delete error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestSavedSearchUniqueName(t *testing.T) {
+	s := openTestStore(t)
+	foo := createTestSavedSearch(t, s, "alice", "foo")
+	bar := createTestSavedSearch(t, s, "alice", "bar")
+	deleted := createTestSavedSearch(t, s, "alice", "deleted")
+	if err := s.DeleteSavedSearch(deleted); err != nil {
+		t.Fatalf("DeleteSavedSearch() error = %v", err)
+	}
+	if _, err := s.UpdateSavedSearch(bar, rename("renamed")); err != nil {
+		t.Fatalf("UpdateSavedSearch() error = %v", err)
+	}
+
+	tests := []struct {
+		owner, name string
+		want        error
+	}{
+		{"alice", "foo", ErrAlreadyExists},
+		{"alice", "renamed", ErrAlreadyExists},
+		{"alice", "bar", nil},
+		{"alice", "deleted", nil},
+		{"alice", "Foo", nil},
+		{"bob", "foo", nil},
+		// Owner and name boundaries are part of the key
+		{"alic", "efoo", nil},
+	}
+	for _, tt := range tests {
+		search := &pb.SavedSearch{Owner: tt.owner, Name: tt.name}
+		if err := s.CreateSavedSearch(search); !errors.Is(err, tt.want) {
+			t.Errorf("CreateSavedSearch(%s, %s) error = %v, want %v", tt.owner, tt.name, err, tt.want)
+		}
+	}
+
+	if _, err := s.UpdateSavedSearch(foo, rename("renamed")); !errors.Is(err, ErrAlreadyExists) {
+		t.Errorf("UpdateSavedSearch() to a taken name error = %v, want ErrAlreadyExists", err)
+	}
+	if _, err := s.UpdateSavedSearch(foo, rename("foo")); err != nil {
+		t.Errorf("UpdateSavedSearch() keeping the name error = %v", err)
+	}
+}
+
+func TestSavedSearchUniqueNameWithoutIndex(t *testing.T) {
+	s := openTestStore(t)
+	id := createTestSavedSearch(t, s, "alice", "foo")
+	// Saved searches created before names were indexed have no index
+	err := s.db.Update(func(tx *bolt.Tx) error { return tx.DeleteBucket(savedSearchNameBucket) })
+	if err != nil {
+		t.Fatalf("DeleteBucket() error = %v", err)
+	}
+
+	if err := s.CreateSavedSearch(&pb.SavedSearch{Owner: "alice", Name: "foo"}); !errors.Is(err, ErrAlreadyExists) {
+		t.Fatalf("CreateSavedSearch() error = %v, want ErrAlreadyExists", err)
+	}
+	if _, err := s.UpdateSavedSearch(id, rename("bar")); err != nil {
+		t.Fatalf("UpdateSavedSearch() error = %v", err)
+	}
+	createTestSavedSearch(t, s, "alice", "foo")
+}
@@ -0,0 +1,185 @@
+package store
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/Pratham700/github-search-service/proto/proto"
+)
+
+var (
+	// snapshotBucket maps snapshot IDs to encoded Snapshot messages without
+	// their hits, so that listing and counting do not decode them.
+	snapshotBucket = []byte("search_snapshots")
+	// snapshotHitBucket maps snapshot IDs to encoded Snapshot messages holding
+	// only the hits.
+	snapshotHitBucket = []byte("search_snapshot_hits")
+	// snapshotCountBucket maps owners to their number of snapshots, so that
+	// the limit per owner is enforced without a scan.
+	snapshotCountBucket = []byte("search_snapshot_counts")
+)
+
+// ErrLimitReached is returned when an owner already has the maximum number of snapshots.
+var ErrLimitReached = errors.New("limit reached")
+
+// CreateSnapshot stores snapshot under a new ID, which it sets on snapshot.
+// It fails with ErrLimitReached if the owner already has maxPerOwner
+// snapshots (0 allows any number).
+func (s *Store) CreateSnapshot(snapshot *pb.Snapshot, maxPerOwner int) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(snapshotBucket)
+		if err != nil {
+			return err
+		}
+		hb, err := tx.CreateBucketIfNotExists(snapshotHitBucket)
+		if err != nil {
+			return err
+		}
+		n, err := snapshotCount(tx, snapshot.GetOwner())
+		if err != nil {
+			return err
+		}
+		if maxPerOwner > 0 && n >= maxPerOwner {
+			return ErrLimitReached
+		}
+		if err := putSnapshotCount(tx, snapshot.GetOwner(), n+1); err != nil {
+			return err
+		}
+
+		snapshot.Id = NewID()
+		meta := proto.Clone(snapshot).(*pb.Snapshot)
+		meta.Hits = nil
+		if err := putSnapshot(b, snapshot.GetId(), meta); err != nil {
+			return err
+		}
+		return putSnapshot(hb, snapshot.GetId(), &pb.Snapshot{Hits: snapshot.GetHits()})
+	})
+}
+
+// GetSnapshot returns the snapshot with the given ID, including its hits.
+func (s *Store) GetSnapshot(id string) (*pb.Snapshot, error) {
+	var snapshot *pb.Snapshot
+	err := s.db.View(func(tx *bolt.Tx) error {
+		var err error
+		if snapshot, err = getSnapshot(tx.Bucket(snapshotBucket), id); err != nil {
+			return err
+		}
+		hits, err := getSnapshot(tx.Bucket(snapshotHitBucket), id)
+		if err != nil {
+			return err
+		}
+		snapshot.Hits = hits.GetHits()
+		return nil
+	})
+	return snapshot, err
+}
+
+// ListSnapshots returns up to limit snapshots accepted by filter, without
+// their hits, newest first, starting after pageToken, and the token of the
+// next page, which is empty on the last page.
+func (s *Store) ListSnapshots(filter func(*pb.Snapshot) bool, limit int, pageToken string) ([]*pb.Snapshot, string, error) {
+	var snapshots []*pb.Snapshot
+	var nextPageToken string
+	err := s.db.View(func(tx *bolt.Tx) error {
+		var err error
+		nextPageToken, err = listNewestFirst(tx.Bucket(snapshotBucket), limit, pageToken, func(v []byte) (bool, error) {
+			snapshot := &pb.Snapshot{}
+			if err := proto.Unmarshal(v, snapshot); err != nil {
+				return false, fmt.Errorf("failed to decode snapshot: %w", err)
+			}
+			if !filter(snapshot) {
+				return false, nil
+			}
+			snapshots = append(snapshots, snapshot)
+			return true, nil
+		})
+		return err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return snapshots, nextPageToken, nil
+}
+
+// DeleteSnapshot deletes the snapshot with the given ID.
+func (s *Store) DeleteSnapshot(id string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(snapshotBucket)
+		snapshot, err := getSnapshot(b, id)
+		if err != nil {
+			return err
+		}
+		n, err := snapshotCount(tx, snapshot.GetOwner())
+		if err != nil {
+			return err
+		}
+		if err := b.Delete([]byte(id)); err != nil {
+			return err
+		}
+		if err := tx.Bucket(snapshotHitBucket).Delete([]byte(id)); err != nil {
+			return err
+		}
+		return putSnapshotCount(tx, snapshot.GetOwner(), max(n, 1)-1)
+	})
+}
+
+// snapshotCount returns the number of snapshots of owner. Owners without a
+// count, whose snapshots were taken before counts were kept, are counted once.
+func snapshotCount(tx *bolt.Tx, owner string) (int, error) {
+	if counts := tx.Bucket(snapshotCountBucket); counts != nil {
+		if value := counts.Get([]byte(owner)); len(value) == 8 {
+			return int(binary.BigEndian.Uint64(value)), nil
+		}
+	}
+	b := tx.Bucket(snapshotBucket)
+	if b == nil {
+		return 0, nil
+	}
+	n := 0
+	err := b.ForEach(func(_, v []byte) error {
+		snapshot := &pb.Snapshot{}
+		if err := proto.Unmarshal(v, snapshot); err != nil {
+			return fmt.Errorf("failed to decode snapshot: %w", err)
+		}
+		if snapshot.GetOwner() == owner {
+			n++
+		}
+		return nil
+	})
+	return n, err
+}
+
+func putSnapshotCount(tx *bolt.Tx, owner string, n int) error {
+	counts, err := tx.CreateBucketIfNotExists(snapshotCountBucket)
+	if err != nil {
+		return err
+	}
+	return counts.Put([]byte(owner), binary.BigEndian.AppendUint64(nil, uint64(n)))
+}
+
+func getSnapshot(b *bolt.Bucket, id string) (*pb.Snapshot, error) {
+	if b == nil {
+		return nil, ErrNotFound
+	}
+	value := b.Get([]byte(id))
+	if value == nil {
+		return nil, ErrNotFound
+	}
+	snapshot := &pb.Snapshot{}
+	if err := proto.Unmarshal(value, snapshot); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot: %w", err)
+	}
+	return snapshot, nil
+}
+
+func putSnapshot(b *bolt.Bucket, id string, snapshot *pb.Snapshot) error {
+	value, err := proto.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	return b.Put([]byte(id), value)
+}
@@ -0,0 +1,68 @@
+package store
+
+import (
+	"errors"
+	"testing"
+
+	bolt "go.etcd.io/bbolt"
+
+	pb "github.com/Pratham700/github-search-service/proto/proto"
+)
+
+func createTestSnapshot(s *Store, owner string, maxPerOwner int) (string, error) {
+	snapshot := &pb.Snapshot{Request: &pb.SearchRequest{SearchTerm: "foo"}, Owner: owner, Hits: hits("o/r:a.go:1")}
+	err := s.CreateSnapshot(snapshot, maxPerOwner)
+	return snapshot.GetId(), err
+}
+
+func TestCreateSnapshotLimit(t *testing.T) {
+	s := openTestStore(t)
+	var ids []string
+	for range 2 {
+		id, err := createTestSnapshot(s, "alice", 2)
+		if err != nil {
+			t.Fatalf("CreateSnapshot() error = %v", err)
+		}
+		ids = append(ids, id)
+	}
+	if _, err := createTestSnapshot(s, "alice", 2); !errors.Is(err, ErrLimitReached) {
+		t.Fatalf("CreateSnapshot() beyond the limit error = %v, want ErrLimitReached", err)
+	}
+	if _, err := createTestSnapshot(s, "bob", 2); err != nil {
+		t.Fatalf("CreateSnapshot() of another owner error = %v", err)
+	}
+
+	if err := s.DeleteSnapshot(ids[0]); err != nil {
+		t.Fatalf("DeleteSnapshot() error = %v", err)
+	}
+	if _, err := createTestSnapshot(s, "alice", 2); err != nil {
+		t.Fatalf("CreateSnapshot() after a deletion error = %v", err)
+	}
+	if _, err := createTestSnapshot(s, "alice", 2); !errors.Is(err, ErrLimitReached) {
+		t.Fatalf("CreateSnapshot() beyond the limit error = %v, want ErrLimitReached", err)
+	}
+}
+
+func TestCreateSnapshotLimitWithoutCounts(t *testing.T) {
+	s := openTestStore(t)
+	for range 2 {
+		if _, err := createTestSnapshot(s, "alice", 0); err != nil {
+			t.Fatalf("CreateSnapshot() error = %v", err)
+		}
+	}
+	// Snapshots taken before counts were kept have none
+	err := s.db.Update(func(tx *bolt.Tx) error { return tx.DeleteBucket(snapshotCountBucket) })
+	if err != nil {
+		t.Fatalf("DeleteBucket() error = %v", err)
+	}
+
+	if _, err := createTestSnapshot(s, "alice", 2); !errors.Is(err, ErrLimitReached) {
+		t.Fatalf("CreateSnapshot() beyond the limit error = %v, want ErrLimitReached", err)
+	}
+	if _, err := createTestSnapshot(s, "alice", 3); err != nil {
+		t.Fatalf("CreateSnapshot() error = %v", err)
+	}
+	if _, err := createTestSnapshot(s, "alice", 3); !errors.Is(err, ErrLimitReached) {
+		t.Fatalf("CreateSnapshot() beyond the limit error = %v, want ErrLimitReached", err)
+	}
+}
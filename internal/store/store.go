@@ -0,0 +1,105 @@
+package store
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	// ErrInvalidPageToken is returned for page tokens that were not issued by the store.
+	ErrInvalidPageToken = errors.New("invalid page token")
+	// ErrInvalidCursor is returned for event cursors that were not issued by the store.
+	ErrInvalidCursor = errors.New("invalid cursor")
+)
+
+// Store persists per-caller state in an embedded bbolt database file.
+type Store struct {
+	db *bolt.DB
+}
+
+// Open opens, or creates, the database at path.
+func Open(path string) (*Store, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open store %s: %w", path, err)
+	}
+	return &Store{db: db}, nil
+}
+
+// Close closes the database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// unixEpoch is the earliest time timeKey distinguishes.
+var unixEpoch = time.Unix(0, 0)
+
+// timeKey builds the key of a time-ordered entry: the time in nanoseconds
+// followed by a sequence number, both big-endian, so that iteration order is
+// chronological. Times before the Unix epoch are clamped to it, as their
+// negative nanoseconds would otherwise wrap around and sort after every
+// later time.
+func timeKey(t time.Time, seq uint64) []byte {
+	key := make([]byte, 16)
+	if t.After(unixEpoch) {
+		binary.BigEndian.PutUint64(key, uint64(t.UnixNano()))
+	}
+	binary.BigEndian.PutUint64(key[8:], seq)
+	return key
+}
+
+// listNewestFirst passes the values of b, whose keys sort chronologically,
+// such as timeKey or NewID keys, to decode, newest first, starting after the
+// key encoded in pageToken, until decode has kept limit of them. It returns
+// the token of the next page, which is empty on the last page.
+func listNewestFirst(b *bolt.Bucket, limit int, pageToken string, decode func(v []byte) (bool, error)) (string, error) {
+	var before []byte
+	if pageToken != "" {
+		var err error
+		before, err = base64.RawURLEncoding.DecodeString(pageToken)
+		if err != nil || len(before) == 0 {
+			return "", ErrInvalidPageToken
+		}
+	}
+	if b == nil {
+		return "", nil
+	}
+
+	c := b.Cursor()
+	var k, v []byte
+	if before == nil {
+		k, v = c.Last()
+	} else {
+		k, v = c.Seek(before)
+		if k == nil {
+			k, v = c.Last()
+		}
+		for k != nil && bytes.Compare(k, before) >= 0 {
+			k, v = c.Prev()
+		}
+	}
+
+	var lastKey []byte
+	n := 0
+	for ; k != nil; k, v = c.Prev() {
+		if n == limit {
+			return base64.RawURLEncoding.EncodeToString(lastKey), nil
+		}
+		kept, err := decode(v)
+		if err != nil {
+			return "", err
+		}
+		if !kept {
+			continue
+		}
+		n++
+		lastKey = append(lastKey[:0], k...)
+	}
+	return "", nil
+}
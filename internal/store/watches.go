@@ -0,0 +1,455 @@
+package store
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/Pratham700/github-search-service/proto/proto"
+)
+
+var (
+	// watchBucket maps watch IDs to encoded Watch messages.
+	watchBucket = []byte("watches")
+	// watchSnapshotBucket holds one nested bucket per watch with the hits of
+	// its last successful run, keyed by hitKey.
+	watchSnapshotBucket = []byte("watch_snapshots")
+	// watchEventBucket holds one nested bucket per watch with its events,
+	// keyed by eventKey.
+	watchEventBucket = []byte("watch_events")
+	// watchTokenBucket maps watch IDs to the sealed GitHub tokens of their owners.
+	watchTokenBucket = []byte("watch_tokens")
+	// watchSecretBucket maps watch IDs to their sealed webhook secrets.
+	watchSecretBucket = []byte("watch_webhook_secrets")
+)
+
+// WatchRun is the outcome of one run of a watch.
+type WatchRun struct {
+	Time    time.Time
+	NextRun time.Time
+	// Hits are the hits found by the run; ignored if Err is set.
+	Hits []*pb.WatchHit
+	// Truncated and Incomplete are set if hits may be missing from Hits,
+	// because GitHub reported more than were fetched or incomplete results.
+	Truncated, Incomplete bool
+	Err                   error
+}
+
+// CreateWatch stores watch under a new ID, which it sets on watch, along
+// with the GitHub token it runs with and its webhook secret, both sealed for
+// that ID by seal. A nil webhookSecret stores none.
+func (s *Store) CreateWatch(watch *pb.Watch, seal func(id string) (token, webhookSecret []byte, err error)) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(watchBucket)
+		if err != nil {
+			return err
+		}
+		tokens, err := tx.CreateBucketIfNotExists(watchTokenBucket)
+		if err != nil {
+			return err
+		}
+		secrets, err := tx.CreateBucketIfNotExists(watchSecretBucket)
+		if err != nil {
+			return err
+		}
+		watch.Id = NewID()
+		token, webhookSecret, err := seal(watch.GetId())
+		if err != nil {
+			return err
+		}
+		if err := tokens.Put([]byte(watch.GetId()), token); err != nil {
+			return err
+		}
+		if webhookSecret != nil {
+			if err := secrets.Put([]byte(watch.GetId()), webhookSecret); err != nil {
+				return err
+			}
+		}
+		return putWatch(b, watch)
+	})
+}
+
+// WatchToken returns the sealed GitHub token of the watch with the given ID.
+func (s *Store) WatchToken(id string) ([]byte, error) {
+	return s.watchSealed(watchTokenBucket, id)
+}
+
+// WatchWebhookSecret returns the sealed webhook secret of the watch with the
+// given ID, or ErrNotFound if it has none.
+func (s *Store) WatchWebhookSecret(id string) ([]byte, error) {
+	return s.watchSealed(watchSecretBucket, id)
+}
+
+func (s *Store) watchSealed(bucket []byte, id string) ([]byte, error) {
+	var sealed []byte
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucket)
+		if b == nil || b.Get([]byte(id)) == nil {
+			return ErrNotFound
+		}
+		sealed = bytes.Clone(b.Get([]byte(id)))
+		return nil
+	})
+	return sealed, err
+}
+
+// GetWatch returns the watch with the given ID.
+func (s *Store) GetWatch(id string) (*pb.Watch, error) {
+	var watch *pb.Watch
+	err := s.db.View(func(tx *bolt.Tx) error {
+		var err error
+		watch, err = getWatch(tx.Bucket(watchBucket), id)
+		return err
+	})
+	return watch, err
+}
+
+// ListWatches returns up to limit watches accepted by filter, in creation
+// order, starting after pageToken, and the token of the next page, which is
+// empty on the last page.
+func (s *Store) ListWatches(filter func(*pb.Watch) bool, limit int, pageToken string) ([]*pb.Watch, string, error) {
+	var watches []*pb.Watch
+	var nextPageToken string
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(watchBucket)
+		if b == nil {
+			return nil
+		}
+
+		c := b.Cursor()
+		k, v := c.First()
+		if pageToken != "" {
+			k, v = c.Seek([]byte(pageToken))
+			if k != nil && string(k) == pageToken {
+				k, v = c.Next()
+			}
+		}
+		for ; k != nil; k, v = c.Next() {
+			watch := &pb.Watch{}
+			if err := proto.Unmarshal(v, watch); err != nil {
+				return fmt.Errorf("failed to decode watch: %w", err)
+			}
+			if !filter(watch) {
+				continue
+			}
+			if len(watches) == limit {
+				nextPageToken = watches[len(watches)-1].GetId()
+				break
+			}
+			watches = append(watches, watch)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return watches, nextPageToken, nil
+}
+
+// DueWatches returns the watches whose next run time is not after now.
+func (s *Store) DueWatches(now time.Time) ([]*pb.Watch, error) {
+	var watches []*pb.Watch
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(watchBucket)
+		if b == nil {
+			return nil
+		}
+		return b.ForEach(func(_, v []byte) error {
+			watch := &pb.Watch{}
+			if err := proto.Unmarshal(v, watch); err != nil {
+				return fmt.Errorf("failed to decode watch: %w", err)
+			}
+			if !watch.GetNextRunTime().AsTime().After(now) {
+				watches = append(watches, watch)
+			}
+			return nil
+		})
+	})
+	return watches, err
+}
+
+// DeleteWatch deletes the watch with the given ID along with its token,
+// webhook secret, snapshot, events and webhook deliveries.
+func (s *Store) DeleteWatch(id string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(watchBucket)
+		if b == nil || b.Get([]byte(id)) == nil {
+			return ErrNotFound
+		}
+		if err := b.Delete([]byte(id)); err != nil {
+			return err
+		}
+		for _, name := range [][]byte{watchTokenBucket, watchSecretBucket} {
+			if sealed := tx.Bucket(name); sealed != nil {
+				if err := sealed.Delete([]byte(id)); err != nil {
+					return err
+				}
+			}
+		}
+		for _, name := range [][]byte{watchSnapshotBucket, watchEventBucket} {
+			if root := tx.Bucket(name); root != nil && root.Bucket([]byte(id)) != nil {
+				if err := root.DeleteBucket([]byte(id)); err != nil {
+					return err
+				}
+			}
+		}
+		return deleteDeliveries(tx, id)
+	})
+}
+
+// RecordWatchRun stores the outcome of a run of the watch with the given ID.
+// A successful run is compared with the previous snapshot: hits that appeared
+// or disappeared become events, keeping at most maxEvents per watch (0 keeps
+// all), and the hits become the new snapshot. The first successful run only
+// takes the snapshot. Hits missing from a truncated or incomplete run, or
+// from the run after it, are kept in the snapshot instead of being reported
+// as removed. It returns the updated watch and the new events.
+func (s *Store) RecordWatchRun(id string, run WatchRun, maxEvents int) (*pb.Watch, []*pb.WatchEvent, error) {
+	var watch *pb.Watch
+	var events []*pb.WatchEvent
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(watchBucket)
+		var err error
+		watch, err = getWatch(b, id)
+		if err != nil {
+			return err
+		}
+
+		watch.LastRunTime = timestamppb.New(run.Time)
+		watch.NextRunTime = timestamppb.New(run.NextRun)
+		if run.Err != nil {
+			watch.LastError = run.Err.Error()
+			return putWatch(b, watch)
+		}
+		watch.LastError = ""
+		partial := run.Truncated || run.Incomplete
+		previousPartial := watch.GetLastRunTruncated() || watch.GetLastRunIncomplete()
+		watch.LastRunTruncated, watch.LastRunIncomplete = run.Truncated, run.Incomplete
+
+		snapshots, err := tx.CreateBucketIfNotExists(watchSnapshotBucket)
+		if err != nil {
+			return err
+		}
+		current := make(map[string]*pb.WatchHit, len(run.Hits))
+		for _, hit := range run.Hits {
+			current[string(hitKey(hit))] = hit
+		}
+
+		if previous := snapshots.Bucket([]byte(id)); previous != nil {
+			event := func(eventType pb.WatchEvent_Type, hit *pb.WatchHit) {
+				events = append(events, &pb.WatchEvent{
+					Id:      NewID(),
+					WatchId: id,
+					Type:    eventType,
+					Hit:     hit,
+					Time:    timestamppb.New(run.Time),
+				})
+			}
+			err := previous.ForEach(func(k, v []byte) error {
+				if _, ok := current[string(k)]; ok {
+					return nil
+				}
+				hit := &pb.WatchHit{}
+				if err := proto.Unmarshal(v, hit); err != nil {
+					return fmt.Errorf("failed to decode watch hit: %w", err)
+				}
+				if partial || previousPartial {
+					// It may only be missing from one of the runs
+					current[string(k)] = hit
+					return nil
+				}
+				event(pb.WatchEvent_TYPE_REMOVED, hit)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			added := make(map[string]bool)
+			for _, hit := range run.Hits {
+				key := hitKey(hit)
+				if previous.Get(key) == nil && !added[string(key)] {
+					added[string(key)] = true
+					event(pb.WatchEvent_TYPE_ADDED, hit)
+				}
+			}
+			if err := snapshots.DeleteBucket([]byte(id)); err != nil {
+				return err
+			}
+		}
+
+		snapshot, err := snapshots.CreateBucket([]byte(id))
+		if err != nil {
+			return err
+		}
+		for key, hit := range current {
+			value, err := proto.Marshal(hit)
+			if err != nil {
+				return fmt.Errorf("failed to encode watch hit: %w", err)
+			}
+			if err := snapshot.Put([]byte(key), value); err != nil {
+				return err
+			}
+		}
+
+		if len(events) > 0 {
+			root, err := tx.CreateBucketIfNotExists(watchEventBucket)
+			if err != nil {
+				return err
+			}
+			eb, err := root.CreateBucketIfNotExists([]byte(id))
+			if err != nil {
+				return err
+			}
+			for _, event := range events {
+				seq, err := eb.NextSequence()
+				if err != nil {
+					return err
+				}
+				key := eventKey(seq)
+				event.Cursor = eventCursor(key)
+				value, err := proto.Marshal(event)
+				if err != nil {
+					return fmt.Errorf("failed to encode watch event: %w", err)
+				}
+				if err := eb.Put(key, value); err != nil {
+					return err
+				}
+			}
+			if err := trimHistory(eb, HistoryRetention{MaxEntries: maxEvents}, run.Time); err != nil {
+				return err
+			}
+		}
+		return putWatch(b, watch)
+	})
+	return watch, events, err
+}
+
+// ListWatchEvents returns up to limit events of the watch with the given ID,
+// newest first, starting after pageToken, and the token of the next page,
+// which is empty on the last page.
+func (s *Store) ListWatchEvents(id string, limit int, pageToken string) ([]*pb.WatchEvent, string, error) {
+	var events []*pb.WatchEvent
+	var nextPageToken string
+	err := s.db.View(func(tx *bolt.Tx) error {
+		var b *bolt.Bucket
+		if root := tx.Bucket(watchEventBucket); root != nil {
+			b = root.Bucket([]byte(id))
+		}
+		var err error
+		nextPageToken, err = listNewestFirst(b, limit, pageToken, func(v []byte) (bool, error) {
+			event := &pb.WatchEvent{}
+			if err := proto.Unmarshal(v, event); err != nil {
+				return false, fmt.Errorf("failed to decode watch event: %w", err)
+			}
+			events = append(events, event)
+			return true, nil
+		})
+		return err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return events, nextPageToken, nil
+}
+
+// WatchEventsAfter returns up to limit events of the watch with the given
+// ID that were recorded after the event at cursor, oldest first. An empty
+// cursor starts at the oldest event kept.
+func (s *Store) WatchEventsAfter(id, cursor string, limit int) ([]*pb.WatchEvent, error) {
+	var after []byte
+	if cursor != "" {
+		var err error
+		if after, err = base64.RawURLEncoding.DecodeString(cursor); err != nil || len(after) != 8 {
+			return nil, ErrInvalidCursor
+		}
+	}
+
+	var events []*pb.WatchEvent
+	err := s.db.View(func(tx *bolt.Tx) error {
+		root := tx.Bucket(watchEventBucket)
+		if root == nil || root.Bucket([]byte(id)) == nil {
+			return nil
+		}
+		c := root.Bucket([]byte(id)).Cursor()
+		k, v := c.First()
+		if after != nil {
+			k, v = c.Seek(after)
+			if k != nil && bytes.Equal(k, after) {
+				k, v = c.Next()
+			}
+		}
+		for ; k != nil && len(events) < limit; k, v = c.Next() {
+			event := &pb.WatchEvent{}
+			if err := proto.Unmarshal(v, event); err != nil {
+				return fmt.Errorf("failed to decode watch event: %w", err)
+			}
+			event.Cursor = eventCursor(k)
+			events = append(events, event)
+		}
+		return nil
+	})
+	return events, err
+}
+
+// LatestWatchEventCursor returns the cursor of the newest event of the watch
+// with the given ID, or an empty string if it has none.
+func (s *Store) LatestWatchEventCursor(id string) (string, error) {
+	var cursor string
+	err := s.db.View(func(tx *bolt.Tx) error {
+		root := tx.Bucket(watchEventBucket)
+		if root == nil || root.Bucket([]byte(id)) == nil {
+			return nil
+		}
+		if k, _ := root.Bucket([]byte(id)).Cursor().Last(); k != nil {
+			cursor = eventCursor(k)
+		}
+		return nil
+	})
+	return cursor, err
+}
+
+// eventKey builds the key of a watch event from its per-watch sequence
+// number, big-endian so that iteration order is the order the events were
+// recorded in, even if the clock steps back between runs.
+func eventKey(seq uint64) []byte {
+	return binary.BigEndian.AppendUint64(nil, seq)
+}
+
+// eventCursor encodes the key of a watch event as a cursor. Cursors use the
+// same encoding as page tokens.
+func eventCursor(key []byte) string {
+	return base64.RawURLEncoding.EncodeToString(key)
+}
+
+func hitKey(hit *pb.WatchHit) []byte {
+	return []byte(hit.GetRepo() + "\x00" + hit.GetPath() + "\x00" + hit.GetSha())
+}
+
+func getWatch(b *bolt.Bucket, id string) (*pb.Watch, error) {
+	if b == nil {
+		return nil, ErrNotFound
+	}
+	value := b.Get([]byte(id))
+	if value == nil {
+		return nil, ErrNotFound
+	}
+	watch := &pb.Watch{}
+	if err := proto.Unmarshal(value, watch); err != nil {
+		return nil, fmt.Errorf("failed to decode watch: %w", err)
+	}
+	return watch, nil
+}
+
+func putWatch(b *bolt.Bucket, watch *pb.Watch) error {
+	value, err := proto.Marshal(watch)
+	if err != nil {
+		return fmt.Errorf("failed to encode watch: %w", err)
+	}
+	return b.Put([]byte(watch.GetId()), value)
+}
@@ -0,0 +1,149 @@
+package store
+
+import (
+	"errors"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/Pratham700/github-search-service/proto/proto"
+)
+
+func openTestStore(t *testing.T) *Store {
+	t.Helper()
+	s, err := Open(filepath.Join(t.TempDir(), "store.db"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func createTestWatch(t *testing.T, s *Store) string {
+	t.Helper()
+	watch := &pb.Watch{Request: &pb.SearchRequest{SearchTerm: "foo"}, Owner: "token:owner"}
+	if err := s.CreateWatch(watch, func(string) ([]byte, []byte, error) { return []byte("sealed"), nil, nil }); err != nil {
+		t.Fatalf("CreateWatch() error = %v", err)
+	}
+	return watch.GetId()
+}
+
+// hits parses "repo:path:sha" strings into watch hits.
+func hits(specs ...string) []*pb.WatchHit {
+	var hits []*pb.WatchHit
+	for _, spec := range specs {
+		parts := strings.SplitN(spec, ":", 3)
+		hits = append(hits, &pb.WatchHit{Repo: parts[0], Path: parts[1], Sha: parts[2]})
+	}
+	return hits
+}
+
+// eventSpecs formats the events of the given type as sorted "repo:path:sha" strings.
+func eventSpecs(events []*pb.WatchEvent, eventType pb.WatchEvent_Type) []string {
+	var specs []string
+	for _, event := range events {
+		if event.GetType() == eventType {
+			hit := event.GetHit()
+			specs = append(specs, hit.GetRepo()+":"+hit.GetPath()+":"+hit.GetSha())
+		}
+	}
+	slices.Sort(specs)
+	return specs
+}
+
+func TestRecordWatchRunDiff(t *testing.T) {
+	tests := []struct {
+		name           string
+		previous       []*pb.WatchHit
+		current        []*pb.WatchHit
+		added, removed []string
+	}{
+		{"unchanged", hits("o/r:a.go:1", "o/r:b.go:2"), hits("o/r:b.go:2", "o/r:a.go:1"), nil, nil},
+		{"added", hits("o/r:a.go:1"), hits("o/r:a.go:1", "o/r:b.go:2"), []string{"o/r:b.go:2"}, nil},
+		{"removed", hits("o/r:a.go:1", "o/r:b.go:2"), hits("o/r:a.go:1"), nil, []string{"o/r:b.go:2"}},
+		{"content changed", hits("o/r:a.go:1"), hits("o/r:a.go:2"), []string{"o/r:a.go:2"}, []string{"o/r:a.go:1"}},
+		{"same path in another repo", hits("o/r:a.go:1"), hits("o/r:a.go:1", "o/fork:a.go:1"), []string{"o/fork:a.go:1"}, nil},
+		{"duplicate hits", nil, hits("o/r:a.go:1", "o/r:a.go:1"), []string{"o/r:a.go:1"}, nil},
+		{"all removed", hits("o/r:a.go:1", "o/r:b.go:2"), nil, nil, []string{"o/r:a.go:1", "o/r:b.go:2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := openTestStore(t)
+			id := createTestWatch(t, s)
+			start := time.Unix(1700000000, 0)
+			if _, events, err := s.RecordWatchRun(id, WatchRun{Time: start, Hits: tt.previous}, 0); err != nil || len(events) != 0 {
+				t.Fatalf("first RecordWatchRun() = %v, %v, want no events", events, err)
+			}
+
+			_, events, err := s.RecordWatchRun(id, WatchRun{Time: start.Add(time.Minute), Hits: tt.current}, 0)
+			if err != nil {
+				t.Fatalf("RecordWatchRun() error = %v", err)
+			}
+			if got := eventSpecs(events, pb.WatchEvent_TYPE_ADDED); !slices.Equal(got, tt.added) {
+				t.Errorf("added = %v, want %v", got, tt.added)
+			}
+			if got := eventSpecs(events, pb.WatchEvent_TYPE_REMOVED); !slices.Equal(got, tt.removed) {
+				t.Errorf("removed = %v, want %v", got, tt.removed)
+			}
+		})
+	}
+}
+
+func TestRecordWatchRunPartial(t *testing.T) {
+	tests := []struct {
+		name           string
+		run            WatchRun
+		added, removed []string
+	}{
+		{"complete", WatchRun{Hits: hits("o/r:a.go:1", "o/r:b.go:2")}, nil, nil},
+		{"truncated", WatchRun{Hits: hits("o/r:a.go:1", "o/r:c.go:3"), Truncated: true}, []string{"o/r:c.go:3"}, nil},
+		{"after truncated", WatchRun{Hits: hits("o/r:a.go:1")}, nil, nil},
+		{"incomplete", WatchRun{Hits: hits("o/r:a.go:1", "o/r:b.go:2"), Incomplete: true}, nil, nil},
+		{"after incomplete", WatchRun{Hits: hits("o/r:a.go:1", "o/r:b.go:2")}, nil, nil},
+		{"both complete", WatchRun{Hits: hits("o/r:a.go:1")}, nil, []string{"o/r:b.go:2", "o/r:c.go:3"}},
+	}
+	s := openTestStore(t)
+	id := createTestWatch(t, s)
+	start := time.Unix(1700000000, 0)
+	for i, tt := range tests {
+		tt.run.Time = start.Add(time.Duration(i) * time.Minute)
+		watch, events, err := s.RecordWatchRun(id, tt.run, 0)
+		if err != nil {
+			t.Fatalf("%s: RecordWatchRun() error = %v", tt.name, err)
+		}
+		if watch.GetLastRunTruncated() != tt.run.Truncated || watch.GetLastRunIncomplete() != tt.run.Incomplete {
+			t.Errorf("%s: watch truncated, incomplete = %v, %v, want %v, %v", tt.name,
+				watch.GetLastRunTruncated(), watch.GetLastRunIncomplete(), tt.run.Truncated, tt.run.Incomplete)
+		}
+		if got := eventSpecs(events, pb.WatchEvent_TYPE_ADDED); !slices.Equal(got, tt.added) {
+			t.Errorf("%s: added = %v, want %v", tt.name, got, tt.added)
+		}
+		if got := eventSpecs(events, pb.WatchEvent_TYPE_REMOVED); !slices.Equal(got, tt.removed) {
+			t.Errorf("%s: removed = %v, want %v", tt.name, got, tt.removed)
+		}
+	}
+}
+
+func TestRecordWatchRunFailureKeepsSnapshot(t *testing.T) {
+	s := openTestStore(t)
+	id := createTestWatch(t, s)
+	start := time.Unix(1700000000, 0)
+	if _, _, err := s.RecordWatchRun(id, WatchRun{Time: start, Hits: hits("o/r:a.go:1")}, 0); err != nil {
+		t.Fatalf("RecordWatchRun() error = %v", err)
+	}
+
+	watch, events, err := s.RecordWatchRun(id, WatchRun{Time: start.Add(time.Minute), Err: errors.New("rate limited")}, 0)
+	if err != nil {
+		t.Fatalf("RecordWatchRun() of a failed run error = %v", err)
+	}
+	if watch.GetLastError() != "rate limited" || len(events) != 0 {
+		t.Errorf("failed run = %q, %v, want its error and no events", watch.GetLastError(), events)
+	}
+
+	_, events, err = s.RecordWatchRun(id, WatchRun{Time: start.Add(2 * time.Minute), Hits: hits("o/r:a.go:1")}, 0)
+	if err != nil || len(events) != 0 {
+		t.Errorf("RecordWatchRun() after a failed run = %v, %v, want no events", events, err)
+	}
+}
@@ -0,0 +1,102 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// recordSpans installs a tracer provider recording every span for the
+// duration of the test.
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+	return recorder
+}
+
+// spanAttributes returns the attributes of span by key.
+func spanAttributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, attr := range span.Attributes() {
+		attrs[attr.Key] = attr.Value
+	}
+	return attrs
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	const traceparent = "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"
+	tests := []struct {
+		name       string
+		md         metadata.MD
+		err        error
+		wantCode   grpccodes.Code
+		wantStatus codes.Code
+		wantParent bool
+	}{
+		{"ok", nil, nil, grpccodes.OK, codes.Unset, false},
+		{"continues the caller's trace", metadata.Pairs("traceparent", traceparent), nil, grpccodes.OK, codes.Unset, true},
+		{"status error", nil, status.Error(grpccodes.NotFound, "missing"), grpccodes.NotFound, codes.Error, false},
+		{"context error", nil, context.DeadlineExceeded, grpccodes.DeadlineExceeded, codes.Error, false},
+		{"plain error", nil, errors.New("broken"), grpccodes.Unknown, codes.Error, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := recordSpans(t)
+			ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+			info := &grpc.UnaryServerInfo{FullMethod: "/pkg.Service/Method"}
+			var handlerSpan trace.SpanContext
+			UnaryServerInterceptor(ctx, nil, info, func(ctx context.Context, _ interface{}) (interface{}, error) {
+				handlerSpan = trace.SpanContextFromContext(ctx)
+				return nil, tt.err
+			})
+
+			spans := recorder.Ended()
+			if len(spans) != 1 {
+				t.Fatalf("ended %d spans, want 1", len(spans))
+			}
+			span := spans[0]
+			if span.Name() != "pkg.Service/Method" || span.SpanKind() != trace.SpanKindServer {
+				t.Errorf("span = %s (%v), want pkg.Service/Method (server)", span.Name(), span.SpanKind())
+			}
+			if handlerSpan.SpanID() != span.SpanContext().SpanID() {
+				t.Errorf("the handler does not run in the RPC span")
+			}
+			attrs := spanAttributes(span)
+			want := map[attribute.Key]string{"rpc.system": "grpc", "rpc.service": "pkg.Service", "rpc.method": "Method"}
+			for key, value := range want {
+				if attrs[key].AsString() != value {
+					t.Errorf("%s = %q, want %q", key, attrs[key].AsString(), value)
+				}
+			}
+			if got := attrs["rpc.grpc.status_code"].AsInt64(); got != int64(tt.wantCode) {
+				t.Errorf("rpc.grpc.status_code = %d, want %d", got, tt.wantCode)
+			}
+			if span.Status().Code != tt.wantStatus {
+				t.Errorf("status = %v, want %v", span.Status().Code, tt.wantStatus)
+			}
+			if got := span.Parent().TraceID().String() == "0af7651916cd43dd8448eb211c80319c"; got != tt.wantParent {
+				t.Errorf("parent = %v, want the caller's span: %v", span.Parent(), tt.wantParent)
+			}
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package util
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// HashToken returns a short, non-reversible identifier for a token, suitable
+// as a map key or metric label where the token itself must never appear.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:8])
+}
@@ -7,8 +7,13 @@
 package proto
 
 import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -21,6 +26,111 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// How Search collapses results holding the same file.
+type DedupMode int32
+
+const (
+	DedupMode_DEDUP_UNSPECIFIED DedupMode = 0 // No deduplication
+	DedupMode_DEDUP_BLOB        DedupMode = 1 // Collapse files with the same blob SHA, such as vendored copies and unchanged forks
+	// Also collapse files at the same path in repositories of the same fork
+	// network, even if they differ; looks up the forks' repositories
+	DedupMode_DEDUP_FORK DedupMode = 2
+)
+
+// Enum value maps for DedupMode.
+var (
+	DedupMode_name = map[int32]string{
+		0: "DEDUP_UNSPECIFIED",
+		1: "DEDUP_BLOB",
+		2: "DEDUP_FORK",
+	}
+	DedupMode_value = map[string]int32{
+		"DEDUP_UNSPECIFIED": 0,
+		"DEDUP_BLOB":        1,
+		"DEDUP_FORK":        2,
+	}
+)
+
+func (x DedupMode) Enum() *DedupMode {
+	p := new(DedupMode)
+	*p = x
+	return p
+}
+
+func (x DedupMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DedupMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_github_search_service_proto_enumTypes[0].Descriptor()
+}
+
+func (DedupMode) Type() protoreflect.EnumType {
+	return &file_proto_github_search_service_proto_enumTypes[0]
+}
+
+func (x DedupMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DedupMode.Descriptor instead.
+func (DedupMode) EnumDescriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{0}
+}
+
+// Which result of a set of duplicates Search returns.
+type Representative int32
+
+const (
+	Representative_REPRESENTATIVE_UNSPECIFIED Representative = 0 // Same as REPRESENTATIVE_ORIGINAL
+	// The first file outside forks, then outside vendored directories such as vendor/
+	Representative_REPRESENTATIVE_ORIGINAL Representative = 1
+	// The file in the repository with the most stars, then as REPRESENTATIVE_ORIGINAL;
+	// looks up the repositories
+	Representative_REPRESENTATIVE_MOST_STARS Representative = 2
+)
+
+// Enum value maps for Representative.
+var (
+	Representative_name = map[int32]string{
+		0: "REPRESENTATIVE_UNSPECIFIED",
+		1: "REPRESENTATIVE_ORIGINAL",
+		2: "REPRESENTATIVE_MOST_STARS",
+	}
+	Representative_value = map[string]int32{
+		"REPRESENTATIVE_UNSPECIFIED": 0,
+		"REPRESENTATIVE_ORIGINAL":    1,
+		"REPRESENTATIVE_MOST_STARS":  2,
+	}
+)
+
+func (x Representative) Enum() *Representative {
+	p := new(Representative)
+	*p = x
+	return p
+}
+
+func (x Representative) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Representative) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_github_search_service_proto_enumTypes[1].Descriptor()
+}
+
+func (Representative) Type() protoreflect.EnumType {
+	return &file_proto_github_search_service_proto_enumTypes[1]
+}
+
+func (x Representative) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Representative.Descriptor instead.
+func (Representative) EnumDescriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{1}
+}
+
 type SortOption int32
 
 const (
@@ -51,11 +161,11 @@ func (x SortOption) String() string {
 }
 
 func (SortOption) Descriptor() protoreflect.EnumDescriptor {
-	return file_proto_github_search_service_proto_enumTypes[0].Descriptor()
+	return file_proto_github_search_service_proto_enumTypes[2].Descriptor()
 }
 
 func (SortOption) Type() protoreflect.EnumType {
-	return &file_proto_github_search_service_proto_enumTypes[0]
+	return &file_proto_github_search_service_proto_enumTypes[2]
 }
 
 func (x SortOption) Number() protoreflect.EnumNumber {
@@ -64,7 +174,7 @@ func (x SortOption) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use SortOption.Descriptor instead.
 func (SortOption) EnumDescriptor() ([]byte, []int) {
-	return file_proto_github_search_service_proto_rawDescGZIP(), []int{0}
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{2}
 }
 
 type OrderOption int32
@@ -100,11 +210,11 @@ func (x OrderOption) String() string {
 }
 
 func (OrderOption) Descriptor() protoreflect.EnumDescriptor {
-	return file_proto_github_search_service_proto_enumTypes[1].Descriptor()
+	return file_proto_github_search_service_proto_enumTypes[3].Descriptor()
 }
 
 func (OrderOption) Type() protoreflect.EnumType {
-	return &file_proto_github_search_service_proto_enumTypes[1]
+	return &file_proto_github_search_service_proto_enumTypes[3]
 }
 
 func (x OrderOption) Number() protoreflect.EnumNumber {
@@ -113,17 +223,142 @@ func (x OrderOption) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use OrderOption.Descriptor instead.
 func (OrderOption) EnumDescriptor() ([]byte, []int) {
-	return file_proto_github_search_service_proto_rawDescGZIP(), []int{1}
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{3}
+}
+
+type WatchEvent_Type int32
+
+const (
+	WatchEvent_TYPE_UNSPECIFIED WatchEvent_Type = 0
+	WatchEvent_TYPE_ADDED       WatchEvent_Type = 1 // The hit is new since the previous run
+	WatchEvent_TYPE_REMOVED     WatchEvent_Type = 2 // The hit is no longer returned
+)
+
+// Enum value maps for WatchEvent_Type.
+var (
+	WatchEvent_Type_name = map[int32]string{
+		0: "TYPE_UNSPECIFIED",
+		1: "TYPE_ADDED",
+		2: "TYPE_REMOVED",
+	}
+	WatchEvent_Type_value = map[string]int32{
+		"TYPE_UNSPECIFIED": 0,
+		"TYPE_ADDED":       1,
+		"TYPE_REMOVED":     2,
+	}
+)
+
+func (x WatchEvent_Type) Enum() *WatchEvent_Type {
+	p := new(WatchEvent_Type)
+	*p = x
+	return p
+}
+
+func (x WatchEvent_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WatchEvent_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_github_search_service_proto_enumTypes[4].Descriptor()
+}
+
+func (WatchEvent_Type) Type() protoreflect.EnumType {
+	return &file_proto_github_search_service_proto_enumTypes[4]
+}
+
+func (x WatchEvent_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WatchEvent_Type.Descriptor instead.
+func (WatchEvent_Type) EnumDescriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{22, 0}
+}
+
+type WebhookDelivery_State int32
+
+const (
+	WebhookDelivery_STATE_UNSPECIFIED WebhookDelivery_State = 0
+	WebhookDelivery_STATE_PENDING     WebhookDelivery_State = 1 // Not delivered yet; retried at next_attempt_time
+	WebhookDelivery_STATE_SUCCEEDED   WebhookDelivery_State = 2 // The receiver answered with a 2xx status
+	WebhookDelivery_STATE_DEAD_LETTER WebhookDelivery_State = 3 // Every attempt failed; no further attempts are made
+)
+
+// Enum value maps for WebhookDelivery_State.
+var (
+	WebhookDelivery_State_name = map[int32]string{
+		0: "STATE_UNSPECIFIED",
+		1: "STATE_PENDING",
+		2: "STATE_SUCCEEDED",
+		3: "STATE_DEAD_LETTER",
+	}
+	WebhookDelivery_State_value = map[string]int32{
+		"STATE_UNSPECIFIED": 0,
+		"STATE_PENDING":     1,
+		"STATE_SUCCEEDED":   2,
+		"STATE_DEAD_LETTER": 3,
+	}
+)
+
+func (x WebhookDelivery_State) Enum() *WebhookDelivery_State {
+	p := new(WebhookDelivery_State)
+	*p = x
+	return p
+}
+
+func (x WebhookDelivery_State) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WebhookDelivery_State) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_github_search_service_proto_enumTypes[5].Descriptor()
+}
+
+func (WebhookDelivery_State) Type() protoreflect.EnumType {
+	return &file_proto_github_search_service_proto_enumTypes[5]
+}
+
+func (x WebhookDelivery_State) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WebhookDelivery_State.Descriptor instead.
+func (WebhookDelivery_State) EnumDescriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{33, 0}
 }
 
+// SearchRequest describes a GitHub code search. The field annotations document
+// the constraints the server enforces; violating them yields INVALID_ARGUMENT.
 type SearchRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	SearchTerm    string                 `protobuf:"bytes,1,opt,name=search_term,json=searchTerm,proto3" json:"search_term,omitempty"`
-	User          string                 `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
-	Sort          SortOption             `protobuf:"varint,3,opt,name=sort,proto3,enum=githubsearchservice.SortOption" json:"sort,omitempty"`
-	Order         OrderOption            `protobuf:"varint,4,opt,name=order,proto3,enum=githubsearchservice.OrderOption" json:"order,omitempty"`
-	PerPage       *int32                 `protobuf:"varint,5,opt,name=per_page,json=perPage,proto3,oneof" json:"per_page,omitempty"`
-	Page          *int32                 `protobuf:"varint,6,opt,name=page,proto3,oneof" json:"page,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The code search query. It is required because GitHub's code search
+	// rejects a query without search terms; qualifiers such as "language:go"
+	// can be appended to it, and "user" adds a user qualifier.
+	SearchTerm string      `protobuf:"bytes,1,opt,name=search_term,json=searchTerm,proto3" json:"search_term,omitempty"`
+	User       string      `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"` // Restricts the search to this user's repositories
+	Sort       SortOption  `protobuf:"varint,3,opt,name=sort,proto3,enum=githubsearchservice.SortOption" json:"sort,omitempty"`
+	Order      OrderOption `protobuf:"varint,4,opt,name=order,proto3,enum=githubsearchservice.OrderOption" json:"order,omitempty"`
+	PerPage    *int32      `protobuf:"varint,5,opt,name=per_page,json=perPage,proto3,oneof" json:"per_page,omitempty"`
+	Page       *int32      `protobuf:"varint,6,opt,name=page,proto3,oneof" json:"page,omitempty"`
+	// Collapses duplicates among the results of the page. Dedup and ranking
+	// apply only to Search, RunSavedSearch and BatchSearch; the RPCs that
+	// return hits as a set reject them.
+	Dedup          DedupMode      `protobuf:"varint,7,opt,name=dedup,proto3,enum=githubsearchservice.DedupMode" json:"dedup,omitempty"`
+	Representative Representative `protobuf:"varint,8,opt,name=representative,proto3,enum=githubsearchservice.Representative" json:"representative,omitempty"`
+	// Filters applied to the results by the server, which fetches further
+	// pages from GitHub to fill the page. They also apply to the hits of
+	// watches, facets, snapshots and exhaustive searches. Paths are matched against globs
+	// whose "**" segments match any number of directories, e.g. "**/vendor/**".
+	// Repositories are full names, e.g. octocat/hello-world, matched ignoring case.
+	IncludePaths []string `protobuf:"bytes,9,rep,name=include_paths,json=includePaths,proto3" json:"include_paths,omitempty"` // If set, only paths matching one of these globs are kept
+	ExcludePaths []string `protobuf:"bytes,10,rep,name=exclude_paths,json=excludePaths,proto3" json:"exclude_paths,omitempty"`
+	IncludeRepos []string `protobuf:"bytes,11,rep,name=include_repos,json=includeRepos,proto3" json:"include_repos,omitempty"` // If set, only these repositories are kept
+	ExcludeRepos []string `protobuf:"bytes,12,rep,name=exclude_repos,json=excludeRepos,proto3" json:"exclude_repos,omitempty"`
+	ExcludeForks bool     `protobuf:"varint,13,opt,name=exclude_forks,json=excludeForks,proto3" json:"exclude_forks,omitempty"`
+	// Looks up the repositories of the results with the caller's token
+	ExcludeArchived bool `protobuf:"varint,14,opt,name=exclude_archived,json=excludeArchived,proto3" json:"exclude_archived,omitempty"`
+	// Re-ranks the results of the page by score instead of GitHub's order
+	Ranking       *RankingWeights `protobuf:"bytes,15,opt,name=ranking,proto3" json:"ranking,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -200,6 +435,161 @@ func (x *SearchRequest) GetPage() int32 {
 	return 0
 }
 
+func (x *SearchRequest) GetDedup() DedupMode {
+	if x != nil {
+		return x.Dedup
+	}
+	return DedupMode_DEDUP_UNSPECIFIED
+}
+
+func (x *SearchRequest) GetRepresentative() Representative {
+	if x != nil {
+		return x.Representative
+	}
+	return Representative_REPRESENTATIVE_UNSPECIFIED
+}
+
+func (x *SearchRequest) GetIncludePaths() []string {
+	if x != nil {
+		return x.IncludePaths
+	}
+	return nil
+}
+
+func (x *SearchRequest) GetExcludePaths() []string {
+	if x != nil {
+		return x.ExcludePaths
+	}
+	return nil
+}
+
+func (x *SearchRequest) GetIncludeRepos() []string {
+	if x != nil {
+		return x.IncludeRepos
+	}
+	return nil
+}
+
+func (x *SearchRequest) GetExcludeRepos() []string {
+	if x != nil {
+		return x.ExcludeRepos
+	}
+	return nil
+}
+
+func (x *SearchRequest) GetExcludeForks() bool {
+	if x != nil {
+		return x.ExcludeForks
+	}
+	return false
+}
+
+func (x *SearchRequest) GetExcludeArchived() bool {
+	if x != nil {
+		return x.ExcludeArchived
+	}
+	return false
+}
+
+func (x *SearchRequest) GetRanking() *RankingWeights {
+	if x != nil {
+		return x.Ranking
+	}
+	return nil
+}
+
+// Weights of the scorers that re-rank search results. Each scorer rates a
+// result between 0 and 1, and the score of the result is the weighted sum.
+type RankingWeights struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 1 for files at the repository root, 1/2 one directory down, 1/3 two down
+	PathDepth float64 `protobuf:"fixed64,1,opt,name=path_depth,json=pathDepth,proto3" json:"path_depth,omitempty"`
+	// 1 for files that are not tests or test data
+	NonTest float64 `protobuf:"fixed64,2,opt,name=non_test,json=nonTest,proto3" json:"non_test,omitempty"`
+	// Grows with the log of the repository's stars, 1 from 10000; looks up the repositories
+	Stars float64 `protobuf:"fixed64,3,opt,name=stars,proto3" json:"stars,omitempty"`
+	// 1 for repositories pushed to now, halving every 90 days; looks up the repositories
+	Recency float64 `protobuf:"fixed64,4,opt,name=recency,proto3" json:"recency,omitempty"`
+	// 1 for repositories owned by the caller or one of its organizations
+	CallerOwned float64 `protobuf:"fixed64,5,opt,name=caller_owned,json=callerOwned,proto3" json:"caller_owned,omitempty"`
+	// Grows with the number of matches of the search term in the file, 1 from 10
+	TextMatches   float64 `protobuf:"fixed64,6,opt,name=text_matches,json=textMatches,proto3" json:"text_matches,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RankingWeights) Reset() {
+	*x = RankingWeights{}
+	mi := &file_proto_github_search_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RankingWeights) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RankingWeights) ProtoMessage() {}
+
+func (x *RankingWeights) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RankingWeights.ProtoReflect.Descriptor instead.
+func (*RankingWeights) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RankingWeights) GetPathDepth() float64 {
+	if x != nil {
+		return x.PathDepth
+	}
+	return 0
+}
+
+func (x *RankingWeights) GetNonTest() float64 {
+	if x != nil {
+		return x.NonTest
+	}
+	return 0
+}
+
+func (x *RankingWeights) GetStars() float64 {
+	if x != nil {
+		return x.Stars
+	}
+	return 0
+}
+
+func (x *RankingWeights) GetRecency() float64 {
+	if x != nil {
+		return x.Recency
+	}
+	return 0
+}
+
+func (x *RankingWeights) GetCallerOwned() float64 {
+	if x != nil {
+		return x.CallerOwned
+	}
+	return 0
+}
+
+func (x *RankingWeights) GetTextMatches() float64 {
+	if x != nil {
+		return x.TextMatches
+	}
+	return 0
+}
+
 type SearchResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Results       []*Result              `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
@@ -209,7 +599,7 @@ type SearchResponse struct {
 
 func (x *SearchResponse) Reset() {
 	*x = SearchResponse{}
-	mi := &file_proto_github_search_service_proto_msgTypes[1]
+	mi := &file_proto_github_search_service_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -221,7 +611,7 @@ func (x *SearchResponse) String() string {
 func (*SearchResponse) ProtoMessage() {}
 
 func (x *SearchResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_github_search_service_proto_msgTypes[1]
+	mi := &file_proto_github_search_service_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -234,7 +624,7 @@ func (x *SearchResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SearchResponse.ProtoReflect.Descriptor instead.
 func (*SearchResponse) Descriptor() ([]byte, []int) {
-	return file_proto_github_search_service_proto_rawDescGZIP(), []int{1}
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *SearchResponse) GetResults() []*Result {
@@ -245,16 +635,18 @@ func (x *SearchResponse) GetResults() []*Result {
 }
 
 type Result struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	FileUrl       string                 `protobuf:"bytes,1,opt,name=file_url,json=fileUrl,proto3" json:"file_url,omitempty"`
-	Repo          string                 `protobuf:"bytes,2,opt,name=repo,proto3" json:"repo,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	FileUrl        string                 `protobuf:"bytes,1,opt,name=file_url,json=fileUrl,proto3" json:"file_url,omitempty"`
+	Repo           string                 `protobuf:"bytes,2,opt,name=repo,proto3" json:"repo,omitempty"`
+	DuplicateCount int32                  `protobuf:"varint,3,opt,name=duplicate_count,json=duplicateCount,proto3" json:"duplicate_count,omitempty"` // Results collapsed into this one by dedup
+	Score          float64                `protobuf:"fixed64,4,opt,name=score,proto3" json:"score,omitempty"`                                        // Set if the request has ranking weights
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *Result) Reset() {
 	*x = Result{}
-	mi := &file_proto_github_search_service_proto_msgTypes[2]
+	mi := &file_proto_github_search_service_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -266,7 +658,7 @@ func (x *Result) String() string {
 func (*Result) ProtoMessage() {}
 
 func (x *Result) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_github_search_service_proto_msgTypes[2]
+	mi := &file_proto_github_search_service_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -279,7 +671,7 @@ func (x *Result) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Result.ProtoReflect.Descriptor instead.
 func (*Result) Descriptor() ([]byte, []int) {
-	return file_proto_github_search_service_proto_rawDescGZIP(), []int{2}
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *Result) GetFileUrl() string {
@@ -296,37 +688,3705 @@ func (x *Result) GetRepo() string {
 	return ""
 }
 
-var File_proto_github_search_service_proto protoreflect.FileDescriptor
+func (x *Result) GetDuplicateCount() int32 {
+	if x != nil {
+		return x.DuplicateCount
+	}
+	return 0
+}
 
-const file_proto_github_search_service_proto_rawDesc = "" +
-	"\n" +
-	"!proto/github_search_service.proto\x12\x13githubsearchservice\"\x80\x02\n" +
-	"\rSearchRequest\x12\x1f\n" +
-	"\vsearch_term\x18\x01 \x01(\tR\n" +
-	"searchTerm\x12\x12\n" +
-	"\x04user\x18\x02 \x01(\tR\x04user\x123\n" +
-	"\x04sort\x18\x03 \x01(\x0e2\x1f.githubsearchservice.SortOptionR\x04sort\x126\n" +
-	"\x05order\x18\x04 \x01(\x0e2 .githubsearchservice.OrderOptionR\x05order\x12\x1e\n" +
-	"\bper_page\x18\x05 \x01(\x05H\x00R\aperPage\x88\x01\x01\x12\x17\n" +
-	"\x04page\x18\x06 \x01(\x05H\x01R\x04page\x88\x01\x01B\v\n" +
-	"\t_per_pageB\a\n" +
-	"\x05_page\"G\n" +
-	"\x0eSearchResponse\x125\n" +
-	"\aresults\x18\x01 \x03(\v2\x1b.githubsearchservice.ResultR\aresults\"7\n" +
-	"\x06Result\x12\x19\n" +
-	"\bfile_url\x18\x01 \x01(\tR\afileUrl\x12\x12\n" +
-	"\x04repo\x18\x02 \x01(\tR\x04repo*4\n" +
-	"\n" +
-	"SortOption\x12\x14\n" +
-	"\x10SORT_UNSPECIFIED\x10\x00\x12\x10\n" +
-	"\fSORT_INDEXED\x10\x01*C\n" +
-	"\vOrderOption\x12\x15\n" +
-	"\x11ORDER_UNSPECIFIED\x10\x00\x12\r\n" +
-	"\tORDER_ASC\x10\x01\x12\x0e\n" +
-	"\n" +
-	"ORDER_DESC\x10\x022h\n" +
-	"\x13GithubSearchService\x12Q\n" +
-	"\x06Search\x12\".githubsearchservice.SearchRequest\x1a#.githubsearchservice.SearchResponseB3Z1github.com/Pratham700/github-search-service/protob\x06proto3"
+func (x *Result) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type GetQuotaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetQuotaRequest) Reset() {
+	*x = GetQuotaRequest{}
+	mi := &file_proto_github_search_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQuotaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQuotaRequest) ProtoMessage() {}
+
+func (x *GetQuotaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQuotaRequest.ProtoReflect.Descriptor instead.
+func (*GetQuotaRequest) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{4}
+}
+
+// GetQuotaResponse reports the caller's remaining budget. A limit of 0 means
+// the corresponding quota is not enforced.
+type GetQuotaResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	RequestsPerMinute int32                  `protobuf:"varint,1,opt,name=requests_per_minute,json=requestsPerMinute,proto3" json:"requests_per_minute,omitempty"`
+	RequestsRemaining int32                  `protobuf:"varint,2,opt,name=requests_remaining,json=requestsRemaining,proto3" json:"requests_remaining,omitempty"`
+	ResultsPerDay     int32                  `protobuf:"varint,3,opt,name=results_per_day,json=resultsPerDay,proto3" json:"results_per_day,omitempty"`
+	ResultsRemaining  int32                  `protobuf:"varint,4,opt,name=results_remaining,json=resultsRemaining,proto3" json:"results_remaining,omitempty"`
+	ResultsResetTime  *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=results_reset_time,json=resultsResetTime,proto3" json:"results_reset_time,omitempty"` // When the results budget is fully replenished
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GetQuotaResponse) Reset() {
+	*x = GetQuotaResponse{}
+	mi := &file_proto_github_search_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQuotaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQuotaResponse) ProtoMessage() {}
+
+func (x *GetQuotaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQuotaResponse.ProtoReflect.Descriptor instead.
+func (*GetQuotaResponse) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetQuotaResponse) GetRequestsPerMinute() int32 {
+	if x != nil {
+		return x.RequestsPerMinute
+	}
+	return 0
+}
+
+func (x *GetQuotaResponse) GetRequestsRemaining() int32 {
+	if x != nil {
+		return x.RequestsRemaining
+	}
+	return 0
+}
+
+func (x *GetQuotaResponse) GetResultsPerDay() int32 {
+	if x != nil {
+		return x.ResultsPerDay
+	}
+	return 0
+}
+
+func (x *GetQuotaResponse) GetResultsRemaining() int32 {
+	if x != nil {
+		return x.ResultsRemaining
+	}
+	return 0
+}
+
+func (x *GetQuotaResponse) GetResultsResetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ResultsResetTime
+	}
+	return nil
+}
+
+// ListRecentSearchesRequest pages through the caller's search history, newest first.
+type ListRecentSearchesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // Defaults to 20
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // next_page_token of a previous response
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRecentSearchesRequest) Reset() {
+	*x = ListRecentSearchesRequest{}
+	mi := &file_proto_github_search_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRecentSearchesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRecentSearchesRequest) ProtoMessage() {}
+
+func (x *ListRecentSearchesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRecentSearchesRequest.ProtoReflect.Descriptor instead.
+func (*ListRecentSearchesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListRecentSearchesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListRecentSearchesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// RecentSearch is a search the caller ran, with the filters it used.
+type RecentSearch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Request       *SearchRequest         `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	ResultCount   int32                  `protobuf:"varint,3,opt,name=result_count,json=resultCount,proto3" json:"result_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecentSearch) Reset() {
+	*x = RecentSearch{}
+	mi := &file_proto_github_search_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecentSearch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecentSearch) ProtoMessage() {}
+
+func (x *RecentSearch) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecentSearch.ProtoReflect.Descriptor instead.
+func (*RecentSearch) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RecentSearch) GetRequest() *SearchRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *RecentSearch) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *RecentSearch) GetResultCount() int32 {
+	if x != nil {
+		return x.ResultCount
+	}
+	return 0
+}
+
+type ListRecentSearchesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Searches      []*RecentSearch        `protobuf:"bytes,1,rep,name=searches,proto3" json:"searches,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Empty on the last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRecentSearchesResponse) Reset() {
+	*x = ListRecentSearchesResponse{}
+	mi := &file_proto_github_search_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRecentSearchesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRecentSearchesResponse) ProtoMessage() {}
+
+func (x *ListRecentSearchesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRecentSearchesResponse.ProtoReflect.Descriptor instead.
+func (*ListRecentSearchesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListRecentSearchesResponse) GetSearches() []*RecentSearch {
+	if x != nil {
+		return x.Searches
+	}
+	return nil
+}
+
+func (x *ListRecentSearchesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// DeleteSearchHistoryRequest deletes the caller's searches older than before,
+// or the whole history when before is unset.
+type DeleteSearchHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Before        *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=before,proto3" json:"before,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSearchHistoryRequest) Reset() {
+	*x = DeleteSearchHistoryRequest{}
+	mi := &file_proto_github_search_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSearchHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSearchHistoryRequest) ProtoMessage() {}
+
+func (x *DeleteSearchHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSearchHistoryRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSearchHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DeleteSearchHistoryRequest) GetBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Before
+	}
+	return nil
+}
+
+type DeleteSearchHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeletedCount  int32                  `protobuf:"varint,1,opt,name=deleted_count,json=deletedCount,proto3" json:"deleted_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSearchHistoryResponse) Reset() {
+	*x = DeleteSearchHistoryResponse{}
+	mi := &file_proto_github_search_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSearchHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSearchHistoryResponse) ProtoMessage() {}
+
+func (x *DeleteSearchHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSearchHistoryResponse.ProtoReflect.Descriptor instead.
+func (*DeleteSearchHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DeleteSearchHistoryResponse) GetDeletedCount() int32 {
+	if x != nil {
+		return x.DeletedCount
+	}
+	return 0
+}
+
+// SavedSearch is a named, reusable search. Only its owner can change or
+// delete it; shared saved searches can be read and run by every caller.
+type SavedSearch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"` // Unique among the owner's saved searches
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Tags          []string               `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
+	Request       *SearchRequest         `protobuf:"bytes,5,opt,name=request,proto3" json:"request,omitempty"`
+	Owner         string                 `protobuf:"bytes,6,opt,name=owner,proto3" json:"owner,omitempty"` // Identity of the caller that created it
+	Shared        bool                   `protobuf:"varint,7,opt,name=shared,proto3" json:"shared,omitempty"`
+	CreateTime    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	UpdateTime    *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SavedSearch) Reset() {
+	*x = SavedSearch{}
+	mi := &file_proto_github_search_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SavedSearch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SavedSearch) ProtoMessage() {}
+
+func (x *SavedSearch) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SavedSearch.ProtoReflect.Descriptor instead.
+func (*SavedSearch) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SavedSearch) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SavedSearch) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SavedSearch) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *SavedSearch) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *SavedSearch) GetRequest() *SearchRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *SavedSearch) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *SavedSearch) GetShared() bool {
+	if x != nil {
+		return x.Shared
+	}
+	return false
+}
+
+func (x *SavedSearch) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *SavedSearch) GetUpdateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdateTime
+	}
+	return nil
+}
+
+type CreateSavedSearchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SavedSearch   *SavedSearch           `protobuf:"bytes,1,opt,name=saved_search,json=savedSearch,proto3" json:"saved_search,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSavedSearchRequest) Reset() {
+	*x = CreateSavedSearchRequest{}
+	mi := &file_proto_github_search_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSavedSearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSavedSearchRequest) ProtoMessage() {}
+
+func (x *CreateSavedSearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSavedSearchRequest.ProtoReflect.Descriptor instead.
+func (*CreateSavedSearchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CreateSavedSearchRequest) GetSavedSearch() *SavedSearch {
+	if x != nil {
+		return x.SavedSearch
+	}
+	return nil
+}
+
+type GetSavedSearchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSavedSearchRequest) Reset() {
+	*x = GetSavedSearchRequest{}
+	mi := &file_proto_github_search_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSavedSearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSavedSearchRequest) ProtoMessage() {}
+
+func (x *GetSavedSearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSavedSearchRequest.ProtoReflect.Descriptor instead.
+func (*GetSavedSearchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetSavedSearchRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// ListSavedSearchesRequest pages through the caller's own and shared saved
+// searches, oldest first.
+type ListSavedSearchesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // Defaults to 20
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // next_page_token of a previous response
+	Tag           string                 `protobuf:"bytes,3,opt,name=tag,proto3" json:"tag,omitempty"`                              // Only return saved searches with this tag
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSavedSearchesRequest) Reset() {
+	*x = ListSavedSearchesRequest{}
+	mi := &file_proto_github_search_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSavedSearchesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSavedSearchesRequest) ProtoMessage() {}
+
+func (x *ListSavedSearchesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSavedSearchesRequest.ProtoReflect.Descriptor instead.
+func (*ListSavedSearchesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListSavedSearchesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListSavedSearchesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListSavedSearchesRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+type ListSavedSearchesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SavedSearches []*SavedSearch         `protobuf:"bytes,1,rep,name=saved_searches,json=savedSearches,proto3" json:"saved_searches,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Empty on the last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSavedSearchesResponse) Reset() {
+	*x = ListSavedSearchesResponse{}
+	mi := &file_proto_github_search_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSavedSearchesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSavedSearchesResponse) ProtoMessage() {}
+
+func (x *ListSavedSearchesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSavedSearchesResponse.ProtoReflect.Descriptor instead.
+func (*ListSavedSearchesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListSavedSearchesResponse) GetSavedSearches() []*SavedSearch {
+	if x != nil {
+		return x.SavedSearches
+	}
+	return nil
+}
+
+func (x *ListSavedSearchesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// UpdateSavedSearchRequest replaces the fields of saved_search listed in
+// update_mask (name, description, tags, request, shared), or all of them
+// when the mask is empty.
+type UpdateSavedSearchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SavedSearch   *SavedSearch           `protobuf:"bytes,1,opt,name=saved_search,json=savedSearch,proto3" json:"saved_search,omitempty"` // Identified by its id
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateSavedSearchRequest) Reset() {
+	*x = UpdateSavedSearchRequest{}
+	mi := &file_proto_github_search_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSavedSearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSavedSearchRequest) ProtoMessage() {}
+
+func (x *UpdateSavedSearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSavedSearchRequest.ProtoReflect.Descriptor instead.
+func (*UpdateSavedSearchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *UpdateSavedSearchRequest) GetSavedSearch() *SavedSearch {
+	if x != nil {
+		return x.SavedSearch
+	}
+	return nil
+}
+
+func (x *UpdateSavedSearchRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type DeleteSavedSearchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSavedSearchRequest) Reset() {
+	*x = DeleteSavedSearchRequest{}
+	mi := &file_proto_github_search_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSavedSearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSavedSearchRequest) ProtoMessage() {}
+
+func (x *DeleteSavedSearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSavedSearchRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSavedSearchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DeleteSavedSearchRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteSavedSearchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSavedSearchResponse) Reset() {
+	*x = DeleteSavedSearchResponse{}
+	mi := &file_proto_github_search_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSavedSearchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSavedSearchResponse) ProtoMessage() {}
+
+func (x *DeleteSavedSearchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSavedSearchResponse.ProtoReflect.Descriptor instead.
+func (*DeleteSavedSearchResponse) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{18}
+}
+
+// RunSavedSearchRequest runs a saved search, optionally fetching another page of results.
+type RunSavedSearchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Page          *int32                 `protobuf:"varint,2,opt,name=page,proto3,oneof" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunSavedSearchRequest) Reset() {
+	*x = RunSavedSearchRequest{}
+	mi := &file_proto_github_search_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunSavedSearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunSavedSearchRequest) ProtoMessage() {}
+
+func (x *RunSavedSearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunSavedSearchRequest.ProtoReflect.Descriptor instead.
+func (*RunSavedSearchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RunSavedSearchRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RunSavedSearchRequest) GetPage() int32 {
+	if x != nil && x.Page != nil {
+		return *x.Page
+	}
+	return 0
+}
+
+// Watch re-runs a search on a schedule and records the hits that appear or
+// disappear between runs as WatchEvents. Watches are private to their owner
+// and run with the GitHub token passed to CreateWatch, which the server
+// stores encrypted until the watch is deleted, so they only see what that
+// token can see. Revoking the token makes the runs fail; recreate the watch
+// with a new token.
+type Watch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Request       *SearchRequest         `protobuf:"bytes,2,opt,name=request,proto3" json:"request,omitempty"`
+	Interval      *durationpb.Duration   `protobuf:"bytes,3,opt,name=interval,proto3" json:"interval,omitempty"` // At least the server's minimum watch interval
+	Owner         string                 `protobuf:"bytes,4,opt,name=owner,proto3" json:"owner,omitempty"`
+	CreateTime    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	LastRunTime   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_run_time,json=lastRunTime,proto3" json:"last_run_time,omitempty"`
+	NextRunTime   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=next_run_time,json=nextRunTime,proto3" json:"next_run_time,omitempty"`
+	LastError     string                 `protobuf:"bytes,8,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`              // Why the last run failed, empty if it succeeded
+	WebhookUrl    string                 `protobuf:"bytes,9,opt,name=webhook_url,json=webhookUrl,proto3" json:"webhook_url,omitempty"`           // http(s) URL that new hits are posted to
+	WebhookSecret string                 `protobuf:"bytes,10,opt,name=webhook_secret,json=webhookSecret,proto3" json:"webhook_secret,omitempty"` // Key of the payload's HMAC-SHA256 signature; required with webhook_url
+	// Whether the last successful run fetched fewer hits than GitHub reported,
+	// or GitHub reported incomplete results. Hits missing from such a run, or
+	// from the run after it, are not reported as removed until a later run.
+	LastRunTruncated  bool `protobuf:"varint,11,opt,name=last_run_truncated,json=lastRunTruncated,proto3" json:"last_run_truncated,omitempty"`
+	LastRunIncomplete bool `protobuf:"varint,12,opt,name=last_run_incomplete,json=lastRunIncomplete,proto3" json:"last_run_incomplete,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Watch) Reset() {
+	*x = Watch{}
+	mi := &file_proto_github_search_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Watch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Watch) ProtoMessage() {}
+
+func (x *Watch) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Watch.ProtoReflect.Descriptor instead.
+func (*Watch) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *Watch) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Watch) GetRequest() *SearchRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *Watch) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+func (x *Watch) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *Watch) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *Watch) GetLastRunTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastRunTime
+	}
+	return nil
+}
+
+func (x *Watch) GetNextRunTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextRunTime
+	}
+	return nil
+}
+
+func (x *Watch) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *Watch) GetWebhookUrl() string {
+	if x != nil {
+		return x.WebhookUrl
+	}
+	return ""
+}
+
+func (x *Watch) GetWebhookSecret() string {
+	if x != nil {
+		return x.WebhookSecret
+	}
+	return ""
+}
+
+func (x *Watch) GetLastRunTruncated() bool {
+	if x != nil {
+		return x.LastRunTruncated
+	}
+	return false
+}
+
+func (x *Watch) GetLastRunIncomplete() bool {
+	if x != nil {
+		return x.LastRunIncomplete
+	}
+	return false
+}
+
+// WatchHit identifies a search hit across runs of a watch or snapshots.
+type WatchHit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Repo          string                 `protobuf:"bytes,1,opt,name=repo,proto3" json:"repo,omitempty"` // Full name, e.g. octocat/hello-world
+	Path          string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Sha           string                 `protobuf:"bytes,3,opt,name=sha,proto3" json:"sha,omitempty"` // Blob SHA of the file content
+	FileUrl       string                 `protobuf:"bytes,4,opt,name=file_url,json=fileUrl,proto3" json:"file_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchHit) Reset() {
+	*x = WatchHit{}
+	mi := &file_proto_github_search_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchHit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchHit) ProtoMessage() {}
+
+func (x *WatchHit) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchHit.ProtoReflect.Descriptor instead.
+func (*WatchHit) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *WatchHit) GetRepo() string {
+	if x != nil {
+		return x.Repo
+	}
+	return ""
+}
+
+func (x *WatchHit) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *WatchHit) GetSha() string {
+	if x != nil {
+		return x.Sha
+	}
+	return ""
+}
+
+func (x *WatchHit) GetFileUrl() string {
+	if x != nil {
+		return x.FileUrl
+	}
+	return ""
+}
+
+type WatchEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	WatchId       string                 `protobuf:"bytes,2,opt,name=watch_id,json=watchId,proto3" json:"watch_id,omitempty"`
+	Type          WatchEvent_Type        `protobuf:"varint,3,opt,name=type,proto3,enum=githubsearchservice.WatchEvent_Type" json:"type,omitempty"`
+	Hit           *WatchHit              `protobuf:"bytes,4,opt,name=hit,proto3" json:"hit,omitempty"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=time,proto3" json:"time,omitempty"`
+	Cursor        string                 `protobuf:"bytes,6,opt,name=cursor,proto3" json:"cursor,omitempty"` // Position of the event, to resume SubscribeWatch after it
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchEvent) Reset() {
+	*x = WatchEvent{}
+	mi := &file_proto_github_search_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchEvent) ProtoMessage() {}
+
+func (x *WatchEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchEvent.ProtoReflect.Descriptor instead.
+func (*WatchEvent) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *WatchEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *WatchEvent) GetWatchId() string {
+	if x != nil {
+		return x.WatchId
+	}
+	return ""
+}
+
+func (x *WatchEvent) GetType() WatchEvent_Type {
+	if x != nil {
+		return x.Type
+	}
+	return WatchEvent_TYPE_UNSPECIFIED
+}
+
+func (x *WatchEvent) GetHit() *WatchHit {
+	if x != nil {
+		return x.Hit
+	}
+	return nil
+}
+
+func (x *WatchEvent) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *WatchEvent) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type CreateWatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Watch         *Watch                 `protobuf:"bytes,1,opt,name=watch,proto3" json:"watch,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWatchRequest) Reset() {
+	*x = CreateWatchRequest{}
+	mi := &file_proto_github_search_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWatchRequest) ProtoMessage() {}
+
+func (x *CreateWatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWatchRequest.ProtoReflect.Descriptor instead.
+func (*CreateWatchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CreateWatchRequest) GetWatch() *Watch {
+	if x != nil {
+		return x.Watch
+	}
+	return nil
+}
+
+type GetWatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWatchRequest) Reset() {
+	*x = GetWatchRequest{}
+	mi := &file_proto_github_search_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWatchRequest) ProtoMessage() {}
+
+func (x *GetWatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWatchRequest.ProtoReflect.Descriptor instead.
+func (*GetWatchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetWatchRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListWatchesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"` // Defaults to 20
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWatchesRequest) Reset() {
+	*x = ListWatchesRequest{}
+	mi := &file_proto_github_search_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWatchesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWatchesRequest) ProtoMessage() {}
+
+func (x *ListWatchesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWatchesRequest.ProtoReflect.Descriptor instead.
+func (*ListWatchesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListWatchesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListWatchesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListWatchesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Watches       []*Watch               `protobuf:"bytes,1,rep,name=watches,proto3" json:"watches,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Empty on the last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWatchesResponse) Reset() {
+	*x = ListWatchesResponse{}
+	mi := &file_proto_github_search_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWatchesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWatchesResponse) ProtoMessage() {}
+
+func (x *ListWatchesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWatchesResponse.ProtoReflect.Descriptor instead.
+func (*ListWatchesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListWatchesResponse) GetWatches() []*Watch {
+	if x != nil {
+		return x.Watches
+	}
+	return nil
+}
+
+func (x *ListWatchesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type DeleteWatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWatchRequest) Reset() {
+	*x = DeleteWatchRequest{}
+	mi := &file_proto_github_search_service_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWatchRequest) ProtoMessage() {}
+
+func (x *DeleteWatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWatchRequest.ProtoReflect.Descriptor instead.
+func (*DeleteWatchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *DeleteWatchRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteWatchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWatchResponse) Reset() {
+	*x = DeleteWatchResponse{}
+	mi := &file_proto_github_search_service_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWatchResponse) ProtoMessage() {}
+
+func (x *DeleteWatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWatchResponse.ProtoReflect.Descriptor instead.
+func (*DeleteWatchResponse) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{28}
+}
+
+// ListWatchEventsRequest pages through the events of a watch, newest first.
+type ListWatchEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WatchId       string                 `protobuf:"bytes,1,opt,name=watch_id,json=watchId,proto3" json:"watch_id,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"` // Defaults to 20
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWatchEventsRequest) Reset() {
+	*x = ListWatchEventsRequest{}
+	mi := &file_proto_github_search_service_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWatchEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWatchEventsRequest) ProtoMessage() {}
+
+func (x *ListWatchEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWatchEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListWatchEventsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListWatchEventsRequest) GetWatchId() string {
+	if x != nil {
+		return x.WatchId
+	}
+	return ""
+}
+
+func (x *ListWatchEventsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListWatchEventsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListWatchEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*WatchEvent          `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Empty on the last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWatchEventsResponse) Reset() {
+	*x = ListWatchEventsResponse{}
+	mi := &file_proto_github_search_service_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWatchEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWatchEventsResponse) ProtoMessage() {}
+
+func (x *ListWatchEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWatchEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListWatchEventsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListWatchEventsResponse) GetEvents() []*WatchEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *ListWatchEventsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// SubscribeWatchRequest streams the events of a watch, oldest first, as the
+// scheduler records them.
+type SubscribeWatchRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	WatchId string                 `protobuf:"bytes,1,opt,name=watch_id,json=watchId,proto3" json:"watch_id,omitempty"`
+	// Cursor of the last event the client received; the events recorded since
+	// are sent first. If empty, only events recorded after subscribing are sent.
+	Cursor        string `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeWatchRequest) Reset() {
+	*x = SubscribeWatchRequest{}
+	mi := &file_proto_github_search_service_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeWatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeWatchRequest) ProtoMessage() {}
+
+func (x *SubscribeWatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeWatchRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeWatchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *SubscribeWatchRequest) GetWatchId() string {
+	if x != nil {
+		return x.WatchId
+	}
+	return ""
+}
+
+func (x *SubscribeWatchRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+// WebhookPayload is the JSON body posted to a watch's webhook_url when a run
+// finds new hits. It is signed with HMAC-SHA256 using the watch's
+// webhook_secret; the hex digest is sent as "X-Hub-Signature-256: sha256=<digest>".
+type WebhookPayload struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeliveryId    string                 `protobuf:"bytes,1,opt,name=delivery_id,json=deliveryId,proto3" json:"delivery_id,omitempty"`
+	WatchId       string                 `protobuf:"bytes,2,opt,name=watch_id,json=watchId,proto3" json:"watch_id,omitempty"`
+	SearchTerm    string                 `protobuf:"bytes,3,opt,name=search_term,json=searchTerm,proto3" json:"search_term,omitempty"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=time,proto3" json:"time,omitempty"`       // When the run found the hits
+	Results       []*Result              `protobuf:"bytes,5,rep,name=results,proto3" json:"results,omitempty"` // Hits that are new since the previous run
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookPayload) Reset() {
+	*x = WebhookPayload{}
+	mi := &file_proto_github_search_service_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookPayload) ProtoMessage() {}
+
+func (x *WebhookPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookPayload.ProtoReflect.Descriptor instead.
+func (*WebhookPayload) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *WebhookPayload) GetDeliveryId() string {
+	if x != nil {
+		return x.DeliveryId
+	}
+	return ""
+}
+
+func (x *WebhookPayload) GetWatchId() string {
+	if x != nil {
+		return x.WatchId
+	}
+	return ""
+}
+
+func (x *WebhookPayload) GetSearchTerm() string {
+	if x != nil {
+		return x.SearchTerm
+	}
+	return ""
+}
+
+func (x *WebhookPayload) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *WebhookPayload) GetResults() []*Result {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// WebhookDelivery records the attempts to post one WebhookPayload.
+type WebhookDelivery struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	WatchId         string                 `protobuf:"bytes,2,opt,name=watch_id,json=watchId,proto3" json:"watch_id,omitempty"`
+	Url             string                 `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	State           WebhookDelivery_State  `protobuf:"varint,4,opt,name=state,proto3,enum=githubsearchservice.WebhookDelivery_State" json:"state,omitempty"`
+	Payload         string                 `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"` // The JSON body, as sent
+	Attempts        int32                  `protobuf:"varint,6,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	LastStatusCode  int32                  `protobuf:"varint,7,opt,name=last_status_code,json=lastStatusCode,proto3" json:"last_status_code,omitempty"` // HTTP status of the last attempt, 0 if no response was received
+	LastError       string                 `protobuf:"bytes,8,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	CreateTime      *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	LastAttemptTime *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=last_attempt_time,json=lastAttemptTime,proto3" json:"last_attempt_time,omitempty"`
+	NextAttemptTime *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=next_attempt_time,json=nextAttemptTime,proto3" json:"next_attempt_time,omitempty"` // Only set while pending
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *WebhookDelivery) Reset() {
+	*x = WebhookDelivery{}
+	mi := &file_proto_github_search_service_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookDelivery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookDelivery) ProtoMessage() {}
+
+func (x *WebhookDelivery) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookDelivery.ProtoReflect.Descriptor instead.
+func (*WebhookDelivery) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *WebhookDelivery) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetWatchId() string {
+	if x != nil {
+		return x.WatchId
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetState() WebhookDelivery_State {
+	if x != nil {
+		return x.State
+	}
+	return WebhookDelivery_STATE_UNSPECIFIED
+}
+
+func (x *WebhookDelivery) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetLastStatusCode() int32 {
+	if x != nil {
+		return x.LastStatusCode
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *WebhookDelivery) GetLastAttemptTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastAttemptTime
+	}
+	return nil
+}
+
+func (x *WebhookDelivery) GetNextAttemptTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextAttemptTime
+	}
+	return nil
+}
+
+// ListDeliveriesRequest pages through the webhook deliveries of a watch,
+// newest first.
+type ListDeliveriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WatchId       string                 `protobuf:"bytes,1,opt,name=watch_id,json=watchId,proto3" json:"watch_id,omitempty"`
+	State         WebhookDelivery_State  `protobuf:"varint,2,opt,name=state,proto3,enum=githubsearchservice.WebhookDelivery_State" json:"state,omitempty"` // Only deliveries in this state, e.g. STATE_DEAD_LETTER; any if unspecified
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`                          // Defaults to 20
+	PageToken     string                 `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeliveriesRequest) Reset() {
+	*x = ListDeliveriesRequest{}
+	mi := &file_proto_github_search_service_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeliveriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeliveriesRequest) ProtoMessage() {}
+
+func (x *ListDeliveriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeliveriesRequest.ProtoReflect.Descriptor instead.
+func (*ListDeliveriesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ListDeliveriesRequest) GetWatchId() string {
+	if x != nil {
+		return x.WatchId
+	}
+	return ""
+}
+
+func (x *ListDeliveriesRequest) GetState() WebhookDelivery_State {
+	if x != nil {
+		return x.State
+	}
+	return WebhookDelivery_STATE_UNSPECIFIED
+}
+
+func (x *ListDeliveriesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListDeliveriesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListDeliveriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deliveries    []*WebhookDelivery     `protobuf:"bytes,1,rep,name=deliveries,proto3" json:"deliveries,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Empty on the last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeliveriesResponse) Reset() {
+	*x = ListDeliveriesResponse{}
+	mi := &file_proto_github_search_service_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeliveriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeliveriesResponse) ProtoMessage() {}
+
+func (x *ListDeliveriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeliveriesResponse.ProtoReflect.Descriptor instead.
+func (*ListDeliveriesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ListDeliveriesResponse) GetDeliveries() []*WebhookDelivery {
+	if x != nil {
+		return x.Deliveries
+	}
+	return nil
+}
+
+func (x *ListDeliveriesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Snapshot records the hits of a search at one point in time, to be compared
+// later with DiffSearch.
+type Snapshot struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Request    *SearchRequest         `protobuf:"bytes,2,opt,name=request,proto3" json:"request,omitempty"` // The page and per_page fields are not stored
+	Owner      string                 `protobuf:"bytes,3,opt,name=owner,proto3" json:"owner,omitempty"`
+	CreateTime *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	HitCount   int32                  `protobuf:"varint,5,opt,name=hit_count,json=hitCount,proto3" json:"hit_count,omitempty"`
+	// True if the search had more hits than were recorded, because of the
+	// server's snapshot limit or GitHub's limit of 1000 results
+	Truncated     bool        `protobuf:"varint,6,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	Hits          []*WatchHit `protobuf:"bytes,7,rep,name=hits,proto3" json:"hits,omitempty"` // Omitted by ListSnapshots
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Snapshot) Reset() {
+	*x = Snapshot{}
+	mi := &file_proto_github_search_service_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Snapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Snapshot) ProtoMessage() {}
+
+func (x *Snapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Snapshot.ProtoReflect.Descriptor instead.
+func (*Snapshot) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *Snapshot) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Snapshot) GetRequest() *SearchRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *Snapshot) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *Snapshot) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *Snapshot) GetHitCount() int32 {
+	if x != nil {
+		return x.HitCount
+	}
+	return 0
+}
+
+func (x *Snapshot) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+func (x *Snapshot) GetHits() []*WatchHit {
+	if x != nil {
+		return x.Hits
+	}
+	return nil
+}
+
+// CreateSnapshotRequest runs a search with the caller's GitHub token and
+// stores its hits.
+type CreateSnapshotRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Request       *SearchRequest         `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSnapshotRequest) Reset() {
+	*x = CreateSnapshotRequest{}
+	mi := &file_proto_github_search_service_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSnapshotRequest) ProtoMessage() {}
+
+func (x *CreateSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*CreateSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *CreateSnapshotRequest) GetRequest() *SearchRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+type GetSnapshotRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSnapshotRequest) Reset() {
+	*x = GetSnapshotRequest{}
+	mi := &file_proto_github_search_service_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSnapshotRequest) ProtoMessage() {}
+
+func (x *GetSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*GetSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *GetSnapshotRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// ListSnapshotsRequest pages through the caller's snapshots, newest first,
+// without their hits.
+type ListSnapshotsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"` // Defaults to 20
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSnapshotsRequest) Reset() {
+	*x = ListSnapshotsRequest{}
+	mi := &file_proto_github_search_service_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSnapshotsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSnapshotsRequest) ProtoMessage() {}
+
+func (x *ListSnapshotsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSnapshotsRequest.ProtoReflect.Descriptor instead.
+func (*ListSnapshotsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ListSnapshotsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListSnapshotsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListSnapshotsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Snapshots     []*Snapshot            `protobuf:"bytes,1,rep,name=snapshots,proto3" json:"snapshots,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Empty on the last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSnapshotsResponse) Reset() {
+	*x = ListSnapshotsResponse{}
+	mi := &file_proto_github_search_service_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSnapshotsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSnapshotsResponse) ProtoMessage() {}
+
+func (x *ListSnapshotsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSnapshotsResponse.ProtoReflect.Descriptor instead.
+func (*ListSnapshotsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ListSnapshotsResponse) GetSnapshots() []*Snapshot {
+	if x != nil {
+		return x.Snapshots
+	}
+	return nil
+}
+
+func (x *ListSnapshotsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type DeleteSnapshotRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSnapshotRequest) Reset() {
+	*x = DeleteSnapshotRequest{}
+	mi := &file_proto_github_search_service_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSnapshotRequest) ProtoMessage() {}
+
+func (x *DeleteSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *DeleteSnapshotRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteSnapshotResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSnapshotResponse) Reset() {
+	*x = DeleteSnapshotResponse{}
+	mi := &file_proto_github_search_service_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSnapshotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSnapshotResponse) ProtoMessage() {}
+
+func (x *DeleteSnapshotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSnapshotResponse.ProtoReflect.Descriptor instead.
+func (*DeleteSnapshotResponse) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{42}
+}
+
+// DiffSearchRequest compares two executions of a search: two stored
+// snapshots, or a stored snapshot and a run of the search now.
+type DiffSearchRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The search; both snapshots must have been taken of the same search,
+	// including its filters, ignoring only page and per_page
+	Request        *SearchRequest `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	BaseSnapshotId string         `protobuf:"bytes,2,opt,name=base_snapshot_id,json=baseSnapshotId,proto3" json:"base_snapshot_id,omitempty"`
+	// If empty, the search is run now with the caller's GitHub token
+	TargetSnapshotId string `protobuf:"bytes,3,opt,name=target_snapshot_id,json=targetSnapshotId,proto3" json:"target_snapshot_id,omitempty"`
+	// Store the hits of a run now as a new snapshot, returned in target_snapshot_id
+	SaveTarget    bool `protobuf:"varint,4,opt,name=save_target,json=saveTarget,proto3" json:"save_target,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffSearchRequest) Reset() {
+	*x = DiffSearchRequest{}
+	mi := &file_proto_github_search_service_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffSearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffSearchRequest) ProtoMessage() {}
+
+func (x *DiffSearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffSearchRequest.ProtoReflect.Descriptor instead.
+func (*DiffSearchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *DiffSearchRequest) GetRequest() *SearchRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *DiffSearchRequest) GetBaseSnapshotId() string {
+	if x != nil {
+		return x.BaseSnapshotId
+	}
+	return ""
+}
+
+func (x *DiffSearchRequest) GetTargetSnapshotId() string {
+	if x != nil {
+		return x.TargetSnapshotId
+	}
+	return ""
+}
+
+func (x *DiffSearchRequest) GetSaveTarget() bool {
+	if x != nil {
+		return x.SaveTarget
+	}
+	return false
+}
+
+// ChangedHit is a file that both executions found with different contents.
+type ChangedHit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	BaseSha       string                 `protobuf:"bytes,2,opt,name=base_sha,json=baseSha,proto3" json:"base_sha,omitempty"`
+	TargetSha     string                 `protobuf:"bytes,3,opt,name=target_sha,json=targetSha,proto3" json:"target_sha,omitempty"`
+	FileUrl       string                 `protobuf:"bytes,4,opt,name=file_url,json=fileUrl,proto3" json:"file_url,omitempty"` // URL of the target version
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangedHit) Reset() {
+	*x = ChangedHit{}
+	mi := &file_proto_github_search_service_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangedHit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangedHit) ProtoMessage() {}
+
+func (x *ChangedHit) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangedHit.ProtoReflect.Descriptor instead.
+func (*ChangedHit) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ChangedHit) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ChangedHit) GetBaseSha() string {
+	if x != nil {
+		return x.BaseSha
+	}
+	return ""
+}
+
+func (x *ChangedHit) GetTargetSha() string {
+	if x != nil {
+		return x.TargetSha
+	}
+	return ""
+}
+
+func (x *ChangedHit) GetFileUrl() string {
+	if x != nil {
+		return x.FileUrl
+	}
+	return ""
+}
+
+// RepositoryDiff holds the differences found in one repository.
+type RepositoryDiff struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Repo          string                 `protobuf:"bytes,1,opt,name=repo,proto3" json:"repo,omitempty"`
+	Added         []*WatchHit            `protobuf:"bytes,2,rep,name=added,proto3" json:"added,omitempty"`     // Found only by the target
+	Removed       []*WatchHit            `protobuf:"bytes,3,rep,name=removed,proto3" json:"removed,omitempty"` // Found only by the base
+	Changed       []*ChangedHit          `protobuf:"bytes,4,rep,name=changed,proto3" json:"changed,omitempty"` // Same path, different blob SHA
+	AddedCount    int32                  `protobuf:"varint,5,opt,name=added_count,json=addedCount,proto3" json:"added_count,omitempty"`
+	RemovedCount  int32                  `protobuf:"varint,6,opt,name=removed_count,json=removedCount,proto3" json:"removed_count,omitempty"`
+	ChangedCount  int32                  `protobuf:"varint,7,opt,name=changed_count,json=changedCount,proto3" json:"changed_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RepositoryDiff) Reset() {
+	*x = RepositoryDiff{}
+	mi := &file_proto_github_search_service_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RepositoryDiff) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RepositoryDiff) ProtoMessage() {}
+
+func (x *RepositoryDiff) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RepositoryDiff.ProtoReflect.Descriptor instead.
+func (*RepositoryDiff) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *RepositoryDiff) GetRepo() string {
+	if x != nil {
+		return x.Repo
+	}
+	return ""
+}
+
+func (x *RepositoryDiff) GetAdded() []*WatchHit {
+	if x != nil {
+		return x.Added
+	}
+	return nil
+}
+
+func (x *RepositoryDiff) GetRemoved() []*WatchHit {
+	if x != nil {
+		return x.Removed
+	}
+	return nil
+}
+
+func (x *RepositoryDiff) GetChanged() []*ChangedHit {
+	if x != nil {
+		return x.Changed
+	}
+	return nil
+}
+
+func (x *RepositoryDiff) GetAddedCount() int32 {
+	if x != nil {
+		return x.AddedCount
+	}
+	return 0
+}
+
+func (x *RepositoryDiff) GetRemovedCount() int32 {
+	if x != nil {
+		return x.RemovedCount
+	}
+	return 0
+}
+
+func (x *RepositoryDiff) GetChangedCount() int32 {
+	if x != nil {
+		return x.ChangedCount
+	}
+	return 0
+}
+
+type DiffSearchResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Repositories     []*RepositoryDiff      `protobuf:"bytes,1,rep,name=repositories,proto3" json:"repositories,omitempty"` // Only repositories with differences, sorted by name
+	AddedCount       int32                  `protobuf:"varint,2,opt,name=added_count,json=addedCount,proto3" json:"added_count,omitempty"`
+	RemovedCount     int32                  `protobuf:"varint,3,opt,name=removed_count,json=removedCount,proto3" json:"removed_count,omitempty"`
+	ChangedCount     int32                  `protobuf:"varint,4,opt,name=changed_count,json=changedCount,proto3" json:"changed_count,omitempty"`
+	BaseTime         *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=base_time,json=baseTime,proto3" json:"base_time,omitempty"`
+	TargetTime       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=target_time,json=targetTime,proto3" json:"target_time,omitempty"`
+	TargetSnapshotId string                 `protobuf:"bytes,7,opt,name=target_snapshot_id,json=targetSnapshotId,proto3" json:"target_snapshot_id,omitempty"` // Set if the target was a stored snapshot or save_target was set
+	Truncated        bool                   `protobuf:"varint,8,opt,name=truncated,proto3" json:"truncated,omitempty"`                                        // Either side was truncated, so some differences may be missing
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *DiffSearchResponse) Reset() {
+	*x = DiffSearchResponse{}
+	mi := &file_proto_github_search_service_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffSearchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffSearchResponse) ProtoMessage() {}
+
+func (x *DiffSearchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffSearchResponse.ProtoReflect.Descriptor instead.
+func (*DiffSearchResponse) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *DiffSearchResponse) GetRepositories() []*RepositoryDiff {
+	if x != nil {
+		return x.Repositories
+	}
+	return nil
+}
+
+func (x *DiffSearchResponse) GetAddedCount() int32 {
+	if x != nil {
+		return x.AddedCount
+	}
+	return 0
+}
+
+func (x *DiffSearchResponse) GetRemovedCount() int32 {
+	if x != nil {
+		return x.RemovedCount
+	}
+	return 0
+}
+
+func (x *DiffSearchResponse) GetChangedCount() int32 {
+	if x != nil {
+		return x.ChangedCount
+	}
+	return 0
+}
+
+func (x *DiffSearchResponse) GetBaseTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BaseTime
+	}
+	return nil
+}
+
+func (x *DiffSearchResponse) GetTargetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TargetTime
+	}
+	return nil
+}
+
+func (x *DiffSearchResponse) GetTargetSnapshotId() string {
+	if x != nil {
+		return x.TargetSnapshotId
+	}
+	return ""
+}
+
+func (x *DiffSearchResponse) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+// BatchSearchRequest runs many searches in one call. Each search is charged
+// to the caller's quotas like a separate Search call.
+type BatchSearchRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Requests []*SearchRequest       `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"` // At most the server's batch size limit
+	// Number of searches run at the same time; defaults to, and is capped at,
+	// the server's batch concurrency limit
+	MaxConcurrency int32 `protobuf:"varint,2,opt,name=max_concurrency,json=maxConcurrency,proto3" json:"max_concurrency,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *BatchSearchRequest) Reset() {
+	*x = BatchSearchRequest{}
+	mi := &file_proto_github_search_service_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchSearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchSearchRequest) ProtoMessage() {}
+
+func (x *BatchSearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchSearchRequest.ProtoReflect.Descriptor instead.
+func (*BatchSearchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *BatchSearchRequest) GetRequests() []*SearchRequest {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+func (x *BatchSearchRequest) GetMaxConcurrency() int32 {
+	if x != nil {
+		return x.MaxConcurrency
+	}
+	return 0
+}
+
+// BatchSearchResult is the outcome of one search of a batch: its response,
+// or the error it failed with.
+type BatchSearchResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`      // Position of the search in BatchSearchRequest.requests
+	Response      *SearchResponse        `protobuf:"bytes,2,opt,name=response,proto3" json:"response,omitempty"` // Unset if the search failed
+	Error         *BatchSearchError      `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`       // Unset if the search succeeded
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchSearchResult) Reset() {
+	*x = BatchSearchResult{}
+	mi := &file_proto_github_search_service_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchSearchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchSearchResult) ProtoMessage() {}
+
+func (x *BatchSearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchSearchResult.ProtoReflect.Descriptor instead.
+func (*BatchSearchResult) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *BatchSearchResult) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *BatchSearchResult) GetResponse() *SearchResponse {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *BatchSearchResult) GetError() *BatchSearchError {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+// BatchSearchError is the status a failed search of a batch would have
+// returned as a separate Search call.
+type BatchSearchError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"` // gRPC status code, e.g. 8 for RESOURCE_EXHAUSTED
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchSearchError) Reset() {
+	*x = BatchSearchError{}
+	mi := &file_proto_github_search_service_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchSearchError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchSearchError) ProtoMessage() {}
+
+func (x *BatchSearchError) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchSearchError.ProtoReflect.Descriptor instead.
+func (*BatchSearchError) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *BatchSearchError) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *BatchSearchError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type BatchSearchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Responses     []*BatchSearchResult   `protobuf:"bytes,1,rep,name=responses,proto3" json:"responses,omitempty"` // In the order of the requests
+	FailedCount   int32                  `protobuf:"varint,2,opt,name=failed_count,json=failedCount,proto3" json:"failed_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchSearchResponse) Reset() {
+	*x = BatchSearchResponse{}
+	mi := &file_proto_github_search_service_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchSearchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchSearchResponse) ProtoMessage() {}
+
+func (x *BatchSearchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchSearchResponse.ProtoReflect.Descriptor instead.
+func (*BatchSearchResponse) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *BatchSearchResponse) GetResponses() []*BatchSearchResult {
+	if x != nil {
+		return x.Responses
+	}
+	return nil
+}
+
+func (x *BatchSearchResponse) GetFailedCount() int32 {
+	if x != nil {
+		return x.FailedCount
+	}
+	return 0
+}
+
+// SearchFacetsRequest summarizes where a search matches instead of listing
+// the hits. The hits are fetched page by page, each page counting against
+// the caller's quotas like a Search call.
+type SearchFacetsRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Request *SearchRequest         `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"` // The page and per_page fields are ignored
+	// Number of hits to analyze; defaults to, and is capped at, the server's facet limit
+	MaxResults int32 `protobuf:"varint,2,opt,name=max_results,json=maxResults,proto3" json:"max_results,omitempty"`
+	// Number of values returned per facet, most frequent first; defaults to 10
+	FacetSize     int32 `protobuf:"varint,3,opt,name=facet_size,json=facetSize,proto3" json:"facet_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchFacetsRequest) Reset() {
+	*x = SearchFacetsRequest{}
+	mi := &file_proto_github_search_service_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchFacetsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchFacetsRequest) ProtoMessage() {}
+
+func (x *SearchFacetsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchFacetsRequest.ProtoReflect.Descriptor instead.
+func (*SearchFacetsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *SearchFacetsRequest) GetRequest() *SearchRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *SearchFacetsRequest) GetMaxResults() int32 {
+	if x != nil {
+		return x.MaxResults
+	}
+	return 0
+}
+
+func (x *SearchFacetsRequest) GetFacetSize() int32 {
+	if x != nil {
+		return x.FacetSize
+	}
+	return 0
+}
+
+type FacetCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FacetCount) Reset() {
+	*x = FacetCount{}
+	mi := &file_proto_github_search_service_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FacetCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FacetCount) ProtoMessage() {}
+
+func (x *FacetCount) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FacetCount.ProtoReflect.Descriptor instead.
+func (*FacetCount) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *FacetCount) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *FacetCount) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type SearchFacetsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Repositories  []*FacetCount          `protobuf:"bytes,1,rep,name=repositories,proto3" json:"repositories,omitempty"`                         // Full names, e.g. octocat/hello-world
+	Owners        []*FacetCount          `protobuf:"bytes,2,rep,name=owners,proto3" json:"owners,omitempty"`                                     // Users and organizations
+	Extensions    []*FacetCount          `protobuf:"bytes,3,rep,name=extensions,proto3" json:"extensions,omitempty"`                             // e.g. ".go"; "" for files without one
+	Languages     []*FacetCount          `protobuf:"bytes,4,rep,name=languages,proto3" json:"languages,omitempty"`                               // Derived from the extension; "Other" if unknown
+	Directories   []*FacetCount          `protobuf:"bytes,5,rep,name=directories,proto3" json:"directories,omitempty"`                           // Top-level directory; "/" for files at the repository root
+	TotalCount    int32                  `protobuf:"varint,6,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`          // Hits GitHub reported for the search
+	AnalyzedCount int32                  `protobuf:"varint,7,opt,name=analyzed_count,json=analyzedCount,proto3" json:"analyzed_count,omitempty"` // Hits fetched and counted in the facets
+	// Share of the hits that were counted, analyzed_count / total_count. Below 1
+	// when the search has more hits than the facet limit or than the 1000 GitHub returns.
+	Coverage      float64 `protobuf:"fixed64,8,opt,name=coverage,proto3" json:"coverage,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchFacetsResponse) Reset() {
+	*x = SearchFacetsResponse{}
+	mi := &file_proto_github_search_service_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchFacetsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchFacetsResponse) ProtoMessage() {}
+
+func (x *SearchFacetsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchFacetsResponse.ProtoReflect.Descriptor instead.
+func (*SearchFacetsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *SearchFacetsResponse) GetRepositories() []*FacetCount {
+	if x != nil {
+		return x.Repositories
+	}
+	return nil
+}
+
+func (x *SearchFacetsResponse) GetOwners() []*FacetCount {
+	if x != nil {
+		return x.Owners
+	}
+	return nil
+}
+
+func (x *SearchFacetsResponse) GetExtensions() []*FacetCount {
+	if x != nil {
+		return x.Extensions
+	}
+	return nil
+}
+
+func (x *SearchFacetsResponse) GetLanguages() []*FacetCount {
+	if x != nil {
+		return x.Languages
+	}
+	return nil
+}
+
+func (x *SearchFacetsResponse) GetDirectories() []*FacetCount {
+	if x != nil {
+		return x.Directories
+	}
+	return nil
+}
+
+func (x *SearchFacetsResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *SearchFacetsResponse) GetAnalyzedCount() int32 {
+	if x != nil {
+		return x.AnalyzedCount
+	}
+	return 0
+}
+
+func (x *SearchFacetsResponse) GetCoverage() float64 {
+	if x != nil {
+		return x.Coverage
+	}
+	return 0
+}
+
+type ExhaustiveSearchRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Request *SearchRequest         `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"` // The page and per_page fields are ignored
+	// Number of distinct hits to return; defaults to, and is capped at, the server's limit
+	MaxResults int32 `protobuf:"varint,2,opt,name=max_results,json=maxResults,proto3" json:"max_results,omitempty"`
+	// Number of GitHub searches to run; defaults to, and is capped at, the server's limit
+	MaxQueries    int32 `protobuf:"varint,3,opt,name=max_queries,json=maxQueries,proto3" json:"max_queries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExhaustiveSearchRequest) Reset() {
+	*x = ExhaustiveSearchRequest{}
+	mi := &file_proto_github_search_service_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExhaustiveSearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExhaustiveSearchRequest) ProtoMessage() {}
+
+func (x *ExhaustiveSearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExhaustiveSearchRequest.ProtoReflect.Descriptor instead.
+func (*ExhaustiveSearchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ExhaustiveSearchRequest) GetRequest() *SearchRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *ExhaustiveSearchRequest) GetMaxResults() int32 {
+	if x != nil {
+		return x.MaxResults
+	}
+	return 0
+}
+
+func (x *ExhaustiveSearchRequest) GetMaxQueries() int32 {
+	if x != nil {
+		return x.MaxQueries
+	}
+	return 0
+}
+
+// A part of an exhaustive search whose hits were not all fetched.
+type ExhaustiveShard struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`                              // The GitHub query of the shard, e.g. "foo size:0..1023 extension:go"
+	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"` // Hits GitHub reported for the shard; 0 if it was never run
+	FetchedCount  int32                  `protobuf:"varint,3,opt,name=fetched_count,json=fetchedCount,proto3" json:"fetched_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExhaustiveShard) Reset() {
+	*x = ExhaustiveShard{}
+	mi := &file_proto_github_search_service_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExhaustiveShard) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExhaustiveShard) ProtoMessage() {}
+
+func (x *ExhaustiveShard) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExhaustiveShard.ProtoReflect.Descriptor instead.
+func (*ExhaustiveShard) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ExhaustiveShard) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *ExhaustiveShard) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *ExhaustiveShard) GetFetchedCount() int32 {
+	if x != nil {
+		return x.FetchedCount
+	}
+	return 0
+}
+
+type ExhaustiveSearchResponse struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Hits       []*WatchHit            `protobuf:"bytes,1,rep,name=hits,proto3" json:"hits,omitempty"`                                // Distinct by repository and path
+	TotalCount int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"` // Hits GitHub reported for the unsplit search, an estimate
+	HitCount   int32                  `protobuf:"varint,3,opt,name=hit_count,json=hitCount,proto3" json:"hit_count,omitempty"`
+	// Share of the hits that were fetched, hit_count / total_count capped at 1;
+	// 1 if the search is complete.
+	Coverage float64 `protobuf:"fixed64,4,opt,name=coverage,proto3" json:"coverage,omitempty"`
+	// Set if every shard was fetched in full. Shards are missed when they
+	// cannot be split below GitHub's 1000-result limit, when GitHub reports
+	// incomplete results, or when max_results or max_queries is reached.
+	Complete         bool               `protobuf:"varint,5,opt,name=complete,proto3" json:"complete,omitempty"`
+	QueryCount       int32              `protobuf:"varint,6,opt,name=query_count,json=queryCount,proto3" json:"query_count,omitempty"` // GitHub searches run
+	ShardCount       int32              `protobuf:"varint,7,opt,name=shard_count,json=shardCount,proto3" json:"shard_count,omitempty"` // Shards fetched, in full or not
+	IncompleteShards []*ExhaustiveShard `protobuf:"bytes,8,rep,name=incomplete_shards,json=incompleteShards,proto3" json:"incomplete_shards,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ExhaustiveSearchResponse) Reset() {
+	*x = ExhaustiveSearchResponse{}
+	mi := &file_proto_github_search_service_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExhaustiveSearchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExhaustiveSearchResponse) ProtoMessage() {}
+
+func (x *ExhaustiveSearchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_github_search_service_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExhaustiveSearchResponse.ProtoReflect.Descriptor instead.
+func (*ExhaustiveSearchResponse) Descriptor() ([]byte, []int) {
+	return file_proto_github_search_service_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *ExhaustiveSearchResponse) GetHits() []*WatchHit {
+	if x != nil {
+		return x.Hits
+	}
+	return nil
+}
+
+func (x *ExhaustiveSearchResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *ExhaustiveSearchResponse) GetHitCount() int32 {
+	if x != nil {
+		return x.HitCount
+	}
+	return 0
+}
+
+func (x *ExhaustiveSearchResponse) GetCoverage() float64 {
+	if x != nil {
+		return x.Coverage
+	}
+	return 0
+}
+
+func (x *ExhaustiveSearchResponse) GetComplete() bool {
+	if x != nil {
+		return x.Complete
+	}
+	return false
+}
+
+func (x *ExhaustiveSearchResponse) GetQueryCount() int32 {
+	if x != nil {
+		return x.QueryCount
+	}
+	return 0
+}
+
+func (x *ExhaustiveSearchResponse) GetShardCount() int32 {
+	if x != nil {
+		return x.ShardCount
+	}
+	return 0
+}
+
+func (x *ExhaustiveSearchResponse) GetIncompleteShards() []*ExhaustiveShard {
+	if x != nil {
+		return x.IncompleteShards
+	}
+	return nil
+}
+
+var File_proto_github_search_service_proto protoreflect.FileDescriptor
+
+const file_proto_github_search_service_proto_rawDesc = "" +
+	"\n" +
+	"!proto/github_search_service.proto\x12\x13githubsearchservice\x1a\x1bbuf/validate/validate.proto\x1a\x1fgoogle/api/field_behavior.proto\x1a\x1egoogle/protobuf/duration.proto\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xec\x06\n" +
+	"\rSearchRequest\x12+\n" +
+	"\vsearch_term\x18\x01 \x01(\tB\n" +
+	"\xe0A\x02\xbaH\x04r\x02\x10\x01R\n" +
+	"searchTerm\x12\x17\n" +
+	"\x04user\x18\x02 \x01(\tB\x03\xe0A\x01R\x04user\x12@\n" +
+	"\x04sort\x18\x03 \x01(\x0e2\x1f.githubsearchservice.SortOptionB\v\xe0A\x01\xbaH\x05\x82\x01\x02\x10\x01R\x04sort\x12C\n" +
+	"\x05order\x18\x04 \x01(\x0e2 .githubsearchservice.OrderOptionB\v\xe0A\x01\xbaH\x05\x82\x01\x02\x10\x01R\x05order\x12,\n" +
+	"\bper_page\x18\x05 \x01(\x05B\f\xe0A\x01\xbaH\x06\x1a\x04\x18d(\x01H\x00R\aperPage\x88\x01\x01\x12#\n" +
+	"\x04page\x18\x06 \x01(\x05B\n" +
+	"\xe0A\x01\xbaH\x04\x1a\x02(\x01H\x01R\x04page\x88\x01\x01\x12A\n" +
+	"\x05dedup\x18\a \x01(\x0e2\x1e.githubsearchservice.DedupModeB\v\xe0A\x01\xbaH\x05\x82\x01\x02\x10\x01R\x05dedup\x12X\n" +
+	"\x0erepresentative\x18\b \x01(\x0e2#.githubsearchservice.RepresentativeB\v\xe0A\x01\xbaH\x05\x82\x01\x02\x10\x01R\x0erepresentative\x129\n" +
+	"\rinclude_paths\x18\t \x03(\tB\x14\xe0A\x01\xbaH\x0e\x92\x01\v\x10d\"\ar\x05\x10\x01\x18\xc8\x01R\fincludePaths\x129\n" +
+	"\rexclude_paths\x18\n" +
+	" \x03(\tB\x14\xe0A\x01\xbaH\x0e\x92\x01\v\x10d\"\ar\x05\x10\x01\x18\xc8\x01R\fexcludePaths\x129\n" +
+	"\rinclude_repos\x18\v \x03(\tB\x14\xe0A\x01\xbaH\x0e\x92\x01\v\x10d\"\ar\x05\x10\x01\x18\xc8\x01R\fincludeRepos\x129\n" +
+	"\rexclude_repos\x18\f \x03(\tB\x14\xe0A\x01\xbaH\x0e\x92\x01\v\x10d\"\ar\x05\x10\x01\x18\xc8\x01R\fexcludeRepos\x12(\n" +
+	"\rexclude_forks\x18\r \x01(\bB\x03\xe0A\x01R\fexcludeForks\x12.\n" +
+	"\x10exclude_archived\x18\x0e \x01(\bB\x03\xe0A\x01R\x0fexcludeArchived\x12B\n" +
+	"\aranking\x18\x0f \x01(\v2#.githubsearchservice.RankingWeightsB\x03\xe0A\x01R\arankingB\v\n" +
+	"\t_per_pageB\a\n" +
+	"\x05_page\"\xac\x02\n" +
+	"\x0eRankingWeights\x12/\n" +
+	"\n" +
+	"path_depth\x18\x01 \x01(\x01B\x10\xbaH\r\x12\v@\x01)\x00\x00\x00\x00\x00\x00\x00\x00R\tpathDepth\x12+\n" +
+	"\bnon_test\x18\x02 \x01(\x01B\x10\xbaH\r\x12\v@\x01)\x00\x00\x00\x00\x00\x00\x00\x00R\anonTest\x12&\n" +
+	"\x05stars\x18\x03 \x01(\x01B\x10\xbaH\r\x12\v@\x01)\x00\x00\x00\x00\x00\x00\x00\x00R\x05stars\x12*\n" +
+	"\arecency\x18\x04 \x01(\x01B\x10\xbaH\r\x12\v@\x01)\x00\x00\x00\x00\x00\x00\x00\x00R\arecency\x123\n" +
+	"\fcaller_owned\x18\x05 \x01(\x01B\x10\xbaH\r\x12\v@\x01)\x00\x00\x00\x00\x00\x00\x00\x00R\vcallerOwned\x123\n" +
+	"\ftext_matches\x18\x06 \x01(\x01B\x10\xbaH\r\x12\v@\x01)\x00\x00\x00\x00\x00\x00\x00\x00R\vtextMatches\"G\n" +
+	"\x0eSearchResponse\x125\n" +
+	"\aresults\x18\x01 \x03(\v2\x1b.githubsearchservice.ResultR\aresults\"v\n" +
+	"\x06Result\x12\x19\n" +
+	"\bfile_url\x18\x01 \x01(\tR\afileUrl\x12\x12\n" +
+	"\x04repo\x18\x02 \x01(\tR\x04repo\x12'\n" +
+	"\x0fduplicate_count\x18\x03 \x01(\x05R\x0eduplicateCount\x12\x14\n" +
+	"\x05score\x18\x04 \x01(\x01R\x05score\"\x11\n" +
+	"\x0fGetQuotaRequest\"\x90\x02\n" +
+	"\x10GetQuotaResponse\x12.\n" +
+	"\x13requests_per_minute\x18\x01 \x01(\x05R\x11requestsPerMinute\x12-\n" +
+	"\x12requests_remaining\x18\x02 \x01(\x05R\x11requestsRemaining\x12&\n" +
+	"\x0fresults_per_day\x18\x03 \x01(\x05R\rresultsPerDay\x12+\n" +
+	"\x11results_remaining\x18\x04 \x01(\x05R\x10resultsRemaining\x12H\n" +
+	"\x12results_reset_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x10resultsResetTime\"j\n" +
+	"\x19ListRecentSearchesRequest\x12)\n" +
+	"\tpage_size\x18\x01 \x01(\x05B\f\xe0A\x01\xbaH\x06\x1a\x04\x18d(\x00R\bpageSize\x12\"\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tB\x03\xe0A\x01R\tpageToken\"\x9f\x01\n" +
+	"\fRecentSearch\x12<\n" +
+	"\arequest\x18\x01 \x01(\v2\".githubsearchservice.SearchRequestR\arequest\x12.\n" +
+	"\x04time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12!\n" +
+	"\fresult_count\x18\x03 \x01(\x05R\vresultCount\"\x83\x01\n" +
+	"\x1aListRecentSearchesResponse\x12=\n" +
+	"\bsearches\x18\x01 \x03(\v2!.githubsearchservice.RecentSearchR\bsearches\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"U\n" +
+	"\x1aDeleteSearchHistoryRequest\x127\n" +
+	"\x06before\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x01R\x06before\"B\n" +
+	"\x1bDeleteSearchHistoryResponse\x12#\n" +
+	"\rdeleted_count\x18\x01 \x01(\x05R\fdeletedCount\"\xa1\x03\n" +
+	"\vSavedSearch\x12\x13\n" +
+	"\x02id\x18\x01 \x01(\tB\x03\xe0A\x03R\x02id\x12 \n" +
+	"\x04name\x18\x02 \x01(\tB\f\xe0A\x02\xbaH\x06r\x04\x10\x01\x18dR\x04name\x12-\n" +
+	"\vdescription\x18\x03 \x01(\tB\v\xe0A\x01\xbaH\x05r\x03\x18\xe8\aR\vdescription\x12'\n" +
+	"\x04tags\x18\x04 \x03(\tB\x13\xe0A\x01\xbaH\r\x92\x01\n" +
+	"\x10\x14\"\x06r\x04\x10\x01\x182R\x04tags\x12G\n" +
+	"\arequest\x18\x05 \x01(\v2\".githubsearchservice.SearchRequestB\t\xe0A\x02\xbaH\x03\xc8\x01\x01R\arequest\x12\x19\n" +
+	"\x05owner\x18\x06 \x01(\tB\x03\xe0A\x03R\x05owner\x12\x1b\n" +
+	"\x06shared\x18\a \x01(\bB\x03\xe0A\x01R\x06shared\x12@\n" +
+	"\vcreate_time\x18\b \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03R\n" +
+	"createTime\x12@\n" +
+	"\vupdate_time\x18\t \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03R\n" +
+	"updateTime\"j\n" +
+	"\x18CreateSavedSearchRequest\x12N\n" +
+	"\fsaved_search\x18\x01 \x01(\v2 .githubsearchservice.SavedSearchB\t\xe0A\x02\xbaH\x03\xc8\x01\x01R\vsavedSearch\"3\n" +
+	"\x15GetSavedSearchRequest\x12\x1a\n" +
+	"\x02id\x18\x01 \x01(\tB\n" +
+	"\xe0A\x02\xbaH\x04r\x02\x10\x01R\x02id\"\x80\x01\n" +
+	"\x18ListSavedSearchesRequest\x12)\n" +
+	"\tpage_size\x18\x01 \x01(\x05B\f\xe0A\x01\xbaH\x06\x1a\x04\x18d(\x00R\bpageSize\x12\"\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tB\x03\xe0A\x01R\tpageToken\x12\x15\n" +
+	"\x03tag\x18\x03 \x01(\tB\x03\xe0A\x01R\x03tag\"\x8c\x01\n" +
+	"\x19ListSavedSearchesResponse\x12G\n" +
+	"\x0esaved_searches\x18\x01 \x03(\v2 .githubsearchservice.SavedSearchR\rsavedSearches\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xac\x01\n" +
+	"\x18UpdateSavedSearchRequest\x12N\n" +
+	"\fsaved_search\x18\x01 \x01(\v2 .githubsearchservice.SavedSearchB\t\xe0A\x02\xbaH\x03\xc8\x01\x01R\vsavedSearch\x12@\n" +
+	"\vupdate_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskB\x03\xe0A\x01R\n" +
+	"updateMask\"6\n" +
+	"\x18DeleteSavedSearchRequest\x12\x1a\n" +
+	"\x02id\x18\x01 \x01(\tB\n" +
+	"\xe0A\x02\xbaH\x04r\x02\x10\x01R\x02id\"\x1b\n" +
+	"\x19DeleteSavedSearchResponse\"a\n" +
+	"\x15RunSavedSearchRequest\x12\x1a\n" +
+	"\x02id\x18\x01 \x01(\tB\n" +
+	"\xe0A\x02\xbaH\x04r\x02\x10\x01R\x02id\x12#\n" +
+	"\x04page\x18\x02 \x01(\x05B\n" +
+	"\xe0A\x01\xbaH\x04\x1a\x02(\x01H\x00R\x04page\x88\x01\x01B\a\n" +
+	"\x05_page\"\xf4\x04\n" +
+	"\x05Watch\x12\x13\n" +
+	"\x02id\x18\x01 \x01(\tB\x03\xe0A\x03R\x02id\x12G\n" +
+	"\arequest\x18\x02 \x01(\v2\".githubsearchservice.SearchRequestB\t\xe0A\x02\xbaH\x03\xc8\x01\x01R\arequest\x12@\n" +
+	"\binterval\x18\x03 \x01(\v2\x19.google.protobuf.DurationB\t\xe0A\x02\xbaH\x03\xc8\x01\x01R\binterval\x12\x19\n" +
+	"\x05owner\x18\x04 \x01(\tB\x03\xe0A\x03R\x05owner\x12@\n" +
+	"\vcreate_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03R\n" +
+	"createTime\x12C\n" +
+	"\rlast_run_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03R\vlastRunTime\x12C\n" +
+	"\rnext_run_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03R\vnextRunTime\x12\"\n" +
+	"\n" +
+	"last_error\x18\b \x01(\tB\x03\xe0A\x03R\tlastError\x12,\n" +
+	"\vwebhook_url\x18\t \x01(\tB\v\xe0A\x01\xbaH\x05r\x03\x88\x01\x01R\n" +
+	"webhookUrl\x12*\n" +
+	"\x0ewebhook_secret\x18\n" +
+	" \x01(\tB\x03\xe0A\x04R\rwebhookSecret\x121\n" +
+	"\x12last_run_truncated\x18\v \x01(\bB\x03\xe0A\x03R\x10lastRunTruncated\x123\n" +
+	"\x13last_run_incomplete\x18\f \x01(\bB\x03\xe0A\x03R\x11lastRunIncomplete\"_\n" +
+	"\bWatchHit\x12\x12\n" +
+	"\x04repo\x18\x01 \x01(\tR\x04repo\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x10\n" +
+	"\x03sha\x18\x03 \x01(\tR\x03sha\x12\x19\n" +
+	"\bfile_url\x18\x04 \x01(\tR\afileUrl\"\xaa\x02\n" +
+	"\n" +
+	"WatchEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\bwatch_id\x18\x02 \x01(\tR\awatchId\x128\n" +
+	"\x04type\x18\x03 \x01(\x0e2$.githubsearchservice.WatchEvent.TypeR\x04type\x12/\n" +
+	"\x03hit\x18\x04 \x01(\v2\x1d.githubsearchservice.WatchHitR\x03hit\x12.\n" +
+	"\x04time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x16\n" +
+	"\x06cursor\x18\x06 \x01(\tR\x06cursor\">\n" +
+	"\x04Type\x12\x14\n" +
+	"\x10TYPE_UNSPECIFIED\x10\x00\x12\x0e\n" +
+	"\n" +
+	"TYPE_ADDED\x10\x01\x12\x10\n" +
+	"\fTYPE_REMOVED\x10\x02\"Q\n" +
+	"\x12CreateWatchRequest\x12;\n" +
+	"\x05watch\x18\x01 \x01(\v2\x1a.githubsearchservice.WatchB\t\xe0A\x02\xbaH\x03\xc8\x01\x01R\x05watch\"-\n" +
+	"\x0fGetWatchRequest\x12\x1a\n" +
+	"\x02id\x18\x01 \x01(\tB\n" +
+	"\xe0A\x02\xbaH\x04r\x02\x10\x01R\x02id\"c\n" +
+	"\x12ListWatchesRequest\x12)\n" +
+	"\tpage_size\x18\x01 \x01(\x05B\f\xe0A\x01\xbaH\x06\x1a\x04\x18d(\x00R\bpageSize\x12\"\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tB\x03\xe0A\x01R\tpageToken\"s\n" +
+	"\x13ListWatchesResponse\x124\n" +
+	"\awatches\x18\x01 \x03(\v2\x1a.githubsearchservice.WatchR\awatches\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"0\n" +
+	"\x12DeleteWatchRequest\x12\x1a\n" +
+	"\x02id\x18\x01 \x01(\tB\n" +
+	"\xe0A\x02\xbaH\x04r\x02\x10\x01R\x02id\"\x15\n" +
+	"\x13DeleteWatchResponse\"\x8e\x01\n" +
+	"\x16ListWatchEventsRequest\x12%\n" +
+	"\bwatch_id\x18\x01 \x01(\tB\n" +
+	"\xe0A\x02\xbaH\x04r\x02\x10\x01R\awatchId\x12)\n" +
+	"\tpage_size\x18\x02 \x01(\x05B\f\xe0A\x01\xbaH\x06\x1a\x04\x18d(\x00R\bpageSize\x12\"\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tB\x03\xe0A\x01R\tpageToken\"z\n" +
+	"\x17ListWatchEventsResponse\x127\n" +
+	"\x06events\x18\x01 \x03(\v2\x1f.githubsearchservice.WatchEventR\x06events\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"[\n" +
+	"\x15SubscribeWatchRequest\x12%\n" +
+	"\bwatch_id\x18\x01 \x01(\tB\n" +
+	"\xe0A\x02\xbaH\x04r\x02\x10\x01R\awatchId\x12\x1b\n" +
+	"\x06cursor\x18\x02 \x01(\tB\x03\xe0A\x01R\x06cursor\"\xd4\x01\n" +
+	"\x0eWebhookPayload\x12\x1f\n" +
+	"\vdelivery_id\x18\x01 \x01(\tR\n" +
+	"deliveryId\x12\x19\n" +
+	"\bwatch_id\x18\x02 \x01(\tR\awatchId\x12\x1f\n" +
+	"\vsearch_term\x18\x03 \x01(\tR\n" +
+	"searchTerm\x12.\n" +
+	"\x04time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x125\n" +
+	"\aresults\x18\x05 \x03(\v2\x1b.githubsearchservice.ResultR\aresults\"\xbb\x04\n" +
+	"\x0fWebhookDelivery\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\bwatch_id\x18\x02 \x01(\tR\awatchId\x12\x10\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\x12@\n" +
+	"\x05state\x18\x04 \x01(\x0e2*.githubsearchservice.WebhookDelivery.StateR\x05state\x12\x18\n" +
+	"\apayload\x18\x05 \x01(\tR\apayload\x12\x1a\n" +
+	"\battempts\x18\x06 \x01(\x05R\battempts\x12(\n" +
+	"\x10last_status_code\x18\a \x01(\x05R\x0elastStatusCode\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\b \x01(\tR\tlastError\x12;\n" +
+	"\vcreate_time\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\x12F\n" +
+	"\x11last_attempt_time\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\x0flastAttemptTime\x12F\n" +
+	"\x11next_attempt_time\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\x0fnextAttemptTime\"]\n" +
+	"\x05State\x12\x15\n" +
+	"\x11STATE_UNSPECIFIED\x10\x00\x12\x11\n" +
+	"\rSTATE_PENDING\x10\x01\x12\x13\n" +
+	"\x0fSTATE_SUCCEEDED\x10\x02\x12\x15\n" +
+	"\x11STATE_DEAD_LETTER\x10\x03\"\xd4\x01\n" +
+	"\x15ListDeliveriesRequest\x12%\n" +
+	"\bwatch_id\x18\x01 \x01(\tB\n" +
+	"\xe0A\x02\xbaH\x04r\x02\x10\x01R\awatchId\x12E\n" +
+	"\x05state\x18\x02 \x01(\x0e2*.githubsearchservice.WebhookDelivery.StateB\x03\xe0A\x01R\x05state\x12)\n" +
+	"\tpage_size\x18\x03 \x01(\x05B\f\xe0A\x01\xbaH\x06\x1a\x04\x18d(\x00R\bpageSize\x12\"\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tB\x03\xe0A\x01R\tpageToken\"\x86\x01\n" +
+	"\x16ListDeliveriesResponse\x12D\n" +
+	"\n" +
+	"deliveries\x18\x01 \x03(\v2$.githubsearchservice.WebhookDeliveryR\n" +
+	"deliveries\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xbc\x02\n" +
+	"\bSnapshot\x12\x13\n" +
+	"\x02id\x18\x01 \x01(\tB\x03\xe0A\x03R\x02id\x12A\n" +
+	"\arequest\x18\x02 \x01(\v2\".githubsearchservice.SearchRequestB\x03\xe0A\x03R\arequest\x12\x19\n" +
+	"\x05owner\x18\x03 \x01(\tB\x03\xe0A\x03R\x05owner\x12@\n" +
+	"\vcreate_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03R\n" +
+	"createTime\x12 \n" +
+	"\thit_count\x18\x05 \x01(\x05B\x03\xe0A\x03R\bhitCount\x12!\n" +
+	"\ttruncated\x18\x06 \x01(\bB\x03\xe0A\x03R\ttruncated\x126\n" +
+	"\x04hits\x18\a \x03(\v2\x1d.githubsearchservice.WatchHitB\x03\xe0A\x03R\x04hits\"`\n" +
+	"\x15CreateSnapshotRequest\x12G\n" +
+	"\arequest\x18\x01 \x01(\v2\".githubsearchservice.SearchRequestB\t\xe0A\x02\xbaH\x03\xc8\x01\x01R\arequest\"0\n" +
+	"\x12GetSnapshotRequest\x12\x1a\n" +
+	"\x02id\x18\x01 \x01(\tB\n" +
+	"\xe0A\x02\xbaH\x04r\x02\x10\x01R\x02id\"e\n" +
+	"\x14ListSnapshotsRequest\x12)\n" +
+	"\tpage_size\x18\x01 \x01(\x05B\f\xe0A\x01\xbaH\x06\x1a\x04\x18d(\x00R\bpageSize\x12\"\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tB\x03\xe0A\x01R\tpageToken\"|\n" +
+	"\x15ListSnapshotsResponse\x12;\n" +
+	"\tsnapshots\x18\x01 \x03(\v2\x1d.githubsearchservice.SnapshotR\tsnapshots\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"3\n" +
+	"\x15DeleteSnapshotRequest\x12\x1a\n" +
+	"\x02id\x18\x01 \x01(\tB\n" +
+	"\xe0A\x02\xbaH\x04r\x02\x10\x01R\x02id\"\x18\n" +
+	"\x16DeleteSnapshotResponse\"\xeb\x01\n" +
+	"\x11DiffSearchRequest\x12G\n" +
+	"\arequest\x18\x01 \x01(\v2\".githubsearchservice.SearchRequestB\t\xe0A\x02\xbaH\x03\xc8\x01\x01R\arequest\x124\n" +
+	"\x10base_snapshot_id\x18\x02 \x01(\tB\n" +
+	"\xe0A\x02\xbaH\x04r\x02\x10\x01R\x0ebaseSnapshotId\x121\n" +
+	"\x12target_snapshot_id\x18\x03 \x01(\tB\x03\xe0A\x01R\x10targetSnapshotId\x12$\n" +
+	"\vsave_target\x18\x04 \x01(\bB\x03\xe0A\x01R\n" +
+	"saveTarget\"u\n" +
+	"\n" +
+	"ChangedHit\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x19\n" +
+	"\bbase_sha\x18\x02 \x01(\tR\abaseSha\x12\x1d\n" +
+	"\n" +
+	"target_sha\x18\x03 \x01(\tR\ttargetSha\x12\x19\n" +
+	"\bfile_url\x18\x04 \x01(\tR\afileUrl\"\xb8\x02\n" +
+	"\x0eRepositoryDiff\x12\x12\n" +
+	"\x04repo\x18\x01 \x01(\tR\x04repo\x123\n" +
+	"\x05added\x18\x02 \x03(\v2\x1d.githubsearchservice.WatchHitR\x05added\x127\n" +
+	"\aremoved\x18\x03 \x03(\v2\x1d.githubsearchservice.WatchHitR\aremoved\x129\n" +
+	"\achanged\x18\x04 \x03(\v2\x1f.githubsearchservice.ChangedHitR\achanged\x12\x1f\n" +
+	"\vadded_count\x18\x05 \x01(\x05R\n" +
+	"addedCount\x12#\n" +
+	"\rremoved_count\x18\x06 \x01(\x05R\fremovedCount\x12#\n" +
+	"\rchanged_count\x18\a \x01(\x05R\fchangedCount\"\x8a\x03\n" +
+	"\x12DiffSearchResponse\x12G\n" +
+	"\frepositories\x18\x01 \x03(\v2#.githubsearchservice.RepositoryDiffR\frepositories\x12\x1f\n" +
+	"\vadded_count\x18\x02 \x01(\x05R\n" +
+	"addedCount\x12#\n" +
+	"\rremoved_count\x18\x03 \x01(\x05R\fremovedCount\x12#\n" +
+	"\rchanged_count\x18\x04 \x01(\x05R\fchangedCount\x127\n" +
+	"\tbase_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\bbaseTime\x12;\n" +
+	"\vtarget_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"targetTime\x12,\n" +
+	"\x12target_snapshot_id\x18\a \x01(\tR\x10targetSnapshotId\x12\x1c\n" +
+	"\ttruncated\x18\b \x01(\bR\ttruncated\"\x96\x01\n" +
+	"\x12BatchSearchRequest\x12K\n" +
+	"\brequests\x18\x01 \x03(\v2\".githubsearchservice.SearchRequestB\v\xe0A\x02\xbaH\x05\x92\x01\x02\b\x01R\brequests\x123\n" +
+	"\x0fmax_concurrency\x18\x02 \x01(\x05B\n" +
+	"\xe0A\x01\xbaH\x04\x1a\x02(\x00R\x0emaxConcurrency\"\xa7\x01\n" +
+	"\x11BatchSearchResult\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12?\n" +
+	"\bresponse\x18\x02 \x01(\v2#.githubsearchservice.SearchResponseR\bresponse\x12;\n" +
+	"\x05error\x18\x03 \x01(\v2%.githubsearchservice.BatchSearchErrorR\x05error\"@\n" +
+	"\x10BatchSearchError\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"~\n" +
+	"\x13BatchSearchResponse\x12D\n" +
+	"\tresponses\x18\x01 \x03(\v2&.githubsearchservice.BatchSearchResultR\tresponses\x12!\n" +
+	"\ffailed_count\x18\x02 \x01(\x05R\vfailedCount\"\xbb\x01\n" +
+	"\x13SearchFacetsRequest\x12G\n" +
+	"\arequest\x18\x01 \x01(\v2\".githubsearchservice.SearchRequestB\t\xe0A\x02\xbaH\x03\xc8\x01\x01R\arequest\x12.\n" +
+	"\vmax_results\x18\x02 \x01(\x05B\r\xe0A\x01\xbaH\a\x1a\x05\x18\xe8\a(\x00R\n" +
+	"maxResults\x12+\n" +
+	"\n" +
+	"facet_size\x18\x03 \x01(\x05B\f\xe0A\x01\xbaH\x06\x1a\x04\x18d(\x00R\tfacetSize\"8\n" +
+	"\n" +
+	"FacetCount\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\"\xbb\x03\n" +
+	"\x14SearchFacetsResponse\x12C\n" +
+	"\frepositories\x18\x01 \x03(\v2\x1f.githubsearchservice.FacetCountR\frepositories\x127\n" +
+	"\x06owners\x18\x02 \x03(\v2\x1f.githubsearchservice.FacetCountR\x06owners\x12?\n" +
+	"\n" +
+	"extensions\x18\x03 \x03(\v2\x1f.githubsearchservice.FacetCountR\n" +
+	"extensions\x12=\n" +
+	"\tlanguages\x18\x04 \x03(\v2\x1f.githubsearchservice.FacetCountR\tlanguages\x12A\n" +
+	"\vdirectories\x18\x05 \x03(\v2\x1f.githubsearchservice.FacetCountR\vdirectories\x12\x1f\n" +
+	"\vtotal_count\x18\x06 \x01(\x05R\n" +
+	"totalCount\x12%\n" +
+	"\x0eanalyzed_count\x18\a \x01(\x05R\ranalyzedCount\x12\x1a\n" +
+	"\bcoverage\x18\b \x01(\x01R\bcoverage\"\xbc\x01\n" +
+	"\x17ExhaustiveSearchRequest\x12G\n" +
+	"\arequest\x18\x01 \x01(\v2\".githubsearchservice.SearchRequestB\t\xe0A\x02\xbaH\x03\xc8\x01\x01R\arequest\x12+\n" +
+	"\vmax_results\x18\x02 \x01(\x05B\n" +
+	"\xe0A\x01\xbaH\x04\x1a\x02(\x00R\n" +
+	"maxResults\x12+\n" +
+	"\vmax_queries\x18\x03 \x01(\x05B\n" +
+	"\xe0A\x01\xbaH\x04\x1a\x02(\x00R\n" +
+	"maxQueries\"m\n" +
+	"\x0fExhaustiveShard\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x05R\n" +
+	"totalCount\x12#\n" +
+	"\rfetched_count\x18\x03 \x01(\x05R\ffetchedCount\"\xd8\x02\n" +
+	"\x18ExhaustiveSearchResponse\x121\n" +
+	"\x04hits\x18\x01 \x03(\v2\x1d.githubsearchservice.WatchHitR\x04hits\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x05R\n" +
+	"totalCount\x12\x1b\n" +
+	"\thit_count\x18\x03 \x01(\x05R\bhitCount\x12\x1a\n" +
+	"\bcoverage\x18\x04 \x01(\x01R\bcoverage\x12\x1a\n" +
+	"\bcomplete\x18\x05 \x01(\bR\bcomplete\x12\x1f\n" +
+	"\vquery_count\x18\x06 \x01(\x05R\n" +
+	"queryCount\x12\x1f\n" +
+	"\vshard_count\x18\a \x01(\x05R\n" +
+	"shardCount\x12Q\n" +
+	"\x11incomplete_shards\x18\b \x03(\v2$.githubsearchservice.ExhaustiveShardR\x10incompleteShards*B\n" +
+	"\tDedupMode\x12\x15\n" +
+	"\x11DEDUP_UNSPECIFIED\x10\x00\x12\x0e\n" +
+	"\n" +
+	"DEDUP_BLOB\x10\x01\x12\x0e\n" +
+	"\n" +
+	"DEDUP_FORK\x10\x02*l\n" +
+	"\x0eRepresentative\x12\x1e\n" +
+	"\x1aREPRESENTATIVE_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17REPRESENTATIVE_ORIGINAL\x10\x01\x12\x1d\n" +
+	"\x19REPRESENTATIVE_MOST_STARS\x10\x02*4\n" +
+	"\n" +
+	"SortOption\x12\x14\n" +
+	"\x10SORT_UNSPECIFIED\x10\x00\x12\x10\n" +
+	"\fSORT_INDEXED\x10\x01*C\n" +
+	"\vOrderOption\x12\x15\n" +
+	"\x11ORDER_UNSPECIFIED\x10\x00\x12\r\n" +
+	"\tORDER_ASC\x10\x01\x12\x0e\n" +
+	"\n" +
+	"ORDER_DESC\x10\x022\xcf\x14\n" +
+	"\x13GithubSearchService\x12Q\n" +
+	"\x06Search\x12\".githubsearchservice.SearchRequest\x1a#.githubsearchservice.SearchResponse\x12W\n" +
+	"\bGetQuota\x12$.githubsearchservice.GetQuotaRequest\x1a%.githubsearchservice.GetQuotaResponse\x12u\n" +
+	"\x12ListRecentSearches\x12..githubsearchservice.ListRecentSearchesRequest\x1a/.githubsearchservice.ListRecentSearchesResponse\x12x\n" +
+	"\x13DeleteSearchHistory\x12/.githubsearchservice.DeleteSearchHistoryRequest\x1a0.githubsearchservice.DeleteSearchHistoryResponse\x12d\n" +
+	"\x11CreateSavedSearch\x12-.githubsearchservice.CreateSavedSearchRequest\x1a .githubsearchservice.SavedSearch\x12^\n" +
+	"\x0eGetSavedSearch\x12*.githubsearchservice.GetSavedSearchRequest\x1a .githubsearchservice.SavedSearch\x12r\n" +
+	"\x11ListSavedSearches\x12-.githubsearchservice.ListSavedSearchesRequest\x1a..githubsearchservice.ListSavedSearchesResponse\x12d\n" +
+	"\x11UpdateSavedSearch\x12-.githubsearchservice.UpdateSavedSearchRequest\x1a .githubsearchservice.SavedSearch\x12r\n" +
+	"\x11DeleteSavedSearch\x12-.githubsearchservice.DeleteSavedSearchRequest\x1a..githubsearchservice.DeleteSavedSearchResponse\x12a\n" +
+	"\x0eRunSavedSearch\x12*.githubsearchservice.RunSavedSearchRequest\x1a#.githubsearchservice.SearchResponse\x12R\n" +
+	"\vCreateWatch\x12'.githubsearchservice.CreateWatchRequest\x1a\x1a.githubsearchservice.Watch\x12L\n" +
+	"\bGetWatch\x12$.githubsearchservice.GetWatchRequest\x1a\x1a.githubsearchservice.Watch\x12`\n" +
+	"\vListWatches\x12'.githubsearchservice.ListWatchesRequest\x1a(.githubsearchservice.ListWatchesResponse\x12`\n" +
+	"\vDeleteWatch\x12'.githubsearchservice.DeleteWatchRequest\x1a(.githubsearchservice.DeleteWatchResponse\x12l\n" +
+	"\x0fListWatchEvents\x12+.githubsearchservice.ListWatchEventsRequest\x1a,.githubsearchservice.ListWatchEventsResponse\x12i\n" +
+	"\x0eListDeliveries\x12*.githubsearchservice.ListDeliveriesRequest\x1a+.githubsearchservice.ListDeliveriesResponse\x12_\n" +
+	"\x0eSubscribeWatch\x12*.githubsearchservice.SubscribeWatchRequest\x1a\x1f.githubsearchservice.WatchEvent0\x01\x12[\n" +
+	"\x0eCreateSnapshot\x12*.githubsearchservice.CreateSnapshotRequest\x1a\x1d.githubsearchservice.Snapshot\x12U\n" +
+	"\vGetSnapshot\x12'.githubsearchservice.GetSnapshotRequest\x1a\x1d.githubsearchservice.Snapshot\x12f\n" +
+	"\rListSnapshots\x12).githubsearchservice.ListSnapshotsRequest\x1a*.githubsearchservice.ListSnapshotsResponse\x12i\n" +
+	"\x0eDeleteSnapshot\x12*.githubsearchservice.DeleteSnapshotRequest\x1a+.githubsearchservice.DeleteSnapshotResponse\x12]\n" +
+	"\n" +
+	"DiffSearch\x12&.githubsearchservice.DiffSearchRequest\x1a'.githubsearchservice.DiffSearchResponse\x12`\n" +
+	"\vBatchSearch\x12'.githubsearchservice.BatchSearchRequest\x1a(.githubsearchservice.BatchSearchResponse\x12f\n" +
+	"\x11StreamBatchSearch\x12'.githubsearchservice.BatchSearchRequest\x1a&.githubsearchservice.BatchSearchResult0\x01\x12c\n" +
+	"\fSearchFacets\x12(.githubsearchservice.SearchFacetsRequest\x1a).githubsearchservice.SearchFacetsResponse\x12o\n" +
+	"\x10ExhaustiveSearch\x12,.githubsearchservice.ExhaustiveSearchRequest\x1a-.githubsearchservice.ExhaustiveSearchResponseB3Z1github.com/Pratham700/github-search-service/protob\x06proto3"
 
 var (
 	file_proto_github_search_service_proto_rawDescOnce sync.Once
@@ -340,26 +4400,196 @@ func file_proto_github_search_service_proto_rawDescGZIP() []byte {
 	return file_proto_github_search_service_proto_rawDescData
 }
 
-var file_proto_github_search_service_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_proto_github_search_service_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_proto_github_search_service_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_proto_github_search_service_proto_msgTypes = make([]protoimpl.MessageInfo, 57)
 var file_proto_github_search_service_proto_goTypes = []any{
-	(SortOption)(0),        // 0: githubsearchservice.SortOption
-	(OrderOption)(0),       // 1: githubsearchservice.OrderOption
-	(*SearchRequest)(nil),  // 2: githubsearchservice.SearchRequest
-	(*SearchResponse)(nil), // 3: githubsearchservice.SearchResponse
-	(*Result)(nil),         // 4: githubsearchservice.Result
+	(DedupMode)(0),                      // 0: githubsearchservice.DedupMode
+	(Representative)(0),                 // 1: githubsearchservice.Representative
+	(SortOption)(0),                     // 2: githubsearchservice.SortOption
+	(OrderOption)(0),                    // 3: githubsearchservice.OrderOption
+	(WatchEvent_Type)(0),                // 4: githubsearchservice.WatchEvent.Type
+	(WebhookDelivery_State)(0),          // 5: githubsearchservice.WebhookDelivery.State
+	(*SearchRequest)(nil),               // 6: githubsearchservice.SearchRequest
+	(*RankingWeights)(nil),              // 7: githubsearchservice.RankingWeights
+	(*SearchResponse)(nil),              // 8: githubsearchservice.SearchResponse
+	(*Result)(nil),                      // 9: githubsearchservice.Result
+	(*GetQuotaRequest)(nil),             // 10: githubsearchservice.GetQuotaRequest
+	(*GetQuotaResponse)(nil),            // 11: githubsearchservice.GetQuotaResponse
+	(*ListRecentSearchesRequest)(nil),   // 12: githubsearchservice.ListRecentSearchesRequest
+	(*RecentSearch)(nil),                // 13: githubsearchservice.RecentSearch
+	(*ListRecentSearchesResponse)(nil),  // 14: githubsearchservice.ListRecentSearchesResponse
+	(*DeleteSearchHistoryRequest)(nil),  // 15: githubsearchservice.DeleteSearchHistoryRequest
+	(*DeleteSearchHistoryResponse)(nil), // 16: githubsearchservice.DeleteSearchHistoryResponse
+	(*SavedSearch)(nil),                 // 17: githubsearchservice.SavedSearch
+	(*CreateSavedSearchRequest)(nil),    // 18: githubsearchservice.CreateSavedSearchRequest
+	(*GetSavedSearchRequest)(nil),       // 19: githubsearchservice.GetSavedSearchRequest
+	(*ListSavedSearchesRequest)(nil),    // 20: githubsearchservice.ListSavedSearchesRequest
+	(*ListSavedSearchesResponse)(nil),   // 21: githubsearchservice.ListSavedSearchesResponse
+	(*UpdateSavedSearchRequest)(nil),    // 22: githubsearchservice.UpdateSavedSearchRequest
+	(*DeleteSavedSearchRequest)(nil),    // 23: githubsearchservice.DeleteSavedSearchRequest
+	(*DeleteSavedSearchResponse)(nil),   // 24: githubsearchservice.DeleteSavedSearchResponse
+	(*RunSavedSearchRequest)(nil),       // 25: githubsearchservice.RunSavedSearchRequest
+	(*Watch)(nil),                       // 26: githubsearchservice.Watch
+	(*WatchHit)(nil),                    // 27: githubsearchservice.WatchHit
+	(*WatchEvent)(nil),                  // 28: githubsearchservice.WatchEvent
+	(*CreateWatchRequest)(nil),          // 29: githubsearchservice.CreateWatchRequest
+	(*GetWatchRequest)(nil),             // 30: githubsearchservice.GetWatchRequest
+	(*ListWatchesRequest)(nil),          // 31: githubsearchservice.ListWatchesRequest
+	(*ListWatchesResponse)(nil),         // 32: githubsearchservice.ListWatchesResponse
+	(*DeleteWatchRequest)(nil),          // 33: githubsearchservice.DeleteWatchRequest
+	(*DeleteWatchResponse)(nil),         // 34: githubsearchservice.DeleteWatchResponse
+	(*ListWatchEventsRequest)(nil),      // 35: githubsearchservice.ListWatchEventsRequest
+	(*ListWatchEventsResponse)(nil),     // 36: githubsearchservice.ListWatchEventsResponse
+	(*SubscribeWatchRequest)(nil),       // 37: githubsearchservice.SubscribeWatchRequest
+	(*WebhookPayload)(nil),              // 38: githubsearchservice.WebhookPayload
+	(*WebhookDelivery)(nil),             // 39: githubsearchservice.WebhookDelivery
+	(*ListDeliveriesRequest)(nil),       // 40: githubsearchservice.ListDeliveriesRequest
+	(*ListDeliveriesResponse)(nil),      // 41: githubsearchservice.ListDeliveriesResponse
+	(*Snapshot)(nil),                    // 42: githubsearchservice.Snapshot
+	(*CreateSnapshotRequest)(nil),       // 43: githubsearchservice.CreateSnapshotRequest
+	(*GetSnapshotRequest)(nil),          // 44: githubsearchservice.GetSnapshotRequest
+	(*ListSnapshotsRequest)(nil),        // 45: githubsearchservice.ListSnapshotsRequest
+	(*ListSnapshotsResponse)(nil),       // 46: githubsearchservice.ListSnapshotsResponse
+	(*DeleteSnapshotRequest)(nil),       // 47: githubsearchservice.DeleteSnapshotRequest
+	(*DeleteSnapshotResponse)(nil),      // 48: githubsearchservice.DeleteSnapshotResponse
+	(*DiffSearchRequest)(nil),           // 49: githubsearchservice.DiffSearchRequest
+	(*ChangedHit)(nil),                  // 50: githubsearchservice.ChangedHit
+	(*RepositoryDiff)(nil),              // 51: githubsearchservice.RepositoryDiff
+	(*DiffSearchResponse)(nil),          // 52: githubsearchservice.DiffSearchResponse
+	(*BatchSearchRequest)(nil),          // 53: githubsearchservice.BatchSearchRequest
+	(*BatchSearchResult)(nil),           // 54: githubsearchservice.BatchSearchResult
+	(*BatchSearchError)(nil),            // 55: githubsearchservice.BatchSearchError
+	(*BatchSearchResponse)(nil),         // 56: githubsearchservice.BatchSearchResponse
+	(*SearchFacetsRequest)(nil),         // 57: githubsearchservice.SearchFacetsRequest
+	(*FacetCount)(nil),                  // 58: githubsearchservice.FacetCount
+	(*SearchFacetsResponse)(nil),        // 59: githubsearchservice.SearchFacetsResponse
+	(*ExhaustiveSearchRequest)(nil),     // 60: githubsearchservice.ExhaustiveSearchRequest
+	(*ExhaustiveShard)(nil),             // 61: githubsearchservice.ExhaustiveShard
+	(*ExhaustiveSearchResponse)(nil),    // 62: githubsearchservice.ExhaustiveSearchResponse
+	(*timestamppb.Timestamp)(nil),       // 63: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),       // 64: google.protobuf.FieldMask
+	(*durationpb.Duration)(nil),         // 65: google.protobuf.Duration
 }
 var file_proto_github_search_service_proto_depIdxs = []int32{
-	0, // 0: githubsearchservice.SearchRequest.sort:type_name -> githubsearchservice.SortOption
-	1, // 1: githubsearchservice.SearchRequest.order:type_name -> githubsearchservice.OrderOption
-	4, // 2: githubsearchservice.SearchResponse.results:type_name -> githubsearchservice.Result
-	2, // 3: githubsearchservice.GithubSearchService.Search:input_type -> githubsearchservice.SearchRequest
-	3, // 4: githubsearchservice.GithubSearchService.Search:output_type -> githubsearchservice.SearchResponse
-	4, // [4:5] is the sub-list for method output_type
-	3, // [3:4] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	2,  // 0: githubsearchservice.SearchRequest.sort:type_name -> githubsearchservice.SortOption
+	3,  // 1: githubsearchservice.SearchRequest.order:type_name -> githubsearchservice.OrderOption
+	0,  // 2: githubsearchservice.SearchRequest.dedup:type_name -> githubsearchservice.DedupMode
+	1,  // 3: githubsearchservice.SearchRequest.representative:type_name -> githubsearchservice.Representative
+	7,  // 4: githubsearchservice.SearchRequest.ranking:type_name -> githubsearchservice.RankingWeights
+	9,  // 5: githubsearchservice.SearchResponse.results:type_name -> githubsearchservice.Result
+	63, // 6: githubsearchservice.GetQuotaResponse.results_reset_time:type_name -> google.protobuf.Timestamp
+	6,  // 7: githubsearchservice.RecentSearch.request:type_name -> githubsearchservice.SearchRequest
+	63, // 8: githubsearchservice.RecentSearch.time:type_name -> google.protobuf.Timestamp
+	13, // 9: githubsearchservice.ListRecentSearchesResponse.searches:type_name -> githubsearchservice.RecentSearch
+	63, // 10: githubsearchservice.DeleteSearchHistoryRequest.before:type_name -> google.protobuf.Timestamp
+	6,  // 11: githubsearchservice.SavedSearch.request:type_name -> githubsearchservice.SearchRequest
+	63, // 12: githubsearchservice.SavedSearch.create_time:type_name -> google.protobuf.Timestamp
+	63, // 13: githubsearchservice.SavedSearch.update_time:type_name -> google.protobuf.Timestamp
+	17, // 14: githubsearchservice.CreateSavedSearchRequest.saved_search:type_name -> githubsearchservice.SavedSearch
+	17, // 15: githubsearchservice.ListSavedSearchesResponse.saved_searches:type_name -> githubsearchservice.SavedSearch
+	17, // 16: githubsearchservice.UpdateSavedSearchRequest.saved_search:type_name -> githubsearchservice.SavedSearch
+	64, // 17: githubsearchservice.UpdateSavedSearchRequest.update_mask:type_name -> google.protobuf.FieldMask
+	6,  // 18: githubsearchservice.Watch.request:type_name -> githubsearchservice.SearchRequest
+	65, // 19: githubsearchservice.Watch.interval:type_name -> google.protobuf.Duration
+	63, // 20: githubsearchservice.Watch.create_time:type_name -> google.protobuf.Timestamp
+	63, // 21: githubsearchservice.Watch.last_run_time:type_name -> google.protobuf.Timestamp
+	63, // 22: githubsearchservice.Watch.next_run_time:type_name -> google.protobuf.Timestamp
+	4,  // 23: githubsearchservice.WatchEvent.type:type_name -> githubsearchservice.WatchEvent.Type
+	27, // 24: githubsearchservice.WatchEvent.hit:type_name -> githubsearchservice.WatchHit
+	63, // 25: githubsearchservice.WatchEvent.time:type_name -> google.protobuf.Timestamp
+	26, // 26: githubsearchservice.CreateWatchRequest.watch:type_name -> githubsearchservice.Watch
+	26, // 27: githubsearchservice.ListWatchesResponse.watches:type_name -> githubsearchservice.Watch
+	28, // 28: githubsearchservice.ListWatchEventsResponse.events:type_name -> githubsearchservice.WatchEvent
+	63, // 29: githubsearchservice.WebhookPayload.time:type_name -> google.protobuf.Timestamp
+	9,  // 30: githubsearchservice.WebhookPayload.results:type_name -> githubsearchservice.Result
+	5,  // 31: githubsearchservice.WebhookDelivery.state:type_name -> githubsearchservice.WebhookDelivery.State
+	63, // 32: githubsearchservice.WebhookDelivery.create_time:type_name -> google.protobuf.Timestamp
+	63, // 33: githubsearchservice.WebhookDelivery.last_attempt_time:type_name -> google.protobuf.Timestamp
+	63, // 34: githubsearchservice.WebhookDelivery.next_attempt_time:type_name -> google.protobuf.Timestamp
+	5,  // 35: githubsearchservice.ListDeliveriesRequest.state:type_name -> githubsearchservice.WebhookDelivery.State
+	39, // 36: githubsearchservice.ListDeliveriesResponse.deliveries:type_name -> githubsearchservice.WebhookDelivery
+	6,  // 37: githubsearchservice.Snapshot.request:type_name -> githubsearchservice.SearchRequest
+	63, // 38: githubsearchservice.Snapshot.create_time:type_name -> google.protobuf.Timestamp
+	27, // 39: githubsearchservice.Snapshot.hits:type_name -> githubsearchservice.WatchHit
+	6,  // 40: githubsearchservice.CreateSnapshotRequest.request:type_name -> githubsearchservice.SearchRequest
+	42, // 41: githubsearchservice.ListSnapshotsResponse.snapshots:type_name -> githubsearchservice.Snapshot
+	6,  // 42: githubsearchservice.DiffSearchRequest.request:type_name -> githubsearchservice.SearchRequest
+	27, // 43: githubsearchservice.RepositoryDiff.added:type_name -> githubsearchservice.WatchHit
+	27, // 44: githubsearchservice.RepositoryDiff.removed:type_name -> githubsearchservice.WatchHit
+	50, // 45: githubsearchservice.RepositoryDiff.changed:type_name -> githubsearchservice.ChangedHit
+	51, // 46: githubsearchservice.DiffSearchResponse.repositories:type_name -> githubsearchservice.RepositoryDiff
+	63, // 47: githubsearchservice.DiffSearchResponse.base_time:type_name -> google.protobuf.Timestamp
+	63, // 48: githubsearchservice.DiffSearchResponse.target_time:type_name -> google.protobuf.Timestamp
+	6,  // 49: githubsearchservice.BatchSearchRequest.requests:type_name -> githubsearchservice.SearchRequest
+	8,  // 50: githubsearchservice.BatchSearchResult.response:type_name -> githubsearchservice.SearchResponse
+	55, // 51: githubsearchservice.BatchSearchResult.error:type_name -> githubsearchservice.BatchSearchError
+	54, // 52: githubsearchservice.BatchSearchResponse.responses:type_name -> githubsearchservice.BatchSearchResult
+	6,  // 53: githubsearchservice.SearchFacetsRequest.request:type_name -> githubsearchservice.SearchRequest
+	58, // 54: githubsearchservice.SearchFacetsResponse.repositories:type_name -> githubsearchservice.FacetCount
+	58, // 55: githubsearchservice.SearchFacetsResponse.owners:type_name -> githubsearchservice.FacetCount
+	58, // 56: githubsearchservice.SearchFacetsResponse.extensions:type_name -> githubsearchservice.FacetCount
+	58, // 57: githubsearchservice.SearchFacetsResponse.languages:type_name -> githubsearchservice.FacetCount
+	58, // 58: githubsearchservice.SearchFacetsResponse.directories:type_name -> githubsearchservice.FacetCount
+	6,  // 59: githubsearchservice.ExhaustiveSearchRequest.request:type_name -> githubsearchservice.SearchRequest
+	27, // 60: githubsearchservice.ExhaustiveSearchResponse.hits:type_name -> githubsearchservice.WatchHit
+	61, // 61: githubsearchservice.ExhaustiveSearchResponse.incomplete_shards:type_name -> githubsearchservice.ExhaustiveShard
+	6,  // 62: githubsearchservice.GithubSearchService.Search:input_type -> githubsearchservice.SearchRequest
+	10, // 63: githubsearchservice.GithubSearchService.GetQuota:input_type -> githubsearchservice.GetQuotaRequest
+	12, // 64: githubsearchservice.GithubSearchService.ListRecentSearches:input_type -> githubsearchservice.ListRecentSearchesRequest
+	15, // 65: githubsearchservice.GithubSearchService.DeleteSearchHistory:input_type -> githubsearchservice.DeleteSearchHistoryRequest
+	18, // 66: githubsearchservice.GithubSearchService.CreateSavedSearch:input_type -> githubsearchservice.CreateSavedSearchRequest
+	19, // 67: githubsearchservice.GithubSearchService.GetSavedSearch:input_type -> githubsearchservice.GetSavedSearchRequest
+	20, // 68: githubsearchservice.GithubSearchService.ListSavedSearches:input_type -> githubsearchservice.ListSavedSearchesRequest
+	22, // 69: githubsearchservice.GithubSearchService.UpdateSavedSearch:input_type -> githubsearchservice.UpdateSavedSearchRequest
+	23, // 70: githubsearchservice.GithubSearchService.DeleteSavedSearch:input_type -> githubsearchservice.DeleteSavedSearchRequest
+	25, // 71: githubsearchservice.GithubSearchService.RunSavedSearch:input_type -> githubsearchservice.RunSavedSearchRequest
+	29, // 72: githubsearchservice.GithubSearchService.CreateWatch:input_type -> githubsearchservice.CreateWatchRequest
+	30, // 73: githubsearchservice.GithubSearchService.GetWatch:input_type -> githubsearchservice.GetWatchRequest
+	31, // 74: githubsearchservice.GithubSearchService.ListWatches:input_type -> githubsearchservice.ListWatchesRequest
+	33, // 75: githubsearchservice.GithubSearchService.DeleteWatch:input_type -> githubsearchservice.DeleteWatchRequest
+	35, // 76: githubsearchservice.GithubSearchService.ListWatchEvents:input_type -> githubsearchservice.ListWatchEventsRequest
+	40, // 77: githubsearchservice.GithubSearchService.ListDeliveries:input_type -> githubsearchservice.ListDeliveriesRequest
+	37, // 78: githubsearchservice.GithubSearchService.SubscribeWatch:input_type -> githubsearchservice.SubscribeWatchRequest
+	43, // 79: githubsearchservice.GithubSearchService.CreateSnapshot:input_type -> githubsearchservice.CreateSnapshotRequest
+	44, // 80: githubsearchservice.GithubSearchService.GetSnapshot:input_type -> githubsearchservice.GetSnapshotRequest
+	45, // 81: githubsearchservice.GithubSearchService.ListSnapshots:input_type -> githubsearchservice.ListSnapshotsRequest
+	47, // 82: githubsearchservice.GithubSearchService.DeleteSnapshot:input_type -> githubsearchservice.DeleteSnapshotRequest
+	49, // 83: githubsearchservice.GithubSearchService.DiffSearch:input_type -> githubsearchservice.DiffSearchRequest
+	53, // 84: githubsearchservice.GithubSearchService.BatchSearch:input_type -> githubsearchservice.BatchSearchRequest
+	53, // 85: githubsearchservice.GithubSearchService.StreamBatchSearch:input_type -> githubsearchservice.BatchSearchRequest
+	57, // 86: githubsearchservice.GithubSearchService.SearchFacets:input_type -> githubsearchservice.SearchFacetsRequest
+	60, // 87: githubsearchservice.GithubSearchService.ExhaustiveSearch:input_type -> githubsearchservice.ExhaustiveSearchRequest
+	8,  // 88: githubsearchservice.GithubSearchService.Search:output_type -> githubsearchservice.SearchResponse
+	11, // 89: githubsearchservice.GithubSearchService.GetQuota:output_type -> githubsearchservice.GetQuotaResponse
+	14, // 90: githubsearchservice.GithubSearchService.ListRecentSearches:output_type -> githubsearchservice.ListRecentSearchesResponse
+	16, // 91: githubsearchservice.GithubSearchService.DeleteSearchHistory:output_type -> githubsearchservice.DeleteSearchHistoryResponse
+	17, // 92: githubsearchservice.GithubSearchService.CreateSavedSearch:output_type -> githubsearchservice.SavedSearch
+	17, // 93: githubsearchservice.GithubSearchService.GetSavedSearch:output_type -> githubsearchservice.SavedSearch
+	21, // 94: githubsearchservice.GithubSearchService.ListSavedSearches:output_type -> githubsearchservice.ListSavedSearchesResponse
+	17, // 95: githubsearchservice.GithubSearchService.UpdateSavedSearch:output_type -> githubsearchservice.SavedSearch
+	24, // 96: githubsearchservice.GithubSearchService.DeleteSavedSearch:output_type -> githubsearchservice.DeleteSavedSearchResponse
+	8,  // 97: githubsearchservice.GithubSearchService.RunSavedSearch:output_type -> githubsearchservice.SearchResponse
+	26, // 98: githubsearchservice.GithubSearchService.CreateWatch:output_type -> githubsearchservice.Watch
+	26, // 99: githubsearchservice.GithubSearchService.GetWatch:output_type -> githubsearchservice.Watch
+	32, // 100: githubsearchservice.GithubSearchService.ListWatches:output_type -> githubsearchservice.ListWatchesResponse
+	34, // 101: githubsearchservice.GithubSearchService.DeleteWatch:output_type -> githubsearchservice.DeleteWatchResponse
+	36, // 102: githubsearchservice.GithubSearchService.ListWatchEvents:output_type -> githubsearchservice.ListWatchEventsResponse
+	41, // 103: githubsearchservice.GithubSearchService.ListDeliveries:output_type -> githubsearchservice.ListDeliveriesResponse
+	28, // 104: githubsearchservice.GithubSearchService.SubscribeWatch:output_type -> githubsearchservice.WatchEvent
+	42, // 105: githubsearchservice.GithubSearchService.CreateSnapshot:output_type -> githubsearchservice.Snapshot
+	42, // 106: githubsearchservice.GithubSearchService.GetSnapshot:output_type -> githubsearchservice.Snapshot
+	46, // 107: githubsearchservice.GithubSearchService.ListSnapshots:output_type -> githubsearchservice.ListSnapshotsResponse
+	48, // 108: githubsearchservice.GithubSearchService.DeleteSnapshot:output_type -> githubsearchservice.DeleteSnapshotResponse
+	52, // 109: githubsearchservice.GithubSearchService.DiffSearch:output_type -> githubsearchservice.DiffSearchResponse
+	56, // 110: githubsearchservice.GithubSearchService.BatchSearch:output_type -> githubsearchservice.BatchSearchResponse
+	54, // 111: githubsearchservice.GithubSearchService.StreamBatchSearch:output_type -> githubsearchservice.BatchSearchResult
+	59, // 112: githubsearchservice.GithubSearchService.SearchFacets:output_type -> githubsearchservice.SearchFacetsResponse
+	62, // 113: githubsearchservice.GithubSearchService.ExhaustiveSearch:output_type -> githubsearchservice.ExhaustiveSearchResponse
+	88, // [88:114] is the sub-list for method output_type
+	62, // [62:88] is the sub-list for method input_type
+	62, // [62:62] is the sub-list for extension type_name
+	62, // [62:62] is the sub-list for extension extendee
+	0,  // [0:62] is the sub-list for field type_name
 }
 
 func init() { file_proto_github_search_service_proto_init() }
@@ -368,13 +4598,14 @@ func file_proto_github_search_service_proto_init() {
 		return
 	}
 	file_proto_github_search_service_proto_msgTypes[0].OneofWrappers = []any{}
+	file_proto_github_search_service_proto_msgTypes[19].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_github_search_service_proto_rawDesc), len(file_proto_github_search_service_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   3,
+			NumEnums:      6,
+			NumMessages:   57,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
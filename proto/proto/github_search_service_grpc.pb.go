@@ -19,7 +19,32 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	GithubSearchService_Search_FullMethodName = "/githubsearchservice.GithubSearchService/Search"
+	GithubSearchService_Search_FullMethodName              = "/githubsearchservice.GithubSearchService/Search"
+	GithubSearchService_GetQuota_FullMethodName            = "/githubsearchservice.GithubSearchService/GetQuota"
+	GithubSearchService_ListRecentSearches_FullMethodName  = "/githubsearchservice.GithubSearchService/ListRecentSearches"
+	GithubSearchService_DeleteSearchHistory_FullMethodName = "/githubsearchservice.GithubSearchService/DeleteSearchHistory"
+	GithubSearchService_CreateSavedSearch_FullMethodName   = "/githubsearchservice.GithubSearchService/CreateSavedSearch"
+	GithubSearchService_GetSavedSearch_FullMethodName      = "/githubsearchservice.GithubSearchService/GetSavedSearch"
+	GithubSearchService_ListSavedSearches_FullMethodName   = "/githubsearchservice.GithubSearchService/ListSavedSearches"
+	GithubSearchService_UpdateSavedSearch_FullMethodName   = "/githubsearchservice.GithubSearchService/UpdateSavedSearch"
+	GithubSearchService_DeleteSavedSearch_FullMethodName   = "/githubsearchservice.GithubSearchService/DeleteSavedSearch"
+	GithubSearchService_RunSavedSearch_FullMethodName      = "/githubsearchservice.GithubSearchService/RunSavedSearch"
+	GithubSearchService_CreateWatch_FullMethodName         = "/githubsearchservice.GithubSearchService/CreateWatch"
+	GithubSearchService_GetWatch_FullMethodName            = "/githubsearchservice.GithubSearchService/GetWatch"
+	GithubSearchService_ListWatches_FullMethodName         = "/githubsearchservice.GithubSearchService/ListWatches"
+	GithubSearchService_DeleteWatch_FullMethodName         = "/githubsearchservice.GithubSearchService/DeleteWatch"
+	GithubSearchService_ListWatchEvents_FullMethodName     = "/githubsearchservice.GithubSearchService/ListWatchEvents"
+	GithubSearchService_ListDeliveries_FullMethodName      = "/githubsearchservice.GithubSearchService/ListDeliveries"
+	GithubSearchService_SubscribeWatch_FullMethodName      = "/githubsearchservice.GithubSearchService/SubscribeWatch"
+	GithubSearchService_CreateSnapshot_FullMethodName      = "/githubsearchservice.GithubSearchService/CreateSnapshot"
+	GithubSearchService_GetSnapshot_FullMethodName         = "/githubsearchservice.GithubSearchService/GetSnapshot"
+	GithubSearchService_ListSnapshots_FullMethodName       = "/githubsearchservice.GithubSearchService/ListSnapshots"
+	GithubSearchService_DeleteSnapshot_FullMethodName      = "/githubsearchservice.GithubSearchService/DeleteSnapshot"
+	GithubSearchService_DiffSearch_FullMethodName          = "/githubsearchservice.GithubSearchService/DiffSearch"
+	GithubSearchService_BatchSearch_FullMethodName         = "/githubsearchservice.GithubSearchService/BatchSearch"
+	GithubSearchService_StreamBatchSearch_FullMethodName   = "/githubsearchservice.GithubSearchService/StreamBatchSearch"
+	GithubSearchService_SearchFacets_FullMethodName        = "/githubsearchservice.GithubSearchService/SearchFacets"
+	GithubSearchService_ExhaustiveSearch_FullMethodName    = "/githubsearchservice.GithubSearchService/ExhaustiveSearch"
 )
 
 // GithubSearchServiceClient is the client API for GithubSearchService service.
@@ -27,6 +52,31 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type GithubSearchServiceClient interface {
 	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
+	GetQuota(ctx context.Context, in *GetQuotaRequest, opts ...grpc.CallOption) (*GetQuotaResponse, error)
+	ListRecentSearches(ctx context.Context, in *ListRecentSearchesRequest, opts ...grpc.CallOption) (*ListRecentSearchesResponse, error)
+	DeleteSearchHistory(ctx context.Context, in *DeleteSearchHistoryRequest, opts ...grpc.CallOption) (*DeleteSearchHistoryResponse, error)
+	CreateSavedSearch(ctx context.Context, in *CreateSavedSearchRequest, opts ...grpc.CallOption) (*SavedSearch, error)
+	GetSavedSearch(ctx context.Context, in *GetSavedSearchRequest, opts ...grpc.CallOption) (*SavedSearch, error)
+	ListSavedSearches(ctx context.Context, in *ListSavedSearchesRequest, opts ...grpc.CallOption) (*ListSavedSearchesResponse, error)
+	UpdateSavedSearch(ctx context.Context, in *UpdateSavedSearchRequest, opts ...grpc.CallOption) (*SavedSearch, error)
+	DeleteSavedSearch(ctx context.Context, in *DeleteSavedSearchRequest, opts ...grpc.CallOption) (*DeleteSavedSearchResponse, error)
+	RunSavedSearch(ctx context.Context, in *RunSavedSearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
+	CreateWatch(ctx context.Context, in *CreateWatchRequest, opts ...grpc.CallOption) (*Watch, error)
+	GetWatch(ctx context.Context, in *GetWatchRequest, opts ...grpc.CallOption) (*Watch, error)
+	ListWatches(ctx context.Context, in *ListWatchesRequest, opts ...grpc.CallOption) (*ListWatchesResponse, error)
+	DeleteWatch(ctx context.Context, in *DeleteWatchRequest, opts ...grpc.CallOption) (*DeleteWatchResponse, error)
+	ListWatchEvents(ctx context.Context, in *ListWatchEventsRequest, opts ...grpc.CallOption) (*ListWatchEventsResponse, error)
+	ListDeliveries(ctx context.Context, in *ListDeliveriesRequest, opts ...grpc.CallOption) (*ListDeliveriesResponse, error)
+	SubscribeWatch(ctx context.Context, in *SubscribeWatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchEvent], error)
+	CreateSnapshot(ctx context.Context, in *CreateSnapshotRequest, opts ...grpc.CallOption) (*Snapshot, error)
+	GetSnapshot(ctx context.Context, in *GetSnapshotRequest, opts ...grpc.CallOption) (*Snapshot, error)
+	ListSnapshots(ctx context.Context, in *ListSnapshotsRequest, opts ...grpc.CallOption) (*ListSnapshotsResponse, error)
+	DeleteSnapshot(ctx context.Context, in *DeleteSnapshotRequest, opts ...grpc.CallOption) (*DeleteSnapshotResponse, error)
+	DiffSearch(ctx context.Context, in *DiffSearchRequest, opts ...grpc.CallOption) (*DiffSearchResponse, error)
+	BatchSearch(ctx context.Context, in *BatchSearchRequest, opts ...grpc.CallOption) (*BatchSearchResponse, error)
+	StreamBatchSearch(ctx context.Context, in *BatchSearchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BatchSearchResult], error)
+	SearchFacets(ctx context.Context, in *SearchFacetsRequest, opts ...grpc.CallOption) (*SearchFacetsResponse, error)
+	ExhaustiveSearch(ctx context.Context, in *ExhaustiveSearchRequest, opts ...grpc.CallOption) (*ExhaustiveSearchResponse, error)
 }
 
 type githubSearchServiceClient struct {
@@ -47,11 +97,304 @@ func (c *githubSearchServiceClient) Search(ctx context.Context, in *SearchReques
 	return out, nil
 }
 
+func (c *githubSearchServiceClient) GetQuota(ctx context.Context, in *GetQuotaRequest, opts ...grpc.CallOption) (*GetQuotaResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetQuotaResponse)
+	err := c.cc.Invoke(ctx, GithubSearchService_GetQuota_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *githubSearchServiceClient) ListRecentSearches(ctx context.Context, in *ListRecentSearchesRequest, opts ...grpc.CallOption) (*ListRecentSearchesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRecentSearchesResponse)
+	err := c.cc.Invoke(ctx, GithubSearchService_ListRecentSearches_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *githubSearchServiceClient) DeleteSearchHistory(ctx context.Context, in *DeleteSearchHistoryRequest, opts ...grpc.CallOption) (*DeleteSearchHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteSearchHistoryResponse)
+	err := c.cc.Invoke(ctx, GithubSearchService_DeleteSearchHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *githubSearchServiceClient) CreateSavedSearch(ctx context.Context, in *CreateSavedSearchRequest, opts ...grpc.CallOption) (*SavedSearch, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SavedSearch)
+	err := c.cc.Invoke(ctx, GithubSearchService_CreateSavedSearch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *githubSearchServiceClient) GetSavedSearch(ctx context.Context, in *GetSavedSearchRequest, opts ...grpc.CallOption) (*SavedSearch, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SavedSearch)
+	err := c.cc.Invoke(ctx, GithubSearchService_GetSavedSearch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *githubSearchServiceClient) ListSavedSearches(ctx context.Context, in *ListSavedSearchesRequest, opts ...grpc.CallOption) (*ListSavedSearchesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSavedSearchesResponse)
+	err := c.cc.Invoke(ctx, GithubSearchService_ListSavedSearches_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *githubSearchServiceClient) UpdateSavedSearch(ctx context.Context, in *UpdateSavedSearchRequest, opts ...grpc.CallOption) (*SavedSearch, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SavedSearch)
+	err := c.cc.Invoke(ctx, GithubSearchService_UpdateSavedSearch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *githubSearchServiceClient) DeleteSavedSearch(ctx context.Context, in *DeleteSavedSearchRequest, opts ...grpc.CallOption) (*DeleteSavedSearchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteSavedSearchResponse)
+	err := c.cc.Invoke(ctx, GithubSearchService_DeleteSavedSearch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *githubSearchServiceClient) RunSavedSearch(ctx context.Context, in *RunSavedSearchRequest, opts ...grpc.CallOption) (*SearchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchResponse)
+	err := c.cc.Invoke(ctx, GithubSearchService_RunSavedSearch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *githubSearchServiceClient) CreateWatch(ctx context.Context, in *CreateWatchRequest, opts ...grpc.CallOption) (*Watch, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Watch)
+	err := c.cc.Invoke(ctx, GithubSearchService_CreateWatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *githubSearchServiceClient) GetWatch(ctx context.Context, in *GetWatchRequest, opts ...grpc.CallOption) (*Watch, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Watch)
+	err := c.cc.Invoke(ctx, GithubSearchService_GetWatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *githubSearchServiceClient) ListWatches(ctx context.Context, in *ListWatchesRequest, opts ...grpc.CallOption) (*ListWatchesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWatchesResponse)
+	err := c.cc.Invoke(ctx, GithubSearchService_ListWatches_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *githubSearchServiceClient) DeleteWatch(ctx context.Context, in *DeleteWatchRequest, opts ...grpc.CallOption) (*DeleteWatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteWatchResponse)
+	err := c.cc.Invoke(ctx, GithubSearchService_DeleteWatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *githubSearchServiceClient) ListWatchEvents(ctx context.Context, in *ListWatchEventsRequest, opts ...grpc.CallOption) (*ListWatchEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWatchEventsResponse)
+	err := c.cc.Invoke(ctx, GithubSearchService_ListWatchEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *githubSearchServiceClient) ListDeliveries(ctx context.Context, in *ListDeliveriesRequest, opts ...grpc.CallOption) (*ListDeliveriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDeliveriesResponse)
+	err := c.cc.Invoke(ctx, GithubSearchService_ListDeliveries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *githubSearchServiceClient) SubscribeWatch(ctx context.Context, in *SubscribeWatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &GithubSearchService_ServiceDesc.Streams[0], GithubSearchService_SubscribeWatch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeWatchRequest, WatchEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GithubSearchService_SubscribeWatchClient = grpc.ServerStreamingClient[WatchEvent]
+
+func (c *githubSearchServiceClient) CreateSnapshot(ctx context.Context, in *CreateSnapshotRequest, opts ...grpc.CallOption) (*Snapshot, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Snapshot)
+	err := c.cc.Invoke(ctx, GithubSearchService_CreateSnapshot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *githubSearchServiceClient) GetSnapshot(ctx context.Context, in *GetSnapshotRequest, opts ...grpc.CallOption) (*Snapshot, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Snapshot)
+	err := c.cc.Invoke(ctx, GithubSearchService_GetSnapshot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *githubSearchServiceClient) ListSnapshots(ctx context.Context, in *ListSnapshotsRequest, opts ...grpc.CallOption) (*ListSnapshotsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSnapshotsResponse)
+	err := c.cc.Invoke(ctx, GithubSearchService_ListSnapshots_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *githubSearchServiceClient) DeleteSnapshot(ctx context.Context, in *DeleteSnapshotRequest, opts ...grpc.CallOption) (*DeleteSnapshotResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteSnapshotResponse)
+	err := c.cc.Invoke(ctx, GithubSearchService_DeleteSnapshot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *githubSearchServiceClient) DiffSearch(ctx context.Context, in *DiffSearchRequest, opts ...grpc.CallOption) (*DiffSearchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiffSearchResponse)
+	err := c.cc.Invoke(ctx, GithubSearchService_DiffSearch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *githubSearchServiceClient) BatchSearch(ctx context.Context, in *BatchSearchRequest, opts ...grpc.CallOption) (*BatchSearchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchSearchResponse)
+	err := c.cc.Invoke(ctx, GithubSearchService_BatchSearch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *githubSearchServiceClient) StreamBatchSearch(ctx context.Context, in *BatchSearchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BatchSearchResult], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &GithubSearchService_ServiceDesc.Streams[1], GithubSearchService_StreamBatchSearch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[BatchSearchRequest, BatchSearchResult]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GithubSearchService_StreamBatchSearchClient = grpc.ServerStreamingClient[BatchSearchResult]
+
+func (c *githubSearchServiceClient) SearchFacets(ctx context.Context, in *SearchFacetsRequest, opts ...grpc.CallOption) (*SearchFacetsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchFacetsResponse)
+	err := c.cc.Invoke(ctx, GithubSearchService_SearchFacets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *githubSearchServiceClient) ExhaustiveSearch(ctx context.Context, in *ExhaustiveSearchRequest, opts ...grpc.CallOption) (*ExhaustiveSearchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExhaustiveSearchResponse)
+	err := c.cc.Invoke(ctx, GithubSearchService_ExhaustiveSearch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GithubSearchServiceServer is the server API for GithubSearchService service.
 // All implementations must embed UnimplementedGithubSearchServiceServer
 // for forward compatibility.
 type GithubSearchServiceServer interface {
 	Search(context.Context, *SearchRequest) (*SearchResponse, error)
+	GetQuota(context.Context, *GetQuotaRequest) (*GetQuotaResponse, error)
+	ListRecentSearches(context.Context, *ListRecentSearchesRequest) (*ListRecentSearchesResponse, error)
+	DeleteSearchHistory(context.Context, *DeleteSearchHistoryRequest) (*DeleteSearchHistoryResponse, error)
+	CreateSavedSearch(context.Context, *CreateSavedSearchRequest) (*SavedSearch, error)
+	GetSavedSearch(context.Context, *GetSavedSearchRequest) (*SavedSearch, error)
+	ListSavedSearches(context.Context, *ListSavedSearchesRequest) (*ListSavedSearchesResponse, error)
+	UpdateSavedSearch(context.Context, *UpdateSavedSearchRequest) (*SavedSearch, error)
+	DeleteSavedSearch(context.Context, *DeleteSavedSearchRequest) (*DeleteSavedSearchResponse, error)
+	RunSavedSearch(context.Context, *RunSavedSearchRequest) (*SearchResponse, error)
+	CreateWatch(context.Context, *CreateWatchRequest) (*Watch, error)
+	GetWatch(context.Context, *GetWatchRequest) (*Watch, error)
+	ListWatches(context.Context, *ListWatchesRequest) (*ListWatchesResponse, error)
+	DeleteWatch(context.Context, *DeleteWatchRequest) (*DeleteWatchResponse, error)
+	ListWatchEvents(context.Context, *ListWatchEventsRequest) (*ListWatchEventsResponse, error)
+	ListDeliveries(context.Context, *ListDeliveriesRequest) (*ListDeliveriesResponse, error)
+	SubscribeWatch(*SubscribeWatchRequest, grpc.ServerStreamingServer[WatchEvent]) error
+	CreateSnapshot(context.Context, *CreateSnapshotRequest) (*Snapshot, error)
+	GetSnapshot(context.Context, *GetSnapshotRequest) (*Snapshot, error)
+	ListSnapshots(context.Context, *ListSnapshotsRequest) (*ListSnapshotsResponse, error)
+	DeleteSnapshot(context.Context, *DeleteSnapshotRequest) (*DeleteSnapshotResponse, error)
+	DiffSearch(context.Context, *DiffSearchRequest) (*DiffSearchResponse, error)
+	BatchSearch(context.Context, *BatchSearchRequest) (*BatchSearchResponse, error)
+	StreamBatchSearch(*BatchSearchRequest, grpc.ServerStreamingServer[BatchSearchResult]) error
+	SearchFacets(context.Context, *SearchFacetsRequest) (*SearchFacetsResponse, error)
+	ExhaustiveSearch(context.Context, *ExhaustiveSearchRequest) (*ExhaustiveSearchResponse, error)
 	mustEmbedUnimplementedGithubSearchServiceServer()
 }
 
@@ -65,6 +408,81 @@ type UnimplementedGithubSearchServiceServer struct{}
 func (UnimplementedGithubSearchServiceServer) Search(context.Context, *SearchRequest) (*SearchResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
 }
+func (UnimplementedGithubSearchServiceServer) GetQuota(context.Context, *GetQuotaRequest) (*GetQuotaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetQuota not implemented")
+}
+func (UnimplementedGithubSearchServiceServer) ListRecentSearches(context.Context, *ListRecentSearchesRequest) (*ListRecentSearchesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRecentSearches not implemented")
+}
+func (UnimplementedGithubSearchServiceServer) DeleteSearchHistory(context.Context, *DeleteSearchHistoryRequest) (*DeleteSearchHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSearchHistory not implemented")
+}
+func (UnimplementedGithubSearchServiceServer) CreateSavedSearch(context.Context, *CreateSavedSearchRequest) (*SavedSearch, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSavedSearch not implemented")
+}
+func (UnimplementedGithubSearchServiceServer) GetSavedSearch(context.Context, *GetSavedSearchRequest) (*SavedSearch, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSavedSearch not implemented")
+}
+func (UnimplementedGithubSearchServiceServer) ListSavedSearches(context.Context, *ListSavedSearchesRequest) (*ListSavedSearchesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSavedSearches not implemented")
+}
+func (UnimplementedGithubSearchServiceServer) UpdateSavedSearch(context.Context, *UpdateSavedSearchRequest) (*SavedSearch, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSavedSearch not implemented")
+}
+func (UnimplementedGithubSearchServiceServer) DeleteSavedSearch(context.Context, *DeleteSavedSearchRequest) (*DeleteSavedSearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSavedSearch not implemented")
+}
+func (UnimplementedGithubSearchServiceServer) RunSavedSearch(context.Context, *RunSavedSearchRequest) (*SearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RunSavedSearch not implemented")
+}
+func (UnimplementedGithubSearchServiceServer) CreateWatch(context.Context, *CreateWatchRequest) (*Watch, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWatch not implemented")
+}
+func (UnimplementedGithubSearchServiceServer) GetWatch(context.Context, *GetWatchRequest) (*Watch, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWatch not implemented")
+}
+func (UnimplementedGithubSearchServiceServer) ListWatches(context.Context, *ListWatchesRequest) (*ListWatchesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWatches not implemented")
+}
+func (UnimplementedGithubSearchServiceServer) DeleteWatch(context.Context, *DeleteWatchRequest) (*DeleteWatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWatch not implemented")
+}
+func (UnimplementedGithubSearchServiceServer) ListWatchEvents(context.Context, *ListWatchEventsRequest) (*ListWatchEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWatchEvents not implemented")
+}
+func (UnimplementedGithubSearchServiceServer) ListDeliveries(context.Context, *ListDeliveriesRequest) (*ListDeliveriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeliveries not implemented")
+}
+func (UnimplementedGithubSearchServiceServer) SubscribeWatch(*SubscribeWatchRequest, grpc.ServerStreamingServer[WatchEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeWatch not implemented")
+}
+func (UnimplementedGithubSearchServiceServer) CreateSnapshot(context.Context, *CreateSnapshotRequest) (*Snapshot, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSnapshot not implemented")
+}
+func (UnimplementedGithubSearchServiceServer) GetSnapshot(context.Context, *GetSnapshotRequest) (*Snapshot, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSnapshot not implemented")
+}
+func (UnimplementedGithubSearchServiceServer) ListSnapshots(context.Context, *ListSnapshotsRequest) (*ListSnapshotsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSnapshots not implemented")
+}
+func (UnimplementedGithubSearchServiceServer) DeleteSnapshot(context.Context, *DeleteSnapshotRequest) (*DeleteSnapshotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSnapshot not implemented")
+}
+func (UnimplementedGithubSearchServiceServer) DiffSearch(context.Context, *DiffSearchRequest) (*DiffSearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiffSearch not implemented")
+}
+func (UnimplementedGithubSearchServiceServer) BatchSearch(context.Context, *BatchSearchRequest) (*BatchSearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchSearch not implemented")
+}
+func (UnimplementedGithubSearchServiceServer) StreamBatchSearch(*BatchSearchRequest, grpc.ServerStreamingServer[BatchSearchResult]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamBatchSearch not implemented")
+}
+func (UnimplementedGithubSearchServiceServer) SearchFacets(context.Context, *SearchFacetsRequest) (*SearchFacetsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchFacets not implemented")
+}
+func (UnimplementedGithubSearchServiceServer) ExhaustiveSearch(context.Context, *ExhaustiveSearchRequest) (*ExhaustiveSearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExhaustiveSearch not implemented")
+}
 func (UnimplementedGithubSearchServiceServer) mustEmbedUnimplementedGithubSearchServiceServer() {}
 func (UnimplementedGithubSearchServiceServer) testEmbeddedByValue()                             {}
 
@@ -104,18 +522,557 @@ func _GithubSearchService_Search_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
-// GithubSearchService_ServiceDesc is the grpc.ServiceDesc for GithubSearchService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var GithubSearchService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "githubsearchservice.GithubSearchService",
-	HandlerType: (*GithubSearchServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Search",
-			Handler:    _GithubSearchService_Search_Handler,
+func _GithubSearchService_GetQuota_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetQuotaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GithubSearchServiceServer).GetQuota(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GithubSearchService_GetQuota_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GithubSearchServiceServer).GetQuota(ctx, req.(*GetQuotaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GithubSearchService_ListRecentSearches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRecentSearchesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GithubSearchServiceServer).ListRecentSearches(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GithubSearchService_ListRecentSearches_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GithubSearchServiceServer).ListRecentSearches(ctx, req.(*ListRecentSearchesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GithubSearchService_DeleteSearchHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSearchHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GithubSearchServiceServer).DeleteSearchHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GithubSearchService_DeleteSearchHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GithubSearchServiceServer).DeleteSearchHistory(ctx, req.(*DeleteSearchHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GithubSearchService_CreateSavedSearch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSavedSearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GithubSearchServiceServer).CreateSavedSearch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GithubSearchService_CreateSavedSearch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GithubSearchServiceServer).CreateSavedSearch(ctx, req.(*CreateSavedSearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GithubSearchService_GetSavedSearch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSavedSearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GithubSearchServiceServer).GetSavedSearch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GithubSearchService_GetSavedSearch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GithubSearchServiceServer).GetSavedSearch(ctx, req.(*GetSavedSearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GithubSearchService_ListSavedSearches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSavedSearchesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GithubSearchServiceServer).ListSavedSearches(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GithubSearchService_ListSavedSearches_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GithubSearchServiceServer).ListSavedSearches(ctx, req.(*ListSavedSearchesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GithubSearchService_UpdateSavedSearch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSavedSearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GithubSearchServiceServer).UpdateSavedSearch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GithubSearchService_UpdateSavedSearch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GithubSearchServiceServer).UpdateSavedSearch(ctx, req.(*UpdateSavedSearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GithubSearchService_DeleteSavedSearch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSavedSearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GithubSearchServiceServer).DeleteSavedSearch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GithubSearchService_DeleteSavedSearch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GithubSearchServiceServer).DeleteSavedSearch(ctx, req.(*DeleteSavedSearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GithubSearchService_RunSavedSearch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunSavedSearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GithubSearchServiceServer).RunSavedSearch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GithubSearchService_RunSavedSearch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GithubSearchServiceServer).RunSavedSearch(ctx, req.(*RunSavedSearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GithubSearchService_CreateWatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GithubSearchServiceServer).CreateWatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GithubSearchService_CreateWatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GithubSearchServiceServer).CreateWatch(ctx, req.(*CreateWatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GithubSearchService_GetWatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GithubSearchServiceServer).GetWatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GithubSearchService_GetWatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GithubSearchServiceServer).GetWatch(ctx, req.(*GetWatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GithubSearchService_ListWatches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWatchesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GithubSearchServiceServer).ListWatches(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GithubSearchService_ListWatches_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GithubSearchServiceServer).ListWatches(ctx, req.(*ListWatchesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GithubSearchService_DeleteWatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GithubSearchServiceServer).DeleteWatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GithubSearchService_DeleteWatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GithubSearchServiceServer).DeleteWatch(ctx, req.(*DeleteWatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GithubSearchService_ListWatchEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWatchEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GithubSearchServiceServer).ListWatchEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GithubSearchService_ListWatchEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GithubSearchServiceServer).ListWatchEvents(ctx, req.(*ListWatchEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GithubSearchService_ListDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeliveriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GithubSearchServiceServer).ListDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GithubSearchService_ListDeliveries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GithubSearchServiceServer).ListDeliveries(ctx, req.(*ListDeliveriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GithubSearchService_SubscribeWatch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeWatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GithubSearchServiceServer).SubscribeWatch(m, &grpc.GenericServerStream[SubscribeWatchRequest, WatchEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GithubSearchService_SubscribeWatchServer = grpc.ServerStreamingServer[WatchEvent]
+
+func _GithubSearchService_CreateSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GithubSearchServiceServer).CreateSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GithubSearchService_CreateSnapshot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GithubSearchServiceServer).CreateSnapshot(ctx, req.(*CreateSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GithubSearchService_GetSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GithubSearchServiceServer).GetSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GithubSearchService_GetSnapshot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GithubSearchServiceServer).GetSnapshot(ctx, req.(*GetSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GithubSearchService_ListSnapshots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSnapshotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GithubSearchServiceServer).ListSnapshots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GithubSearchService_ListSnapshots_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GithubSearchServiceServer).ListSnapshots(ctx, req.(*ListSnapshotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GithubSearchService_DeleteSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GithubSearchServiceServer).DeleteSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GithubSearchService_DeleteSnapshot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GithubSearchServiceServer).DeleteSnapshot(ctx, req.(*DeleteSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GithubSearchService_DiffSearch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffSearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GithubSearchServiceServer).DiffSearch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GithubSearchService_DiffSearch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GithubSearchServiceServer).DiffSearch(ctx, req.(*DiffSearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GithubSearchService_BatchSearch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchSearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GithubSearchServiceServer).BatchSearch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GithubSearchService_BatchSearch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GithubSearchServiceServer).BatchSearch(ctx, req.(*BatchSearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GithubSearchService_StreamBatchSearch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BatchSearchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GithubSearchServiceServer).StreamBatchSearch(m, &grpc.GenericServerStream[BatchSearchRequest, BatchSearchResult]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GithubSearchService_StreamBatchSearchServer = grpc.ServerStreamingServer[BatchSearchResult]
+
+func _GithubSearchService_SearchFacets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchFacetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GithubSearchServiceServer).SearchFacets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GithubSearchService_SearchFacets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GithubSearchServiceServer).SearchFacets(ctx, req.(*SearchFacetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GithubSearchService_ExhaustiveSearch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExhaustiveSearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GithubSearchServiceServer).ExhaustiveSearch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GithubSearchService_ExhaustiveSearch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GithubSearchServiceServer).ExhaustiveSearch(ctx, req.(*ExhaustiveSearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// GithubSearchService_ServiceDesc is the grpc.ServiceDesc for GithubSearchService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GithubSearchService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "githubsearchservice.GithubSearchService",
+	HandlerType: (*GithubSearchServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Search",
+			Handler:    _GithubSearchService_Search_Handler,
+		},
+		{
+			MethodName: "GetQuota",
+			Handler:    _GithubSearchService_GetQuota_Handler,
+		},
+		{
+			MethodName: "ListRecentSearches",
+			Handler:    _GithubSearchService_ListRecentSearches_Handler,
+		},
+		{
+			MethodName: "DeleteSearchHistory",
+			Handler:    _GithubSearchService_DeleteSearchHistory_Handler,
+		},
+		{
+			MethodName: "CreateSavedSearch",
+			Handler:    _GithubSearchService_CreateSavedSearch_Handler,
+		},
+		{
+			MethodName: "GetSavedSearch",
+			Handler:    _GithubSearchService_GetSavedSearch_Handler,
+		},
+		{
+			MethodName: "ListSavedSearches",
+			Handler:    _GithubSearchService_ListSavedSearches_Handler,
+		},
+		{
+			MethodName: "UpdateSavedSearch",
+			Handler:    _GithubSearchService_UpdateSavedSearch_Handler,
+		},
+		{
+			MethodName: "DeleteSavedSearch",
+			Handler:    _GithubSearchService_DeleteSavedSearch_Handler,
+		},
+		{
+			MethodName: "RunSavedSearch",
+			Handler:    _GithubSearchService_RunSavedSearch_Handler,
+		},
+		{
+			MethodName: "CreateWatch",
+			Handler:    _GithubSearchService_CreateWatch_Handler,
+		},
+		{
+			MethodName: "GetWatch",
+			Handler:    _GithubSearchService_GetWatch_Handler,
+		},
+		{
+			MethodName: "ListWatches",
+			Handler:    _GithubSearchService_ListWatches_Handler,
+		},
+		{
+			MethodName: "DeleteWatch",
+			Handler:    _GithubSearchService_DeleteWatch_Handler,
+		},
+		{
+			MethodName: "ListWatchEvents",
+			Handler:    _GithubSearchService_ListWatchEvents_Handler,
+		},
+		{
+			MethodName: "ListDeliveries",
+			Handler:    _GithubSearchService_ListDeliveries_Handler,
+		},
+		{
+			MethodName: "CreateSnapshot",
+			Handler:    _GithubSearchService_CreateSnapshot_Handler,
+		},
+		{
+			MethodName: "GetSnapshot",
+			Handler:    _GithubSearchService_GetSnapshot_Handler,
+		},
+		{
+			MethodName: "ListSnapshots",
+			Handler:    _GithubSearchService_ListSnapshots_Handler,
+		},
+		{
+			MethodName: "DeleteSnapshot",
+			Handler:    _GithubSearchService_DeleteSnapshot_Handler,
+		},
+		{
+			MethodName: "DiffSearch",
+			Handler:    _GithubSearchService_DiffSearch_Handler,
+		},
+		{
+			MethodName: "BatchSearch",
+			Handler:    _GithubSearchService_BatchSearch_Handler,
+		},
+		{
+			MethodName: "SearchFacets",
+			Handler:    _GithubSearchService_SearchFacets_Handler,
+		},
+		{
+			MethodName: "ExhaustiveSearch",
+			Handler:    _GithubSearchService_ExhaustiveSearch_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeWatch",
+			Handler:       _GithubSearchService_SubscribeWatch_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamBatchSearch",
+			Handler:       _GithubSearchService_StreamBatchSearch_Handler,
+			ServerStreams: true,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/github_search_service.proto",
 }